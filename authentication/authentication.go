@@ -0,0 +1,170 @@
+// Package authentication lets a backend authenticate users who log in with Apillon's
+// embedded auth and wallet login: generating session tokens for the login widget,
+// verifying the login tokens it returns and looking up user and wallet identities.
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Apillon/go-sdk/requests"
+)
+
+// AuthError represents an error that occurred during authentication operations.
+// Kind classifies the error and can be matched with errors.Is, e.g. errors.Is(err, ErrInvalidToken).
+type AuthError struct {
+	Code    int
+	Message string
+	Kind    error
+	Err     error
+}
+
+func (e *AuthError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("authentication error (code %d): %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("authentication error (code %d): %s", e.Code, e.Message)
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the kind of the error.
+func (e *AuthError) Is(target error) bool {
+	return e.Kind != nil && e.Kind == target
+}
+
+// Error codes
+const (
+	ErrCodeInvalidInput = 40000001
+)
+
+// Error kinds
+var (
+	ErrInvalidInput = errors.New("invalid input")                    // Arguments were rejected before sending the request
+	ErrInvalidToken = errors.New("invalid or expired login token")   // The login token was rejected
+	ErrUnauthorized = errors.New("unauthorized")                     // The API key is missing, invalid or lacks permissions
+	ErrNotFound     = errors.New("not found")                        // The requested user or identity does not exist
+	ErrRateLimited  = errors.New("rate limited")                     // Too many requests
+	ErrServer       = errors.New("server or network error")          // The API failed or could not be reached
+	ErrResponse     = errors.New("unexpected response from the API") // The response could not be decoded
+
+	ErrInvalidSignature  = errors.New("invalid signature")  // A wallet signature does not match the message and address
+	ErrChallengeExpired  = errors.New("challenge expired")  // A login challenge is outside its validity window
+	ErrChallengeMismatch = errors.New("challenge mismatch") // A signed message is not the issued login challenge
+)
+
+// GenerateSessionToken generates a session token to initialise the Apillon login widget on the frontend.
+// Returns a SessionTokenResponse struct or an error if the request or unmarshalling fails.
+func GenerateSessionToken(ctx context.Context) (SessionTokenResponse, error) {
+	res, err := requests.GetReq(ctx, "/auth/session-token", nil)
+	if err != nil {
+		return SessionTokenResponse{}, classify(err, "failed to generate session token", false)
+	}
+
+	var token SessionTokenResponse
+	if err := json.Unmarshal([]byte(res), &token); err != nil {
+		return SessionTokenResponse{}, responseError("session token", err)
+	}
+
+	return token, nil
+}
+
+// VerifyLoginToken verifies a login token returned by the Apillon login widget.
+// Returns a VerifyLoginResponse with the user's email and wallets, or an error matching
+// ErrInvalidToken if the token is invalid or expired.
+func VerifyLoginToken(ctx context.Context, token string) (VerifyLoginResponse, error) {
+	if strings.TrimSpace(token) == "" {
+		return VerifyLoginResponse{}, invalidInput("login token cannot be empty")
+	}
+
+	bodyBytes, err := json.Marshal(verifyLoginRequest{Token: token})
+	if err != nil {
+		return VerifyLoginResponse{}, &AuthError{
+			Code:    500,
+			Message: "failed to marshal verify login request",
+			Kind:    ErrServer,
+			Err:     err,
+		}
+	}
+
+	res, err := requests.PostReq(ctx, "/auth/verify-login", strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return VerifyLoginResponse{}, classify(err, "failed to verify login token", true)
+	}
+
+	var user VerifyLoginResponse
+	if err := json.Unmarshal([]byte(res), &user); err != nil {
+		return VerifyLoginResponse{}, responseError("verify login", err)
+	}
+
+	return user, nil
+}
+
+// GetWalletIdentity retrieves the on-chain identity registered for a wallet address.
+// Returns a WalletIdentityResponse struct or an error if the request or unmarshalling fails.
+func GetWalletIdentity(ctx context.Context, address string) (WalletIdentityResponse, error) {
+	if strings.TrimSpace(address) == "" {
+		return WalletIdentityResponse{}, invalidInput("wallet address cannot be empty")
+	}
+
+	res, err := requests.GetReq(ctx, "/wallet-identity", map[string]string{"address": address})
+	if err != nil {
+		return WalletIdentityResponse{}, classify(err, fmt.Sprintf("failed to get identity of wallet %s", address), false)
+	}
+
+	var identity WalletIdentityResponse
+	if err := json.Unmarshal([]byte(res), &identity); err != nil {
+		return WalletIdentityResponse{}, responseError("wallet identity", err)
+	}
+
+	return identity, nil
+}
+
+// classify wraps a request error in an AuthError whose kind and code follow the API response.
+// When tokenRequest is set, client errors other than authorization failures are reported as ErrInvalidToken.
+func classify(err error, message string, tokenRequest bool) *AuthError {
+	authErr := &AuthError{Code: 500, Message: message, Kind: ErrServer, Err: err}
+
+	var apiErr *requests.APIError
+	if !errors.As(err, &apiErr) {
+		return authErr
+	}
+	authErr.Code = apiErr.Status
+
+	switch {
+	case apiErr.Status == http.StatusUnauthorized || apiErr.Status == http.StatusForbidden:
+		authErr.Kind = ErrUnauthorized
+	case apiErr.Status == http.StatusTooManyRequests:
+		authErr.Kind = ErrRateLimited
+	case tokenRequest && apiErr.Status >= 400 && apiErr.Status < 500:
+		authErr.Kind = ErrInvalidToken
+	case apiErr.Status == http.StatusNotFound:
+		authErr.Kind = ErrNotFound
+	case apiErr.Status >= 400 && apiErr.Status < 500:
+		authErr.Kind = ErrInvalidInput
+	}
+	return authErr
+}
+
+func responseError(operation string, err error) *AuthError {
+	return &AuthError{
+		Code:    500,
+		Message: fmt.Sprintf("failed to unmarshal %s response", operation),
+		Kind:    ErrResponse,
+		Err:     err,
+	}
+}
+
+func invalidInput(message string) *AuthError {
+	return &AuthError{
+		Code:    ErrCodeInvalidInput,
+		Message: message,
+		Kind:    ErrInvalidInput,
+	}
+}
@@ -0,0 +1,89 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Apillon/go-sdk/requests"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		tokenRequest bool
+		kind         error
+		code         int
+	}{
+		{"network", fmt.Errorf("dial tcp: timeout"), false, ErrServer, 500},
+		{"unauthorized", &requests.APIError{Status: 401}, true, ErrUnauthorized, 401},
+		{"forbidden", &requests.APIError{Status: 403}, false, ErrUnauthorized, 403},
+		{"rate limited", &requests.APIError{Status: 429}, true, ErrRateLimited, 429},
+		{"bad token", &requests.APIError{Status: 400}, true, ErrInvalidToken, 400},
+		{"not found", &requests.APIError{Status: 404}, false, ErrNotFound, 404},
+		{"validation", &requests.APIError{Status: 422}, false, ErrInvalidInput, 422},
+		{"server", &requests.APIError{Status: 502}, true, ErrServer, 502},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classify(fmt.Errorf("wrapped: %w", tt.err), "message", tt.tokenRequest)
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("expected kind %v, got %v", tt.kind, err.Kind)
+			}
+			if err.Code != tt.code {
+				t.Errorf("expected code %d, got %d", tt.code, err.Code)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Error("classified error should wrap the original error")
+			}
+		})
+	}
+}
+
+func TestInputValidation(t *testing.T) {
+	ctx := context.Background()
+
+	if _, err := VerifyLoginToken(ctx, " "); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected invalid input for empty token, got %v", err)
+	}
+	if _, err := GetWalletIdentity(ctx, ""); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected invalid input for empty address, got %v", err)
+	}
+}
+
+func TestVerifyLoginResponseUnmarshal(t *testing.T) {
+	body := `{"id":"1","status":200,"data":{"email":"user@example.com","wallets":[{"address":"0xabc","chainType":"evm"},{"address":"5Grw","chainType":"substrate"}]}}`
+
+	var res VerifyLoginResponse
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if res.Data.Email != "user@example.com" {
+		t.Errorf("unexpected email %q", res.Data.Email)
+	}
+	if addrs := res.Data.WalletAddresses(); len(addrs) != 2 || addrs[0] != "0xabc" || addrs[1] != "5Grw" {
+		t.Errorf("unexpected wallet addresses %v", addrs)
+	}
+}
+
+func TestWalletIdentityUnmarshal(t *testing.T) {
+	var res WalletIdentityResponse
+	if err := json.Unmarshal([]byte(`{"data":{"polkadot":{"info":{"display":"Alice","email":"alice@example.com"}}}}`), &res); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if res.Data.Polkadot == nil || res.Data.Polkadot.Info.Display != "Alice" {
+		t.Errorf("unexpected identity %+v", res.Data)
+	}
+
+	res = WalletIdentityResponse{}
+	if err := json.Unmarshal([]byte(`{"data":{"polkadot":null}}`), &res); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if res.Data.Polkadot != nil {
+		t.Error("expected no Polkadot identity")
+	}
+}
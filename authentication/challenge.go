@@ -0,0 +1,114 @@
+package authentication
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Challenge is a time-limited login message a wallet signs to prove it controls an address.
+// A server issues a challenge with NewChallenge, keeps it (e.g. keyed by Nonce) until the signed
+// message comes back and then checks it with Verify, so every nonce is accepted only once.
+type Challenge struct {
+	Domain    string    // Domain requesting the login, e.g. "app.example.com"
+	Address   string    // Wallet address expected to sign
+	Nonce     string    // Random value that makes the challenge single-use
+	IssuedAt  time.Time // When the challenge was created
+	ExpiresAt time.Time // When the challenge stops being accepted
+}
+
+const (
+	challengeHeader  = " wants you to sign in with your wallet:"
+	nonceField       = "Nonce: "
+	issuedAtField    = "Issued At: "
+	expirationField  = "Expiration Time: "
+	challengeNonceSz = 16
+)
+
+// NewChallenge creates a challenge for address that is valid for ttl from now, with a random nonce.
+// Returns the challenge or an error if an argument is empty or the nonce cannot be generated.
+func NewChallenge(domain string, address string, ttl time.Duration) (Challenge, error) {
+	if strings.TrimSpace(domain) == "" || strings.TrimSpace(address) == "" {
+		return Challenge{}, invalidInput("domain and address cannot be empty")
+	}
+	if ttl <= 0 {
+		return Challenge{}, invalidInput("challenge TTL must be positive")
+	}
+
+	nonce := make([]byte, challengeNonceSz)
+	if _, err := rand.Read(nonce); err != nil {
+		return Challenge{}, &AuthError{Code: 500, Message: "failed to generate challenge nonce", Kind: ErrServer, Err: err}
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	return Challenge{
+		Domain:    domain,
+		Address:   address,
+		Nonce:     hex.EncodeToString(nonce),
+		IssuedAt:  now,
+		ExpiresAt: now.Add(ttl),
+	}, nil
+}
+
+// Message returns the text the wallet is asked to sign.
+func (c Challenge) Message() string {
+	return c.Domain + challengeHeader + "\n" +
+		c.Address + "\n\n" +
+		nonceField + c.Nonce + "\n" +
+		issuedAtField + c.IssuedAt.UTC().Format(time.RFC3339) + "\n" +
+		expirationField + c.ExpiresAt.UTC().Format(time.RFC3339)
+}
+
+// ParseChallenge parses a message produced by Challenge.Message.
+// It is meant for servers that do not store issued challenges; such servers must still reject reused nonces.
+// Returns the challenge or an error matching ErrInvalidInput if the message is malformed.
+func ParseChallenge(message string) (Challenge, error) {
+	lines := strings.Split(message, "\n")
+	if len(lines) != 6 || !strings.HasSuffix(lines[0], challengeHeader) || lines[2] != "" {
+		return Challenge{}, invalidInput("message is not a login challenge")
+	}
+
+	c := Challenge{
+		Domain:  strings.TrimSuffix(lines[0], challengeHeader),
+		Address: lines[1],
+	}
+	var ok bool
+	if c.Nonce, ok = strings.CutPrefix(lines[3], nonceField); !ok || c.Nonce == "" {
+		return Challenge{}, invalidInput("login challenge has no nonce")
+	}
+
+	issuedAt, ok := strings.CutPrefix(lines[4], issuedAtField)
+	expiresAt, ok2 := strings.CutPrefix(lines[5], expirationField)
+	if !ok || !ok2 {
+		return Challenge{}, invalidInput("login challenge has no validity window")
+	}
+	var err error
+	if c.IssuedAt, err = time.Parse(time.RFC3339, issuedAt); err != nil {
+		return Challenge{}, &AuthError{Code: ErrCodeInvalidInput, Message: "invalid challenge issue time", Kind: ErrInvalidInput, Err: err}
+	}
+	if c.ExpiresAt, err = time.Parse(time.RFC3339, expiresAt); err != nil {
+		return Challenge{}, &AuthError{Code: ErrCodeInvalidInput, Message: "invalid challenge expiration time", Kind: ErrInvalidInput, Err: err}
+	}
+
+	return c, nil
+}
+
+// Verify checks that message is this challenge, that now falls inside its validity window
+// and that signature is a valid signature of message by the challenge address (see VerifySignature).
+// Returns nil on success, or an error matching ErrChallengeMismatch, ErrChallengeExpired,
+// ErrInvalidSignature or ErrInvalidInput.
+func (c Challenge) Verify(message string, signature string, now time.Time) error {
+	if message != c.Message() {
+		return &AuthError{Code: 401, Message: "signed message does not match the issued challenge", Kind: ErrChallengeMismatch}
+	}
+	if now.Before(c.IssuedAt) || !now.Before(c.ExpiresAt) {
+		return &AuthError{
+			Code:    401,
+			Message: fmt.Sprintf("challenge valid from %s to %s", c.IssuedAt.Format(time.RFC3339), c.ExpiresAt.Format(time.RFC3339)),
+			Kind:    ErrChallengeExpired,
+		}
+	}
+	return VerifySignature(message, signature, c.Address)
+}
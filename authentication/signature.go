@@ -0,0 +1,143 @@
+package authentication
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	schnorrkel "github.com/ChainSafe/go-schnorrkel"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+// substrateSigningContext is the sr25519 signing context used by Substrate wallets.
+var substrateSigningContext = []byte("substrate")
+
+// VerifySignature checks offline that signature is a signature of message by the wallet at address.
+// EVM addresses (0x-prefixed hex) are verified as personal_sign signatures by recovering the secp256k1 signer.
+// Substrate SS58 addresses are verified as sr25519 or ed25519 signatures, accepting messages signed
+// raw or wrapped in <Bytes>...</Bytes> as browser extensions do.
+// The signature is hex encoded, with or without a 0x prefix.
+// Returns nil if the signature is valid, an error matching ErrInvalidSignature if it is not,
+// or an error matching ErrInvalidInput if the address or signature is malformed.
+func VerifySignature(message string, signature string, address string) error {
+	sig, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(signature), "0x"))
+	if err != nil {
+		return &AuthError{Code: ErrCodeInvalidInput, Message: "signature must be hex encoded", Kind: ErrInvalidInput, Err: err}
+	}
+
+	if strings.HasPrefix(address, "0x") {
+		return verifyEVMSignature([]byte(message), sig, address)
+	}
+	return verifySubstrateSignature([]byte(message), sig, address)
+}
+
+// verifyEVMSignature recovers the signer of a personal_sign signature and compares it with address.
+func verifyEVMSignature(message []byte, sig []byte, address string) error {
+	if !isHexAddress(address) {
+		return invalidInput(fmt.Sprintf("invalid EVM address %q", address))
+	}
+	if len(sig) != 65 {
+		return invalidInput(fmt.Sprintf("EVM signature must be 65 bytes, got %d", len(sig)))
+	}
+
+	// personal_sign signatures are r || s || v with v in {27, 28} (or {0, 1} from some signers);
+	// RecoverCompact expects v || r || s with v = 27 + recovery id.
+	v := sig[64]
+	if v < 27 {
+		v += 27
+	}
+	if v != 27 && v != 28 {
+		return invalidInput(fmt.Sprintf("invalid EVM signature recovery id %d", sig[64]))
+	}
+	compact := append([]byte{v}, sig[:64]...)
+
+	publicKey, _, err := ecdsa.RecoverCompact(compact, personalSignHash(message))
+	if err != nil {
+		return signatureMismatch(address, err)
+	}
+	if !strings.EqualFold(evmAddress(publicKey.SerializeUncompressed()), address) {
+		return signatureMismatch(address, nil)
+	}
+	return nil
+}
+
+// personalSignHash returns the hash signed by personal_sign:
+// keccak256("\x19Ethereum Signed Message:\n" + len(message) + message).
+func personalSignHash(message []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte("\x19Ethereum Signed Message:\n" + strconv.Itoa(len(message))))
+	h.Write(message)
+	return h.Sum(nil)
+}
+
+// evmAddress derives the lowercase hex address of an uncompressed secp256k1 public key.
+func evmAddress(uncompressed []byte) string {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(uncompressed[1:])
+	return "0x" + hex.EncodeToString(h.Sum(nil)[12:])
+}
+
+func isHexAddress(address string) bool {
+	if len(address) != 42 {
+		return false
+	}
+	_, err := hex.DecodeString(address[2:])
+	return err == nil
+}
+
+// verifySubstrateSignature checks an sr25519 or ed25519 signature against the public key in an SS58 address.
+func verifySubstrateSignature(message []byte, sig []byte, address string) error {
+	_, publicKey, err := DecodeSS58(address)
+	if err != nil {
+		return &AuthError{Code: ErrCodeInvalidInput, Message: fmt.Sprintf("invalid Substrate address %q", address), Kind: ErrInvalidInput, Err: err}
+	}
+
+	// MultiSignature encodings prefix the signature with its type: 0 for ed25519, 1 for sr25519.
+	schemes := []string{"sr25519", "ed25519"}
+	if len(sig) == 65 && sig[0] <= 1 {
+		schemes = []string{"ed25519", "sr25519"}[sig[0] : sig[0]+1]
+		sig = sig[1:]
+	}
+	if len(sig) != 64 {
+		return invalidInput(fmt.Sprintf("Substrate signature must be 64 bytes, got %d", len(sig)))
+	}
+
+	wrapped := append(append([]byte("<Bytes>"), message...), "</Bytes>"...)
+	for _, scheme := range schemes {
+		for _, msg := range [][]byte{message, wrapped} {
+			if verifySubstrateScheme(scheme, publicKey, msg, sig) {
+				return nil
+			}
+		}
+	}
+	return signatureMismatch(address, nil)
+}
+
+func verifySubstrateScheme(scheme string, publicKey []byte, message []byte, sig []byte) bool {
+	if scheme == "ed25519" {
+		return ed25519.Verify(ed25519.PublicKey(publicKey), message, sig)
+	}
+
+	pk, err := schnorrkel.NewPublicKey([32]byte(publicKey))
+	if err != nil {
+		return false
+	}
+	var s schnorrkel.Signature
+	if err := s.Decode([64]byte(sig)); err != nil {
+		return false
+	}
+	ok, err := pk.Verify(&s, schnorrkel.NewSigningContext(substrateSigningContext, message))
+	return err == nil && ok
+}
+
+func signatureMismatch(address string, err error) *AuthError {
+	return &AuthError{
+		Code:    401,
+		Message: fmt.Sprintf("signature does not match address %s", address),
+		Kind:    ErrInvalidSignature,
+		Err:     err,
+	}
+}
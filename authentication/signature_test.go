@@ -0,0 +1,168 @@
+package authentication
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	schnorrkel "github.com/ChainSafe/go-schnorrkel"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// Account and signature of "Some data" from the web3.js accounts documentation.
+	testEVMAddress   = "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"
+	testEVMSignature = "0xb91467e570a6466aa9e9876cbcd013baba02900b8979d43fe208a4a4f339f5fd6007e74cd82e037b800186422fc2da167c747ef045e5d18a5f5d4300f8e1a0291c"
+	testEVMKey       = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+	// Well-known development account Alice.
+	aliceAddress   = "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	alicePublicKey = "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"
+)
+
+func TestDecodeSS58(t *testing.T) {
+	network, publicKey, err := DecodeSS58(aliceAddress)
+	if err != nil {
+		t.Fatalf("DecodeSS58 failed: %v", err)
+	}
+	if network != 42 || hex.EncodeToString(publicKey) != alicePublicKey {
+		t.Errorf("unexpected network %d and key %x", network, publicKey)
+	}
+
+	corrupted := aliceAddress[:len(aliceAddress)-1] + "Z"
+	if _, _, err := DecodeSS58(corrupted); err == nil {
+		t.Error("expected checksum error for corrupted address")
+	}
+	if _, _, err := DecodeSS58("0OIl"); err == nil {
+		t.Error("expected error for invalid base58")
+	}
+}
+
+func TestVerifyEVMSignature(t *testing.T) {
+	if err := VerifySignature("Some data", testEVMSignature, testEVMAddress); err != nil {
+		t.Errorf("known signature rejected: %v", err)
+	}
+	if err := VerifySignature("Some data", testEVMSignature, strings.ToLower(testEVMAddress)); err != nil {
+		t.Errorf("lowercase address rejected: %v", err)
+	}
+	if err := VerifySignature("Other data", testEVMSignature, testEVMAddress); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for different message, got %v", err)
+	}
+	if err := VerifySignature("Some data", "0x1234", testEVMAddress); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for short signature, got %v", err)
+	}
+
+	// Signers that use recovery ids 0/1 instead of 27/28 are accepted too.
+	key, _ := hex.DecodeString(testEVMKey)
+	compact := ecdsa.SignCompact(secp256k1.PrivKeyFromBytes(key), personalSignHash([]byte("hello")), false)
+	sig := append(compact[1:], compact[0]-27)
+	if err := VerifySignature("hello", hex.EncodeToString(sig), testEVMAddress); err != nil {
+		t.Errorf("signature with recovery id %d rejected: %v", sig[64], err)
+	}
+}
+
+func TestVerifySubstrateSignature(t *testing.T) {
+	message := "Sign in to example.com"
+
+	secret, public, err := schnorrkel.GenerateKeypair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey := public.Encode()
+	srAddress := encodeSS58(42, publicKey[:])
+
+	for _, signed := range []string{message, "<Bytes>" + message + "</Bytes>"} {
+		sig, err := secret.Sign(schnorrkel.NewSigningContext(substrateSigningContext, []byte(signed)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		encoded := sig.Encode()
+		if err := VerifySignature(message, "0x"+hex.EncodeToString(encoded[:]), srAddress); err != nil {
+			t.Errorf("sr25519 signature of %q rejected: %v", signed, err)
+		}
+	}
+
+	edPublic, edPrivate, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edAddress := encodeSS58(0, edPublic)
+	edSig := ed25519.Sign(edPrivate, []byte(message))
+	if err := VerifySignature(message, hex.EncodeToString(edSig), edAddress); err != nil {
+		t.Errorf("ed25519 signature rejected: %v", err)
+	}
+	if err := VerifySignature(message, hex.EncodeToString(append([]byte{0}, edSig...)), edAddress); err != nil {
+		t.Errorf("ed25519 MultiSignature rejected: %v", err)
+	}
+	if err := VerifySignature(message, hex.EncodeToString(edSig), srAddress); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for wrong signer, got %v", err)
+	}
+}
+
+func TestChallenge(t *testing.T) {
+	challenge, err := NewChallenge("app.example.com", testEVMAddress, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("NewChallenge failed: %v", err)
+	}
+	message := challenge.Message()
+
+	parsed, err := ParseChallenge(message)
+	if err != nil {
+		t.Fatalf("ParseChallenge failed: %v", err)
+	}
+	if parsed != challenge {
+		t.Errorf("parsed challenge %+v differs from %+v", parsed, challenge)
+	}
+
+	key, _ := hex.DecodeString(testEVMKey)
+	compact := ecdsa.SignCompact(secp256k1.PrivKeyFromBytes(key), personalSignHash([]byte(message)), false)
+	signature := hex.EncodeToString(append(compact[1:], compact[0]))
+
+	now := challenge.IssuedAt.Add(time.Minute)
+	if err := challenge.Verify(message, signature, now); err != nil {
+		t.Errorf("valid challenge rejected: %v", err)
+	}
+	if err := challenge.Verify(message, signature, challenge.ExpiresAt); !errors.Is(err, ErrChallengeExpired) {
+		t.Errorf("expected ErrChallengeExpired, got %v", err)
+	}
+
+	other, _ := NewChallenge("app.example.com", testEVMAddress, 5*time.Minute)
+	if err := other.Verify(message, signature, now); !errors.Is(err, ErrChallengeMismatch) {
+		t.Errorf("expected ErrChallengeMismatch for another nonce, got %v", err)
+	}
+
+	if _, err := ParseChallenge("hello"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for malformed message, got %v", err)
+	}
+}
+
+// encodeSS58 encodes a public key as an SS58 address with a one-byte network prefix.
+func encodeSS58(network byte, publicKey []byte) string {
+	payload := append([]byte{network}, publicKey...)
+	hash := blake2b.Sum512(append([]byte("SS58PRE"), payload...))
+	raw := append(payload, hash[:2]...)
+
+	var out []byte
+	n := new(big.Int).SetBytes(raw)
+	radix, mod := big.NewInt(58), new(big.Int)
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	for _, b := range raw {
+		if b != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
@@ -0,0 +1,71 @@
+package authentication
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// ss58Prefix is hashed together with the address payload to compute the SS58 checksum.
+var ss58Prefix = []byte("SS58PRE")
+
+// DecodeSS58 decodes a Substrate SS58 address into its network prefix and 32-byte public key.
+// Returns an error if the address is not valid base58, has an unsupported length or a wrong checksum.
+func DecodeSS58(address string) (network uint16, publicKey []byte, err error) {
+	raw, err := decodeBase58(address)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// One-byte prefixes cover networks 0-63; two-byte prefixes cover 64-16383.
+	prefixLen := 1
+	if len(raw) > 0 && raw[0]&0x40 != 0 {
+		prefixLen = 2
+	}
+	if len(raw) != prefixLen+32+2 {
+		return 0, nil, fmt.Errorf("unsupported SS58 address length %d", len(raw))
+	}
+
+	payload, checksum := raw[:len(raw)-2], raw[len(raw)-2:]
+	hash := blake2b.Sum512(append(append([]byte{}, ss58Prefix...), payload...))
+	if !bytes.Equal(hash[:2], checksum) {
+		return 0, nil, fmt.Errorf("invalid SS58 checksum")
+	}
+
+	if prefixLen == 1 {
+		network = uint16(raw[0])
+	} else {
+		// See https://docs.substrate.io/reference/address-formats/ for the two-byte layout.
+		network = uint16(raw[0]&0x3f)<<2 | uint16(raw[1]>>6) | uint16(raw[1]&0x3f)<<8
+	}
+	return network, payload[prefixLen:], nil
+}
+
+// decodeBase58 decodes a Bitcoin-alphabet base58 string.
+func decodeBase58(s string) ([]byte, error) {
+	if s == "" {
+		return nil, fmt.Errorf("empty base58 string")
+	}
+
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for _, c := range []byte(s) {
+		digit := bytes.IndexByte([]byte(base58Alphabet), c)
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", c)
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+
+	// Leading '1's encode leading zero bytes.
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
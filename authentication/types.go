@@ -0,0 +1,62 @@
+package authentication
+
+import "github.com/Apillon/go-sdk/storage"
+
+// SessionToken is a short-lived token the frontend passes to the Apillon login widget.
+type SessionToken struct {
+	SessionToken string `json:"sessionToken"` // Token to initialise the widget with
+}
+
+// Wallet is a wallet address linked to an Apillon user.
+type Wallet struct {
+	Address   string `json:"address"`   // Wallet address
+	ChainType string `json:"chainType"` // "evm" or "substrate"
+}
+
+// User is the identity of a user who logged in through the Apillon widget.
+type User struct {
+	Email   string   `json:"email"`             // Email the user logged in with, if any
+	Wallets []Wallet `json:"wallets,omitempty"` // Wallets linked to the user
+}
+
+// WalletAddresses returns the addresses of all wallets linked to the user.
+func (u User) WalletAddresses() []string {
+	addresses := make([]string, 0, len(u.Wallets))
+	for _, w := range u.Wallets {
+		addresses = append(addresses, w.Address)
+	}
+	return addresses
+}
+
+// IdentityInfo is the on-chain identity registered for a Polkadot address.
+type IdentityInfo struct {
+	Display string `json:"display"` // Display name
+	Legal   string `json:"legal"`   // Legal name
+	Email   string `json:"email"`   // Email address
+	Web     string `json:"web"`     // Website
+	Twitter string `json:"twitter"` // Twitter handle
+	Riot    string `json:"riot"`    // Matrix handle
+}
+
+// PolkadotIdentity is the Polkadot identity of a wallet.
+type PolkadotIdentity struct {
+	Info IdentityInfo `json:"info"` // Identity fields
+}
+
+// WalletIdentity contains the on-chain identities registered for a wallet address.
+type WalletIdentity struct {
+	Polkadot *PolkadotIdentity `json:"polkadot"` // Polkadot identity, nil if none is registered
+}
+
+// SessionTokenResponse represents a response containing a session token.
+type SessionTokenResponse = storage.APIResponse[SessionToken]
+
+// VerifyLoginResponse represents a response containing the user behind a login token.
+type VerifyLoginResponse = storage.APIResponse[User]
+
+// WalletIdentityResponse represents a response containing the identity of a wallet.
+type WalletIdentityResponse = storage.APIResponse[WalletIdentity]
+
+type verifyLoginRequest struct {
+	Token string `json:"token"`
+}
@@ -0,0 +1,179 @@
+package cloudfunctions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Apillon/go-sdk/internal/apitest"
+)
+
+func TestInputValidation(t *testing.T) {
+	ctx := context.Background()
+	emptyScript := filepath.Join(t.TempDir(), "empty.js")
+	if err := os.WriteFile(emptyScript, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"create without name", func() error {
+			_, err := CreateFunction(ctx, CreateFunctionRequest{Description: "no name"})
+			return err
+		}},
+		{"get without UUID", func() error {
+			_, err := GetFunction(ctx, "")
+			return err
+		}},
+		{"job without script CID", func() error {
+			_, err := CreateJob(ctx, "fn", CreateJobRequest{Name: "v1"})
+			return err
+		}},
+		{"job with negative slots", func() error {
+			_, err := CreateJob(ctx, "fn", CreateJobRequest{Name: "v1", ScriptCID: "bafy", Slots: -1})
+			return err
+		}},
+		{"job from missing file", func() error {
+			_, err := CreateJobFromFile(ctx, "fn", "v1", filepath.Join(t.TempDir(), "missing.js"), 0)
+			return err
+		}},
+		{"job from empty file", func() error {
+			_, err := CreateJobFromFile(ctx, "fn", "v1", emptyScript, 0)
+			return err
+		}},
+		{"runs without job UUID", func() error {
+			_, err := ListJobRuns(ctx, "fn", "")
+			return err
+		}},
+		{"environment without variables", func() error {
+			_, err := SetEnvironment(ctx, "fn", nil)
+			return err
+		}},
+		{"environment with empty key", func() error {
+			_, err := SetEnvironment(ctx, "fn", []EnvironmentVariable{{Value: "x"}})
+			return err
+		}},
+		{"trigger without URL", func() error {
+			_, err := TriggerURL[map[string]any](ctx, "", nil)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fnErr *CloudFunctionError
+			if err := tt.call(); !errors.As(err, &fnErr) || fnErr.Code != ErrCodeInvalidInput {
+				t.Errorf("expected invalid input error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTriggerURL(t *testing.T) {
+	type greeting struct {
+		Message string `json:"message"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, "bad payload", http.StatusBadRequest)
+			return
+		}
+		if payload["name"] == "fail" {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(greeting{Message: "hello " + payload["name"]})
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	res, err := TriggerURL[greeting](ctx, server.URL, map[string]string{"name": "apillon"})
+	if err != nil {
+		t.Fatalf("TriggerURL failed: %v", err)
+	}
+	if res.Message != "hello apillon" {
+		t.Errorf("unexpected response %+v", res)
+	}
+
+	_, err = TriggerURL[greeting](ctx, server.URL, map[string]string{"name": "fail"})
+	var fnErr *CloudFunctionError
+	if !errors.As(err, &fnErr) || fnErr.Code != http.StatusInternalServerError {
+		t.Errorf("expected HTTP 500 error, got %v", err)
+	}
+}
+
+func TestFunctionResponseUnmarshal(t *testing.T) {
+	body := `{"id":"1","status":200,"data":{"functionUuid":"fn","name":"hello","bucketUuid":"b1","gatewayUrl":"https://fn.example","jobs":[{"jobUuid":"j1","scriptCid":"bafy","jobStatus":2}]}}`
+
+	var res FunctionResponse
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if res.Data.GatewayURL != "https://fn.example" || len(res.Data.Jobs) != 1 || res.Data.Jobs[0].JobStatus != JobDeployed {
+		t.Errorf("unexpected function %+v", res.Data)
+	}
+}
+
+func TestCreateJobFromFileIgnoresStaleScript(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.ProcessAfter = 1
+	server.AddFile("b1", "script.js", "old script")
+	server.Mux.HandleFunc("GET /cloud-functions/fn", func(w http.ResponseWriter, r *http.Request) {
+		apitest.WriteJSON(w, Function{FunctionUUID: "fn", BucketUUID: "b1"})
+	})
+	var created CreateJobRequest
+	server.Mux.HandleFunc("POST /cloud-functions/fn/jobs", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&created)
+		apitest.WriteJSON(w, Job{JobUUID: "j1", FunctionUUID: "fn", Name: created.Name, ScriptCID: created.ScriptCID})
+	})
+
+	script := filepath.Join(t.TempDir(), "script.js")
+	if err := os.WriteFile(script, []byte("new script"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	job, err := CreateJobFromFile(context.Background(), "fn", "v2", script, time.Millisecond)
+	if err != nil {
+		t.Fatalf("CreateJobFromFile failed: %v", err)
+	}
+	if want := apitest.CID("new script"); job.Data.ScriptCID != want || created.ScriptCID != want {
+		t.Errorf("deployed script %s, want %s", created.ScriptCID, want)
+	}
+}
+
+func TestListJobRuns(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.Reply("GET /cloud-functions/fn/jobs/j1/runs", map[string]any{
+		"items": []map[string]any{
+			{"runUuid": "r2", "jobUuid": "j1", "runStatus": 3, "error": "boom"},
+			{"runUuid": "r1", "jobUuid": "j1", "runStatus": 2},
+		},
+		"total": 2,
+	})
+
+	runs, err := ListJobRuns(context.Background(), "fn", "j1")
+	if err != nil {
+		t.Fatalf("ListJobRuns failed: %v", err)
+	}
+	items := runs.Data.Items
+	if len(items) != 2 || items[0].RunStatus != RunFailed || items[0].Error != "boom" || items[1].RunStatus != RunSucceeded {
+		t.Errorf("unexpected runs %+v", items)
+	}
+	if query := server.Received()[0].Query; !strings.Contains(query, "desc=true") {
+		t.Errorf("runs requested with query %q, want newest first", query)
+	}
+}
@@ -0,0 +1,191 @@
+// Package cloudfunctions provides functions to manage Apillon Cloud Functions:
+// creating functions, deploying jobs from local scripts, managing environment variables
+// and triggering functions through their gateway.
+package cloudfunctions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Apillon/go-sdk/requests"
+)
+
+// CloudFunctionError represents an error that occurred during cloud function operations
+type CloudFunctionError struct {
+	Code    int
+	Message string
+	Err     error
+}
+
+func (e *CloudFunctionError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("cloud function error (code %d): %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("cloud function error (code %d): %s", e.Code, e.Message)
+}
+
+func (e *CloudFunctionError) Unwrap() error {
+	return e.Err
+}
+
+// Error codes
+const (
+	ErrCodeInvalidInput = 40000001
+)
+
+// ListFunctions lists all cloud functions in the project.
+// Returns a ListFunctionsResponse struct or an error if the request or unmarshalling fails.
+func ListFunctions(ctx context.Context) (ListFunctionsResponse, error) {
+	res, err := requests.GetReq(ctx, "/cloud-functions", nil)
+	if err != nil {
+		return ListFunctionsResponse{}, &CloudFunctionError{
+			Code:    500,
+			Message: "failed to list cloud functions",
+			Err:     err,
+		}
+	}
+
+	var functions ListFunctionsResponse
+	if err := json.Unmarshal([]byte(res), &functions); err != nil {
+		return ListFunctionsResponse{}, &CloudFunctionError{
+			Code:    500,
+			Message: "failed to unmarshal list cloud functions response",
+			Err:     err,
+		}
+	}
+
+	return functions, nil
+}
+
+// GetFunction retrieves a cloud function and its jobs by the function UUID.
+// Returns a FunctionResponse struct or an error if the request or unmarshalling fails.
+func GetFunction(ctx context.Context, functionUuid string) (FunctionResponse, error) {
+	if functionUuid == "" {
+		return FunctionResponse{}, invalidInput("function UUID cannot be empty")
+	}
+
+	res, err := requests.GetReq(ctx, "/cloud-functions/"+functionUuid, nil)
+	if err != nil {
+		return FunctionResponse{}, &CloudFunctionError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to get cloud function %s", functionUuid),
+			Err:     err,
+		}
+	}
+
+	var function FunctionResponse
+	if err := json.Unmarshal([]byte(res), &function); err != nil {
+		return FunctionResponse{}, &CloudFunctionError{
+			Code:    500,
+			Message: "failed to unmarshal get cloud function response",
+			Err:     err,
+		}
+	}
+
+	return function, nil
+}
+
+// CreateFunction creates a new cloud function. Deploy code to it with CreateJob or CreateJobFromFile.
+// Returns a FunctionResponse containing the created function, or an error if the request or unmarshalling fails.
+func CreateFunction(ctx context.Context, req CreateFunctionRequest) (FunctionResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return FunctionResponse{}, invalidInput("function name cannot be empty")
+	}
+
+	var function FunctionResponse
+	if err := post(ctx, "/cloud-functions", req, &function, "create cloud function"); err != nil {
+		return FunctionResponse{}, err
+	}
+	return function, nil
+}
+
+// SetEnvironment sets environment variables of a function's jobs, replacing variables with the same keys.
+// Variables take effect after the next job is deployed.
+// Returns an EnvironmentResponse with all variables of the function, or an error if the request fails.
+func SetEnvironment(ctx context.Context, functionUuid string, variables []EnvironmentVariable) (EnvironmentResponse, error) {
+	if functionUuid == "" {
+		return EnvironmentResponse{}, invalidInput("function UUID cannot be empty")
+	}
+	if len(variables) == 0 {
+		return EnvironmentResponse{}, invalidInput("no environment variables provided")
+	}
+	for _, v := range variables {
+		if strings.TrimSpace(v.Key) == "" {
+			return EnvironmentResponse{}, invalidInput("environment variable key cannot be empty")
+		}
+	}
+
+	var env EnvironmentResponse
+	path := "/cloud-functions/" + functionUuid + "/environment"
+	if err := post(ctx, path, environmentRequest{Variables: variables}, &env, "set environment variables"); err != nil {
+		return EnvironmentResponse{}, err
+	}
+	return env, nil
+}
+
+// ListEnvironment lists the environment variables of a function.
+// Returns an EnvironmentResponse struct or an error if the request or unmarshalling fails.
+func ListEnvironment(ctx context.Context, functionUuid string) (EnvironmentResponse, error) {
+	if functionUuid == "" {
+		return EnvironmentResponse{}, invalidInput("function UUID cannot be empty")
+	}
+
+	res, err := requests.GetReq(ctx, "/cloud-functions/"+functionUuid+"/environment", nil)
+	if err != nil {
+		return EnvironmentResponse{}, &CloudFunctionError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to list environment variables of cloud function %s", functionUuid),
+			Err:     err,
+		}
+	}
+
+	var env EnvironmentResponse
+	if err := json.Unmarshal([]byte(res), &env); err != nil {
+		return EnvironmentResponse{}, &CloudFunctionError{
+			Code:    500,
+			Message: "failed to unmarshal environment variables response",
+			Err:     err,
+		}
+	}
+
+	return env, nil
+}
+
+// post marshals body, sends it to path and decodes the response into out; operation names the call for error messages.
+func post(ctx context.Context, path string, body any, out any, operation string) error {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return &CloudFunctionError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to marshal %s request", operation),
+			Err:     err,
+		}
+	}
+
+	res, err := requests.PostReq(ctx, path, strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return &CloudFunctionError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to %s", operation),
+			Err:     err,
+		}
+	}
+
+	if err := json.Unmarshal([]byte(res), out); err != nil {
+		return &CloudFunctionError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to unmarshal %s response", operation),
+			Err:     err,
+		}
+	}
+	return nil
+}
+
+func invalidInput(message string) *CloudFunctionError {
+	return &CloudFunctionError{
+		Code:    ErrCodeInvalidInput,
+		Message: message,
+	}
+}
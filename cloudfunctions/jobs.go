@@ -0,0 +1,236 @@
+package cloudfunctions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Apillon/go-sdk/requests"
+	"github.com/Apillon/go-sdk/storage"
+)
+
+// triggerTimeout bounds a gateway call when ctx has no deadline.
+const triggerTimeout = 60 * time.Second
+
+// CreateJob deploys a script that is already uploaded (identified by its CID) as a new job of a function.
+// The new job replaces the function's active job once it is deployed.
+// Returns a JobResponse containing the created job, or an error if the request or unmarshalling fails.
+func CreateJob(ctx context.Context, functionUuid string, req CreateJobRequest) (JobResponse, error) {
+	if functionUuid == "" {
+		return JobResponse{}, invalidInput("function UUID cannot be empty")
+	}
+	if strings.TrimSpace(req.Name) == "" || req.ScriptCID == "" {
+		return JobResponse{}, invalidInput("job name and script CID cannot be empty")
+	}
+	if req.Slots < 0 {
+		return JobResponse{}, invalidInput("job slots cannot be negative")
+	}
+
+	var job JobResponse
+	if err := post(ctx, "/cloud-functions/"+functionUuid+"/jobs", req, &job, "create job"); err != nil {
+		return JobResponse{}, err
+	}
+	return job, nil
+}
+
+// CreateJobFromFile uploads a local JS script to the function's bucket with storage.UploadAndWait
+// and deploys it as a new job named name. A different script already stored under the same
+// file name is not deployed in its place.
+// pollInterval defaults to 3 seconds.
+// Returns a JobResponse containing the created job, or an error if reading, uploading or creating the job fails.
+func CreateJobFromFile(ctx context.Context, functionUuid string, name string, scriptPath string, pollInterval time.Duration) (JobResponse, error) {
+	if functionUuid == "" || strings.TrimSpace(name) == "" {
+		return JobResponse{}, invalidInput("function UUID and job name cannot be empty")
+	}
+
+	script, err := os.ReadFile(scriptPath)
+	if err != nil {
+		return JobResponse{}, &CloudFunctionError{
+			Code:    ErrCodeInvalidInput,
+			Message: "failed to read job script",
+			Err:     err,
+		}
+	}
+	if len(script) == 0 {
+		return JobResponse{}, invalidInput("job script is empty")
+	}
+
+	function, err := GetFunction(ctx, functionUuid)
+	if err != nil {
+		return JobResponse{}, err
+	}
+
+	fileName := filepath.Base(scriptPath)
+	files := []storage.WholeFile{{
+		Content: string(script),
+		Metadata: storage.FileMetadata{
+			FileName:    fileName,
+			ContentType: "application/javascript",
+		},
+	}}
+	uploaded, err := storage.UploadAndWait(ctx, function.Data.BucketUUID, files, pollInterval)
+	if err != nil {
+		return JobResponse{}, &CloudFunctionError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to upload job script %s to bucket %s", fileName, function.Data.BucketUUID),
+			Err:     err,
+		}
+	}
+
+	return CreateJob(ctx, functionUuid, CreateJobRequest{Name: name, ScriptCID: uploaded[fileName].CID})
+}
+
+// ListJobs lists the jobs of a function with their deployment status.
+// Returns a ListJobsResponse struct or an error if the request or unmarshalling fails.
+func ListJobs(ctx context.Context, functionUuid string) (ListJobsResponse, error) {
+	if functionUuid == "" {
+		return ListJobsResponse{}, invalidInput("function UUID cannot be empty")
+	}
+
+	res, err := requests.GetReq(ctx, "/cloud-functions/"+functionUuid+"/jobs", nil)
+	if err != nil {
+		return ListJobsResponse{}, &CloudFunctionError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to list jobs of cloud function %s", functionUuid),
+			Err:     err,
+		}
+	}
+
+	var jobs ListJobsResponse
+	if err := json.Unmarshal([]byte(res), &jobs); err != nil {
+		return ListJobsResponse{}, &CloudFunctionError{
+			Code:    500,
+			Message: "failed to unmarshal list jobs response",
+			Err:     err,
+		}
+	}
+
+	return jobs, nil
+}
+
+// ListJobRuns lists the runs of a job, newest first, with the status of each run.
+// Returns a ListJobRunsResponse struct or an error if the request or unmarshalling fails.
+func ListJobRuns(ctx context.Context, functionUuid string, jobUuid string) (ListJobRunsResponse, error) {
+	if functionUuid == "" || jobUuid == "" {
+		return ListJobRunsResponse{}, invalidInput("function UUID and job UUID cannot be empty")
+	}
+
+	params := map[string]string{
+		"orderBy": "createTime",
+		"desc":    "true",
+	}
+	res, err := requests.GetReq(ctx, "/cloud-functions/"+functionUuid+"/jobs/"+jobUuid+"/runs", params)
+	if err != nil {
+		return ListJobRunsResponse{}, &CloudFunctionError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to list runs of job %s", jobUuid),
+			Err:     err,
+		}
+	}
+
+	var runs ListJobRunsResponse
+	if err := json.Unmarshal([]byte(res), &runs); err != nil {
+		return ListJobRunsResponse{}, &CloudFunctionError{
+			Code:    500,
+			Message: "failed to unmarshal list job runs response",
+			Err:     err,
+		}
+	}
+
+	return runs, nil
+}
+
+// Trigger calls a function through its gateway URL with payload encoded as JSON
+// and decodes the JSON response of the function into T.
+// Returns the decoded response, or an error if the function has no gateway URL, the call fails
+// or the response is not valid JSON for T.
+func Trigger[T any](ctx context.Context, functionUuid string, payload any) (T, error) {
+	var zero T
+	function, err := GetFunction(ctx, functionUuid)
+	if err != nil {
+		return zero, err
+	}
+	if function.Data.GatewayURL == "" {
+		return zero, &CloudFunctionError{
+			Code:    500,
+			Message: fmt.Sprintf("cloud function %s has no gateway URL", functionUuid),
+		}
+	}
+	return TriggerURL[T](ctx, function.Data.GatewayURL, payload)
+}
+
+// TriggerURL calls a function gateway URL directly, skipping the function lookup done by Trigger.
+// Returns the decoded response, or an error if the call fails or the response is not valid JSON for T.
+func TriggerURL[T any](ctx context.Context, gatewayURL string, payload any) (T, error) {
+	var zero T
+	if gatewayURL == "" {
+		return zero, invalidInput("gateway URL cannot be empty")
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return zero, &CloudFunctionError{
+			Code:    ErrCodeInvalidInput,
+			Message: "failed to marshal function payload",
+			Err:     err,
+		}
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, triggerTimeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, gatewayURL, bytes.NewReader(body))
+	if err != nil {
+		return zero, &CloudFunctionError{
+			Code:    ErrCodeInvalidInput,
+			Message: "invalid gateway URL",
+			Err:     err,
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return zero, &CloudFunctionError{
+			Code:    500,
+			Message: "failed to trigger cloud function",
+			Err:     err,
+		}
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return zero, &CloudFunctionError{
+			Code:    500,
+			Message: "failed to read cloud function response",
+			Err:     err,
+		}
+	}
+	if resp.StatusCode >= 400 {
+		return zero, &CloudFunctionError{
+			Code:    resp.StatusCode,
+			Message: fmt.Sprintf("cloud function returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(responseBody))),
+		}
+	}
+
+	var out T
+	if err := json.Unmarshal(responseBody, &out); err != nil {
+		return zero, &CloudFunctionError{
+			Code:    500,
+			Message: "failed to unmarshal cloud function response",
+			Err:     err,
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,136 @@
+package cloudfunctions
+
+import "github.com/Apillon/go-sdk/storage"
+
+// JobStatus is the deployment status of a cloud function job.
+type JobStatus int
+
+const (
+	JobPending   JobStatus = 0 // Job was created
+	JobDeploying JobStatus = 1 // Job is being deployed to processors
+	JobDeployed  JobStatus = 2 // Job is running and handles requests
+	JobFailed    JobStatus = 3 // Job deployment failed
+	JobInactive  JobStatus = 4 // Job was replaced by a newer job or stopped
+)
+
+func (s JobStatus) String() string {
+	switch s {
+	case JobPending:
+		return "pending"
+	case JobDeploying:
+		return "deploying"
+	case JobDeployed:
+		return "deployed"
+	case JobFailed:
+		return "failed"
+	case JobInactive:
+		return "inactive"
+	default:
+		return "unknown"
+	}
+}
+
+// RunStatus is the status of a single invocation of a cloud function job.
+type RunStatus int
+
+const (
+	RunPending   RunStatus = 0 // Invocation was received and waits for a processor
+	RunRunning   RunStatus = 1 // Job script is handling the invocation
+	RunSucceeded RunStatus = 2 // Job script returned a response
+	RunFailed    RunStatus = 3 // Job script threw an error or the processor failed
+	RunTimedOut  RunStatus = 4 // Job script did not respond in time
+)
+
+func (s RunStatus) String() string {
+	switch s {
+	case RunPending:
+		return "pending"
+	case RunRunning:
+		return "running"
+	case RunSucceeded:
+		return "succeeded"
+	case RunFailed:
+		return "failed"
+	case RunTimedOut:
+		return "timed out"
+	default:
+		return "unknown"
+	}
+}
+
+// Function contains information about a cloud function.
+type Function struct {
+	storage.Timestamps
+	FunctionUUID  string `json:"functionUuid"`  // Unique identifier for the function
+	Name          string `json:"name"`          // Name of the function
+	Description   string `json:"description"`   // Description of the function
+	BucketUUID    string `json:"bucketUuid"`    // UUID of the bucket holding job scripts
+	ActiveJobUUID string `json:"activeJobUuid"` // UUID of the job currently handling requests
+	GatewayURL    string `json:"gatewayUrl"`    // URL that triggers the function
+	Jobs          []Job  `json:"jobs"`          // Jobs of the function, when returned by GetFunction
+}
+
+// Job is a deployment of a function script.
+type Job struct {
+	storage.Timestamps
+	JobUUID      string       `json:"jobUuid"`      // Unique identifier for the job
+	FunctionUUID string       `json:"functionUuid"` // UUID of the function the job belongs to
+	Name         string       `json:"name"`         // Name of the job
+	ScriptCID    string       `json:"scriptCid"`    // CID of the job script
+	Slots        int          `json:"slots"`        // Number of processors running the job
+	JobStatus    JobStatus    `json:"jobStatus"`    // Deployment status
+	StartTime    storage.Time `json:"startTime"`    // When the job started running
+	EndTime      storage.Time `json:"endTime"`      // When the job stops running
+}
+
+// JobRun is a single invocation of a cloud function job.
+type JobRun struct {
+	storage.Timestamps
+	RunUUID   string       `json:"runUuid"`   // Unique identifier for the run
+	JobUUID   string       `json:"jobUuid"`   // UUID of the job that handled the run
+	RunStatus RunStatus    `json:"runStatus"` // Status of the run
+	StartTime storage.Time `json:"startTime"` // When the job started handling the run
+	EndTime   storage.Time `json:"endTime"`   // When the run finished (null while it is pending or running)
+	Error     string       `json:"error"`     // Error reported by the job for failed runs
+}
+
+// EnvironmentVariable is an environment variable available to function jobs.
+type EnvironmentVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// FunctionResponse represents a response containing a single function.
+type FunctionResponse = storage.APIResponse[Function]
+
+// ListFunctionsResponse represents a response containing a list of functions.
+type ListFunctionsResponse = storage.APIResponse[storage.ListData[Function]]
+
+// JobResponse represents a response containing a single job.
+type JobResponse = storage.APIResponse[Job]
+
+// ListJobsResponse represents a response containing a list of jobs.
+type ListJobsResponse = storage.APIResponse[storage.ListData[Job]]
+
+// ListJobRunsResponse represents a response containing a list of job runs.
+type ListJobRunsResponse = storage.APIResponse[storage.ListData[JobRun]]
+
+// EnvironmentResponse represents a response containing the environment variables of a function.
+type EnvironmentResponse = storage.APIResponse[[]EnvironmentVariable]
+
+// CreateFunctionRequest represents the request body for creating a cloud function.
+type CreateFunctionRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// CreateJobRequest represents the request body for creating a job from an uploaded script.
+type CreateJobRequest struct {
+	Name      string `json:"name"`
+	ScriptCID string `json:"scriptCid"`
+	Slots     int    `json:"slots,omitempty"` // Number of processors; the API default is used if zero
+}
+
+type environmentRequest struct {
+	Variables []EnvironmentVariable `json:"variables"`
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Apillon/go-sdk/credentials"
+	"github.com/Apillon/go-sdk/requests"
+)
+
+var configCommands = map[string]*command{
+	"show": {
+		usage:  "config show",
+		noAuth: true,
+		run:    runConfigShow,
+	},
+}
+
+// configureCredentials applies --api-key or --profile, which take precedence over the
+// environment, and resolves the credentials through the SDK provider chain.
+// A --profile without an API key is an error rather than falling back to the environment,
+// so a command never runs against a different account than the one named.
+func configureCredentials(g globalFlags) (credentials.Credentials, error) {
+	requests.SetCredentials(credentials.Credentials{})
+	switch {
+	case g.APIKey != "":
+		requests.SetCredentials(credentials.Credentials{APIKey: g.APIKey, Origin: "--api-key"})
+	case g.Profile != "":
+		path := credentials.ConfigPath()
+		profile, err := credentials.LoadProfile(path, g.Profile)
+		if err != nil {
+			return credentials.Credentials{}, err
+		}
+		if profile.APIKey == "" {
+			return credentials.Credentials{}, &credentials.CredentialsError{
+				Code:    credentials.ErrCodeInvalidInput,
+				Message: fmt.Sprintf("profile %q in %s has no api_key", g.Profile, path),
+			}
+		}
+		requests.SetCredentials(profile.Credentials(path))
+	}
+	return requests.ResolveCredentials()
+}
+
+func runConfigShow(ctx context.Context, e *env, args []string) (result, error) {
+	if err := wantArgs(args, 0, 0, "config show"); err != nil {
+		return result{}, err
+	}
+
+	c := e.creds
+	return result{value: map[string]string{
+		"source":        c.Source.String(),
+		"origin":        c.Origin,
+		"apiKey":        mask(c.APIKey),
+		"apiSecret":     mask(c.APISecret),
+		"baseUrl":       c.BaseURL,
+		"defaultBucket": c.DefaultBucket,
+	}}, nil
+}
+
+// mask hides all but the last four characters of a secret.
+func mask(secret string) string {
+	if len(secret) <= 4 {
+		return strings.Repeat("*", len(secret))
+	}
+	return strings.Repeat("*", 8) + secret[len(secret)-4:]
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Apillon/go-sdk/credentials"
+	"github.com/Apillon/go-sdk/hosting"
+	"github.com/Apillon/go-sdk/nft"
+	"github.com/Apillon/go-sdk/requests"
+	"github.com/Apillon/go-sdk/storage"
+)
+
+// Exit codes, documented in the package comment.
+const (
+	exitOK          = 0
+	exitError       = 1
+	exitUsage       = 2
+	exitAuth        = 3
+	exitNotFound    = 4
+	exitRateLimited = 5
+	exitServer      = 6
+)
+
+// errNotFound is wrapped by errors for buckets or files the CLI could not find by name.
+var errNotFound = errors.New("not found")
+
+// usageError is an invalid command line.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usageErrorf(format string, args ...any) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// exitCode maps an error returned by a command to a process exit code. The status of an
+// API error anywhere in the chain wins; SDK input validation errors map to exitUsage.
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+
+	var apiErr *requests.APIError
+	if errors.As(err, &apiErr) {
+		switch status := apiErr.Status; {
+		case status == http.StatusUnauthorized || status == http.StatusForbidden:
+			return exitAuth
+		case status == http.StatusNotFound:
+			return exitNotFound
+		case status == http.StatusTooManyRequests:
+			return exitRateLimited
+		case status >= 500:
+			return exitServer
+		case status >= 400:
+			return exitUsage
+		}
+	}
+
+	var usage *usageError
+	if errors.As(err, &usage) || invalidInput(err) {
+		return exitUsage
+	}
+	if errors.Is(err, credentials.ErrNoCredentials) {
+		return exitAuth
+	}
+	if errors.Is(err, errNotFound) {
+		return exitNotFound
+	}
+	return exitError
+}
+
+// invalidInput reports whether err is an SDK input validation or configuration error.
+func invalidInput(err error) bool {
+	var credentialsErr *credentials.CredentialsError
+	if errors.As(err, &credentialsErr) && credentialsErr.Code == credentials.ErrCodeInvalidInput {
+		return true
+	}
+	var storageErr *storage.StorageError
+	if errors.As(err, &storageErr) && storageErr.Code == storage.ErrCodeInvalidInput {
+		return true
+	}
+	var hostingErr *hosting.HostingError
+	if errors.As(err, &hostingErr) && hostingErr.Code == hosting.ErrCodeInvalidInput {
+		return true
+	}
+	var nftErr *nft.NFTError
+	return errors.As(err, &nftErr) && nftErr.Code == nft.ErrCodeInvalidInput
+}
+
+// category names the class of failure an exit code stands for.
+func category(code int) string {
+	switch code {
+	case exitUsage:
+		return "validation"
+	case exitAuth:
+		return "auth"
+	case exitNotFound:
+		return "not-found"
+	case exitRateLimited:
+		return "rate-limit"
+	case exitServer:
+		return "server"
+	default:
+		return "error"
+	}
+}
+
+// githubAnnotation formats err as a GitHub Actions error workflow command.
+func githubAnnotation(command string, code int, err error) string {
+	title := fmt.Sprintf("%s failed (%s)", strings.TrimSpace("apillon "+command), category(code))
+	return fmt.Sprintf("::error title=%s::%s", escapeProperty(title), escapeData(err.Error()))
+}
+
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
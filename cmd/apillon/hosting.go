@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Apillon/go-sdk/hosting"
+	"github.com/Apillon/go-sdk/storage"
+)
+
+var hostingCommands = map[string]*command{
+	"deploy": {
+		usage: "hosting deploy <dir> --website UUID [--env staging|production] [--wait]",
+		flags: func(fs *flag.FlagSet) {
+			fs.String("website", "", "website UUID")
+			fs.String("env", "staging", "environment: staging or production")
+			fs.Bool("wait", false, "wait until the deployment succeeds or fails")
+		},
+		run: runHostingDeploy,
+	},
+	"deployments ls": {
+		usage: "hosting deployments ls --website UUID",
+		flags: func(fs *flag.FlagSet) { fs.String("website", "", "website UUID") },
+		run:   runHostingDeployments,
+	},
+}
+
+// deployOutput is the output of hosting deploy.
+type deployOutput struct {
+	Deployment hosting.Deployment `json:"deployment"`
+	URLs       []string           `json:"urls,omitempty"`
+	Skipped    []string           `json:"skipped,omitempty"` // Empty files left out of the upload
+}
+
+func runHostingDeploy(ctx context.Context, e *env, args []string) (result, error) {
+	if err := wantArgs(args, 1, 1, "hosting deploy <dir> --website UUID [--env staging|production] [--wait]"); err != nil {
+		return result{}, err
+	}
+	website := e.flag("website")
+	if website == "" {
+		return result{}, usageErrorf("--website is required")
+	}
+	environment, err := parseEnvironment(e.flag("env"))
+	if err != nil {
+		return result{}, err
+	}
+
+	var out deployOutput
+	if e.boolFlag("wait") {
+		deployed, err := hosting.DeployDirectory(ctx, website, args[0], environment, hosting.DeployOptions{
+			OnProgress: func(ev hosting.DeployEvent) { e.progress(deployProgress(ev)) },
+		})
+		if err != nil {
+			return result{}, err
+		}
+		out = deployOutput{Deployment: deployed.Deployment, URLs: deployed.URLs, Skipped: deployed.Skipped}
+	} else {
+		out, err = startDeployment(ctx, e, website, args[0], environment)
+		if err != nil {
+			return result{}, err
+		}
+	}
+
+	d := out.Deployment
+	return result{
+		value:  out,
+		header: []string{"DEPLOYMENT", "ENV", "STATUS", "CID", "URLS"},
+		rows:   [][]string{{d.DeploymentUUID, d.Environment.String(), d.DeploymentStatus.String(), d.CID, strings.Join(out.URLs, " ")}},
+	}, nil
+}
+
+// startDeployment uploads dir and starts a deployment without waiting for it to finish.
+func startDeployment(ctx context.Context, e *env, website string, dir string, environment hosting.Environment) (deployOutput, error) {
+	target := hosting.DeployToStaging
+	if environment == hosting.EnvironmentProduction {
+		target = hosting.DeployDirectlyToProduction
+	}
+
+	files, skipped, err := storage.WalkDirectory(dir)
+	if err != nil {
+		return deployOutput{}, err
+	}
+	e.progress(deployProgress(hosting.DeployEvent{Stage: hosting.StageUploading, Files: len(files), Skipped: skipped}))
+	if _, err := hosting.UploadWebsiteSources(ctx, website, files); err != nil {
+		return deployOutput{}, err
+	}
+
+	started, err := hosting.DeployWebsite(ctx, website, target)
+	if err != nil {
+		return deployOutput{}, err
+	}
+	return deployOutput{Deployment: started.Data, Skipped: skipped}, nil
+}
+
+func deployProgress(ev hosting.DeployEvent) string {
+	switch ev.Stage {
+	case hosting.StageUploading:
+		if len(ev.Skipped) > 0 {
+			return fmt.Sprintf("uploading %d files, skipping %d empty: %s", ev.Files, len(ev.Skipped), strings.Join(ev.Skipped, ", "))
+		}
+		return fmt.Sprintf("uploading %d files", ev.Files)
+	case hosting.StageDeploying:
+		return "deployment " + ev.Deployment.DeploymentUUID + " started"
+	case hosting.StageWaiting:
+		return "deployment " + ev.Deployment.DeploymentStatus.String()
+	default:
+		return "deployment " + ev.Stage.String()
+	}
+}
+
+func runHostingDeployments(ctx context.Context, e *env, args []string) (result, error) {
+	if err := wantArgs(args, 0, 0, "hosting deployments ls --website UUID"); err != nil {
+		return result{}, err
+	}
+	website := e.flag("website")
+	if website == "" {
+		return result{}, usageErrorf("--website is required")
+	}
+
+	deployments, err := hosting.ListDeployments(ctx, website)
+	if err != nil {
+		return result{}, err
+	}
+
+	res := result{value: deployments.Data.Items, header: []string{"NUMBER", "DEPLOYMENT", "ENV", "STATUS", "CID", "SIZE", "CREATED"}}
+	for _, d := range deployments.Data.Items {
+		res.rows = append(res.rows, []string{
+			strconv.Itoa(d.Number), d.DeploymentUUID, d.Environment.String(), d.DeploymentStatus.String(),
+			d.CID, d.Size.String(), formatTime(d.CreateTime),
+		})
+	}
+	return res, nil
+}
+
+func parseEnvironment(name string) (hosting.Environment, error) {
+	switch name {
+	case "staging":
+		return hosting.EnvironmentStaging, nil
+	case "production":
+		return hosting.EnvironmentProduction, nil
+	}
+	return 0, usageErrorf("unknown environment %q, expected staging or production", name)
+}
@@ -0,0 +1,313 @@
+// Command apillon is a command-line client for the Apillon API built on the Go SDK.
+//
+// Usage:
+//
+//	apillon [global flags] <service> <command> [flags] [args]
+//
+// Global flags, accepted anywhere on the command line:
+//
+//	--api-key KEY        API key
+//	--profile NAME       profile in ~/.config/apillon/config.toml
+//
+// Without --api-key or --profile, credentials are resolved like in the SDK: APILLON_API_KEY, then
+// the APILLON_PROFILE or "default" profile. "apillon config show" reports the source used.
+//
+//	--output FORMAT      json, table or yaml (default: table)
+//
+// Hosting commands:
+//
+//	hosting deploy <dir> --website UUID [--env staging|production] [--wait]
+//	hosting deployments ls --website UUID
+//
+// NFT commands:
+//
+//	nft collections ls
+//	nft collections create --name NAME --symbol SYMBOL --chain CHAIN [flags] [--wait]
+//	nft mint <collectionUuid> --to ADDRESS [--quantity N] [--ids 1,2] [--wait]
+//
+// Storage commands:
+//
+//	storage buckets ls
+//	storage buckets create <name> [--description TEXT]
+//	storage buckets delete <bucket>
+//	storage ls <bucket>[/path]
+//	storage upload <dir|file> <bucket>[/path]
+//	storage download <bucket>/<path> [destination]
+//	storage rm <bucket>/<path> [--recursive] [--dry-run]
+//	storage sync <dir> <bucket>[/path] [--delete] [--dry-run]
+//	storage info <fileUuid> [--bucket BUCKET]
+//	storage link <cid>
+//
+// Config commands:
+//
+//	config show
+//
+// Buckets can be given by name or UUID. An empty bucket, e.g. "/assets", selects the
+// default_bucket of the profile or APILLON_DEFAULT_BUCKET.
+//
+// Exit codes:
+//
+//	0  success
+//	1  unexpected error
+//	2  invalid usage or input
+//	3  authentication or authorization failure
+//	4  not found
+//	5  rate limited
+//	6  Apillon API server error
+//
+// With --output json, errors are written to stderr as a JSON object with the message, category
+// and exit code. When GITHUB_ACTIONS is "true", failures are also reported as error annotations.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/Apillon/go-sdk/credentials"
+)
+
+// globalFlags are the flags shared by every command.
+type globalFlags struct {
+	APIKey  string
+	Profile string
+	Output  string
+}
+
+func (g *globalFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&g.APIKey, "api-key", g.APIKey, "Apillon API key")
+	fs.StringVar(&g.Profile, "profile", g.Profile, "profile in the config file")
+	fs.StringVar(&g.Output, "output", g.Output, "output format: json, table or yaml")
+}
+
+// command is a leaf command. run receives the context, the parsed flags and positional arguments.
+type command struct {
+	usage  string
+	noAuth bool // runs without credentials
+	flags  func(fs *flag.FlagSet)
+	run    func(ctx context.Context, env *env, args []string) (result, error)
+}
+
+// env is the state shared by a command invocation.
+type env struct {
+	globals globalFlags
+	creds   credentials.Credentials
+	flags   *flag.FlagSet
+	stdout  io.Writer
+	stderr  io.Writer
+}
+
+// flag returns the value of a command flag.
+func (e *env) flag(name string) string {
+	return e.flags.Lookup(name).Value.String()
+}
+
+// intFlag returns the value of an integer command flag.
+func (e *env) intFlag(name string) int {
+	return e.flags.Lookup(name).Value.(flag.Getter).Get().(int)
+}
+
+// floatFlag returns the value of a float command flag.
+func (e *env) floatFlag(name string) float64 {
+	return e.flags.Lookup(name).Value.(flag.Getter).Get().(float64)
+}
+
+// progress reports a step of a long-running command on stderr, keeping stdout for the result.
+func (e *env) progress(msg string) {
+	fmt.Fprintf(e.stderr, "%s\n", msg)
+}
+
+// boolFlag returns the value of a boolean command flag.
+func (e *env) boolFlag(name string) bool {
+	return e.flag(name) == "true"
+}
+
+// services maps "<service> <command>" paths to commands.
+var services = map[string]map[string]*command{
+	"storage": storageCommands,
+	"hosting": hostingCommands,
+	"nft":     nftCommands,
+	"config":  configCommands,
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run executes the command line args and returns the process exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	// Failures before the flags are parsed, such as an unknown command, still honour --output
+	e := &env{globals: globalFlags{Output: outputFormat(args)}, stdout: stdout, stderr: stderr}
+
+	cmd, name, rest, err := lookup(args)
+	if err != nil {
+		return e.fail("", err)
+	}
+
+	fs := flag.NewFlagSet("apillon "+name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	e.globals.register(fs)
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+	e.flags = fs
+	positional, err := parseInterspersed(fs, rest)
+	if err != nil {
+		return e.fail(name, usageErrorf("%v\nusage: apillon %s", err, cmd.usage))
+	}
+
+	if err := validateOutput(e.globals.Output); err != nil {
+		e.globals.Output = "table"
+		return e.fail(name, err)
+	}
+	if e.creds, err = configureCredentials(e.globals); err != nil && !(cmd.noAuth && errors.Is(err, credentials.ErrNoCredentials)) {
+		return e.fail(name, err)
+	}
+
+	// Partial results, e.g. of a bulk delete with failures, are written before the error.
+	res, err := cmd.run(ctx, e, positional)
+	if writeErr := writeResult(stdout, e.globals.Output, res); err == nil {
+		err = writeErr
+	}
+	if err != nil {
+		return e.fail(name, err)
+	}
+	return exitOK
+}
+
+// lookup finds the command named by the leading non-flag arguments and returns the remaining
+// arguments, flags included, with the command words removed.
+func lookup(args []string) (*command, string, []string, error) {
+	var words []string
+	consumed := map[int]bool{}
+	for i := 0; i < len(args) && len(words) < 3; i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		if strings.HasPrefix(arg, "-") {
+			if isGlobalFlag(arg) && !strings.Contains(arg, "=") {
+				i++
+			}
+			continue
+		}
+
+		words = append(words, arg)
+		consumed[i] = true
+		if cmd := leaf(words); cmd != nil {
+			var rest []string
+			for j, a := range args {
+				if !consumed[j] {
+					rest = append(rest, a)
+				}
+			}
+			return cmd, strings.Join(words, " "), rest, nil
+		}
+	}
+
+	if len(words) == 0 {
+		return nil, "", nil, usageErrorf("missing command\n%s", usageText())
+	}
+	return nil, "", nil, usageErrorf("unknown command %q\n%s", strings.Join(words, " "), usageText())
+}
+
+// leaf returns the command named by words, or nil.
+func leaf(words []string) *command {
+	if len(words) < 2 {
+		return nil
+	}
+	return services[words[0]][strings.Join(words[1:], " ")]
+}
+
+// outputFormat returns the last valid --output value in args, or "table" if there is none.
+func outputFormat(args []string) string {
+	format := "table"
+	for i := 0; i < len(args) && args[i] != "--"; i++ {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(args[i], "-"), "=")
+		if !strings.HasPrefix(args[i], "-") || name != "output" {
+			continue
+		}
+		if !hasValue {
+			if i+1 == len(args) {
+				break
+			}
+			i++
+			value = args[i]
+		}
+		if validateOutput(value) == nil {
+			format = value
+		}
+	}
+	return format
+}
+
+func isGlobalFlag(arg string) bool {
+	switch strings.TrimLeft(arg, "-") {
+	case "api-key", "profile", "output":
+		return true
+	}
+	return false
+}
+
+// parseInterspersed parses flags that may appear before, between or after positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		if args[0] == "--" {
+			return append(positional, args[1:]...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func usageText() string {
+	var b strings.Builder
+	b.WriteString("usage: apillon [--api-key KEY] [--profile NAME] [--output json|table|yaml] <service> <command> [args]\n\ncommands:\n")
+	for _, service := range sortedKeys(services) {
+		for _, name := range sortedKeys(services[service]) {
+			fmt.Fprintf(&b, "  %s\n", services[service][name].usage)
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// fail reports err on stderr, as JSON with --output json, and returns its exit code.
+// When running in GitHub Actions the error is also written as an error annotation.
+func (e *env) fail(command string, err error) int {
+	code := exitCode(err)
+
+	if e.globals.Output == "json" {
+		data, _ := json.Marshal(map[string]any{"error": map[string]any{
+			"command":  command,
+			"message":  err.Error(),
+			"category": category(code),
+			"exitCode": code,
+		}})
+		fmt.Fprintf(e.stderr, "%s\n", data)
+	} else {
+		fmt.Fprintf(e.stderr, "apillon: %v\n", err)
+	}
+
+	if os.Getenv("GITHUB_ACTIONS") == "true" {
+		// The runner picks up workflow commands on stderr too, which keeps stdout parseable.
+		fmt.Fprintln(e.stderr, githubAnnotation(command, code, err))
+	}
+	return code
+}
@@ -0,0 +1,446 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Apillon/go-sdk/credentials"
+	"github.com/Apillon/go-sdk/internal/apitest"
+	"github.com/Apillon/go-sdk/nft"
+	"github.com/Apillon/go-sdk/requests"
+	"github.com/Apillon/go-sdk/storage"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, exitOK},
+		{fmt.Errorf("boom"), exitError},
+		{usageErrorf("bad"), exitUsage},
+		{&storage.StorageError{Code: storage.ErrCodeInvalidInput, Message: "empty"}, exitUsage},
+		{&storage.StorageError{Code: 500, Err: &requests.APIError{Status: 401}}, exitAuth},
+		{&storage.StorageError{Code: 500, Err: &requests.APIError{Status: 404}}, exitNotFound},
+		{fmt.Errorf("bucket x: %w", errNotFound), exitNotFound},
+		{&storage.StorageError{Code: 500, Err: &requests.APIError{Status: 429}}, exitRateLimited},
+		{&storage.StorageError{Code: 500, Err: &requests.APIError{Status: 503}}, exitServer},
+		{&storage.StorageError{Code: 500, Err: &requests.APIError{Status: 422}}, exitUsage},
+		{&credentials.CredentialsError{Code: credentials.ErrCodeInvalidInput, Message: "bad config"}, exitUsage},
+		{credentials.ErrNoCredentials, exitAuth},
+	}
+	for _, tt := range tests {
+		if got := exitCode(tt.err); got != tt.want {
+			t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestExitCodeForNonJSONErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		want   int
+	}{
+		{http.StatusBadGateway, "<html><body>502 Bad Gateway</body></html>", exitServer},
+		{http.StatusServiceUnavailable, "upstream connect error", exitServer},
+		{http.StatusTooManyRequests, "<html><body>Too Many Requests</body></html>", exitRateLimited},
+		{http.StatusForbidden, "", exitAuth},
+	}
+	for _, tt := range tests {
+		server := apitest.NewServer(t)
+		server.Mux.HandleFunc("GET /storage/buckets/", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(tt.status)
+			fmt.Fprint(w, tt.body)
+		})
+		t.Setenv("APILLON_API_KEY", "test")
+		t.Setenv("APILLON_BASE_URL", server.URL)
+		t.Setenv("APILLON_PROFILE", "")
+		t.Setenv("APILLON_CONFIG", filepath.Join(t.TempDir(), "missing.toml"))
+
+		var stdout, stderr bytes.Buffer
+		if code := run(context.Background(), []string{"storage", "buckets", "ls"}, &stdout, &stderr); code != tt.want {
+			t.Errorf("status %d: exit code %d, want %d (stderr %q)", tt.status, code, tt.want, stderr.String())
+		}
+	}
+}
+
+func TestRunUsageErrors(t *testing.T) {
+	t.Setenv("APILLON_API_KEY", "test")
+	t.Setenv("APILLON_DEFAULT_BUCKET", "")
+	t.Setenv("APILLON_PROFILE", "")
+	t.Setenv("APILLON_CONFIG", filepath.Join(t.TempDir(), "missing.toml"))
+
+	for _, args := range [][]string{
+		{},
+		{"storage", "unknown"},
+		{"storage", "ls"},
+		{"--output", "xml", "storage", "buckets", "ls"},
+		{"storage", "link", "--bogus", "cid"},
+		{"--profile", "ci", "storage", "buckets", "ls"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := run(context.Background(), args, &stdout, &stderr); code != exitUsage {
+			t.Errorf("run(%q) = %d, want %d (stderr: %s)", args, code, exitUsage, stderr.String())
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	cmd, name, rest, err := lookup([]string{"--output", "json", "storage", "rm", "bucket/tmp", "--recursive"})
+	if err != nil || cmd != storageCommands["rm"] || name != "storage rm" {
+		t.Fatalf("lookup = %v, %q, %v", cmd, name, err)
+	}
+	if strings.Join(rest, " ") != "--output json bucket/tmp --recursive" {
+		t.Errorf("unexpected remaining args %q", rest)
+	}
+}
+
+func TestWriteResult(t *testing.T) {
+	value := []map[string]any{{"name": "site", "size": 10, "tags": []string{"a"}, "note": "yes"}}
+	res := result{value: value, header: []string{"NAME", "SIZE"}, rows: [][]string{{"site", "10 B"}}}
+
+	var b bytes.Buffer
+	if err := writeResult(&b, "yaml", res); err != nil {
+		t.Fatal(err)
+	}
+	if want := "-\n  name: site\n  note: \"yes\"\n  size: 10\n  tags:\n    - a\n"; b.String() != want {
+		t.Errorf("unexpected YAML:\n%s", b.String())
+	}
+
+	b.Reset()
+	if err := writeResult(&b, "table", res); err != nil {
+		t.Fatal(err)
+	}
+	if want := "NAME  SIZE\nsite  10 B\n"; b.String() != want {
+		t.Errorf("unexpected table:\n%s", b.String())
+	}
+
+	b.Reset()
+	if err := writeResult(&b, "table", result{value: map[string]string{"cid": "Qm", "link": "https://x"}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "cid:   Qm\nlink:  https://x\n"; b.String() != want {
+		t.Errorf("unexpected key/value table:\n%s", b.String())
+	}
+}
+
+func TestConfigShow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	config := "[ci]\napi_key = \"ci-key-1234\"\ndefault_bucket = \"site\"\n"
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("APILLON_API_KEY", "")
+	t.Setenv("APILLON_PROFILE", "")
+	t.Setenv("APILLON_CONFIG", path)
+
+	var stdout, stderr bytes.Buffer
+	if code := run(context.Background(), []string{"--profile", "ci", "--output", "json", "config", "show"}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("run = %d (stderr: %s)", code, stderr.String())
+	}
+	var shown map[string]string
+	if err := json.Unmarshal(stdout.Bytes(), &shown); err != nil {
+		t.Fatal(err)
+	}
+	if shown["source"] != "profile" || shown["origin"] != path+" [ci]" || shown["apiKey"] != "********1234" || shown["defaultBucket"] != "site" {
+		t.Errorf("unexpected config %v", shown)
+	}
+
+	stdout.Reset()
+	if code := run(context.Background(), []string{"config", "show"}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("expected config show to succeed without credentials, got %d", code)
+	}
+	if code := run(context.Background(), []string{"storage", "buckets", "ls"}, &stdout, &stderr); code != exitAuth {
+		t.Errorf("expected auth error without credentials, got %d", code)
+	}
+}
+
+func TestProfileWithoutAPIKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("[staging]\nbase_url = \"https://staging.example\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("APILLON_API_KEY", "env-key")
+	t.Setenv("APILLON_PROFILE", "")
+	t.Setenv("APILLON_CONFIG", path)
+
+	var stdout, stderr bytes.Buffer
+	if code := run(context.Background(), []string{"--profile", "staging", "storage", "buckets", "ls"}, &stdout, &stderr); code != exitUsage {
+		t.Errorf("run = %d, want %d (stderr: %s)", code, exitUsage, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), `profile "staging"`) {
+		t.Errorf("unexpected error %q", stderr.String())
+	}
+}
+
+func TestMask(t *testing.T) {
+	for secret, want := range map[string]string{"": "", "abc": "***", "0123456789": "********6789"} {
+		if got := mask(secret); got != want {
+			t.Errorf("mask(%q) = %q, want %q", secret, got, want)
+		}
+	}
+}
+
+func file(p string, size int64, updated time.Time) storage.FileInfo {
+	dir, name := filepath.Split(p)
+	f := storage.FileInfo{FileUUID: "uuid-" + p, Name: name, Size: storage.ByteSize(size)}
+	if dir != "" {
+		f.Path = &dir
+	}
+	f.UpdateTime.Time = updated
+	return f
+}
+
+func TestPlanSync(t *testing.T) {
+	now := time.Now()
+	local := []localFile{
+		{Path: "index.html", Size: 10, ModTime: now.Add(-time.Hour)},
+		{Path: "css/site.css", Size: 20, ModTime: now},
+		{Path: "new.txt", Size: 5, ModTime: now},
+		{Path: "app.js", Size: 30, ModTime: now.Add(-time.Hour)},
+	}
+	remote := []storage.FileInfo{
+		file("www/index.html", 10, now),
+		file("www/css/site.css", 20, now.Add(-2*time.Hour)),
+		file("www/app.js", 31, now),
+		file("www/old.txt", 1, now),
+	}
+
+	var got []string
+	for _, a := range planSync(local, remote, "www", true) {
+		got = append(got, a.Action+" "+a.Path+" ("+a.Reason+")")
+	}
+	want := []string{
+		"upload www/app.js (size changed)",
+		"upload www/css/site.css (modified)",
+		"upload www/new.txt (new)",
+		"delete www/old.txt (not in source)",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected plan:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestSyncDeleteReportsFailures(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.AddFile("site-uuid", "www/index.html", "<html>")
+	server.AddFile("site-uuid", "www/a.txt", "a")
+	broken := server.AddFile("site-uuid", "www/b.txt", "b")
+	server.AddFile("site-uuid", "www/c.txt", "c")
+	server.Mux.HandleFunc("GET /storage/buckets/", func(w http.ResponseWriter, r *http.Request) {
+		apitest.WriteJSON(w, map[string]any{"items": []storage.BucketItem{{BucketUUID: "site-uuid", Name: "site"}}, "total": 1})
+	})
+	server.Mux.HandleFunc("DELETE /storage/buckets/site-uuid/files/"+broken.UUID, func(w http.ResponseWriter, r *http.Request) {
+		apitest.WriteError(w, http.StatusInternalServerError, 50000000, "delete failed")
+	})
+	t.Setenv("APILLON_API_KEY", "test")
+	t.Setenv("APILLON_BASE_URL", server.URL)
+	t.Setenv("APILLON_PROFILE", "")
+	t.Setenv("APILLON_CONFIG", filepath.Join(t.TempDir(), "missing.toml"))
+
+	// The local copy of index.html is older than the remote one, so only deletes are planned
+	dir := t.TempDir()
+	local := filepath.Join(dir, "index.html")
+	if err := os.WriteFile(local, []byte("<html>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(local, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"--output", "json", "storage", "sync", dir, "site/www", "--delete"}, &stdout, &stderr)
+	if code == exitOK || !strings.Contains(stderr.String(), "1 of 3 deletes failed") {
+		t.Errorf("run = %d, stderr: %s", code, stderr.String())
+	}
+
+	var remaining []string
+	for _, f := range server.Files("site-uuid") {
+		remaining = append(remaining, f.Path())
+	}
+	if strings.Join(remaining, ",") != "www/b.txt,www/index.html" {
+		t.Errorf("remaining files %v, want the failed delete and index.html", remaining)
+	}
+}
+
+func TestUploadInBatches(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.Mux.HandleFunc("GET /storage/buckets/", func(w http.ResponseWriter, r *http.Request) {
+		apitest.WriteJSON(w, map[string]any{"items": []storage.BucketItem{{BucketUUID: "site-uuid", Name: "site"}}, "total": 1})
+	})
+	t.Setenv("APILLON_API_KEY", "test")
+	t.Setenv("APILLON_BASE_URL", server.URL)
+	t.Setenv("APILLON_PROFILE", "")
+	t.Setenv("APILLON_CONFIG", filepath.Join(t.TempDir(), "missing.toml"))
+
+	dir := t.TempDir()
+	n := uploadBatchSize + 1
+	for i := range n {
+		name := filepath.Join(dir, "assets", fmt.Sprintf("%02d.txt", i))
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(fmt.Sprint(i)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "empty.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run(context.Background(), []string{"storage", "upload", dir, "site/www"}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("run = %d, stderr: %s", code, stderr.String())
+	}
+	if sessions := server.Count("POST /storage/buckets/site-uuid/upload") - server.Count("POST /storage/buckets/site-uuid/upload/"); sessions != 2 {
+		t.Errorf("upload used %d sessions, want 2", sessions)
+	}
+	files := server.Files("site-uuid")
+	if len(files) != n || files[0].Path() != "www/assets/00.txt" || files[n-1].Content != fmt.Sprint(n-1) {
+		t.Errorf("uploaded %d files, first %s", len(files), files[0].Path())
+	}
+}
+
+func TestDownloadTargets(t *testing.T) {
+	files := []storage.FileInfo{
+		file("docs/a.txt", 1, time.Time{}),
+		file("docs/sub/b.txt", 1, time.Time{}),
+		file("docsx/c.txt", 1, time.Time{}),
+	}
+
+	single, err := downloadTargets(files, "docs/a.txt", "")
+	if err != nil || len(single) != 1 || single[0].local != "a.txt" {
+		t.Errorf("unexpected single file target %+v, %v", single, err)
+	}
+
+	dir, err := downloadTargets(files, "docs", "out")
+	if err != nil || len(dir) != 2 || dir[1].local != filepath.Join("out", "sub", "b.txt") {
+		t.Errorf("unexpected directory targets %+v, %v", dir, err)
+	}
+
+	if _, err := downloadTargets(files, "missing", ""); exitCode(err) != exitNotFound {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestSplitBucketPath(t *testing.T) {
+	if b, p := splitBucketPath("site/assets/img/"); b != "site" || p != "assets/img" {
+		t.Errorf("splitBucketPath = %q, %q", b, p)
+	}
+	if !isUUID("123e4567-e89b-12d3-a456-426614174000") || isUUID("my-bucket") {
+		t.Error("unexpected isUUID result")
+	}
+	if got := joinRemoteDir("www", "css/"); got != "www/css/" {
+		t.Errorf("joinRemoteDir = %q", got)
+	}
+}
+
+func TestServiceValidationErrors(t *testing.T) {
+	t.Setenv("APILLON_API_KEY", "test")
+
+	for _, args := range [][]string{
+		{"hosting", "deploy", "./dist"},
+		{"hosting", "deploy", "./dist", "--website", "w", "--env", "preview"},
+		{"hosting", "deployments", "ls"},
+		{"nft", "collections", "create", "--name", "n", "--symbol", "S"},
+		{"nft", "collections", "create", "--name", "n", "--symbol", "S", "--chain", "moonbeam", "--royalties-fees", "150"},
+		{"nft", "mint", "collection", "--to", "not-an-address"},
+		{"nft", "mint", "collection", "--to", "0x0000000000000000000000000000000000000001", "--ids", "1,x"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := run(context.Background(), args, &stdout, &stderr); code != exitUsage {
+			t.Errorf("run(%q) = %d, want %d (stderr: %s)", args, code, exitUsage, stderr.String())
+		}
+	}
+}
+
+func TestFailureReporting(t *testing.T) {
+	t.Setenv("APILLON_API_KEY", "test")
+	t.Setenv("GITHUB_ACTIONS", "true")
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"--output", "json", "hosting", "deployments", "ls"}, &stdout, &stderr)
+	if code != exitUsage || stdout.Len() != 0 {
+		t.Fatalf("unexpected exit code %d or stdout %q", code, stdout.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected JSON error and annotation, got %q", stderr.String())
+	}
+	var report struct {
+		Error struct {
+			Command  string `json:"command"`
+			Message  string `json:"message"`
+			Category string `json:"category"`
+			ExitCode int    `json:"exitCode"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &report); err != nil {
+		t.Fatalf("invalid JSON error %q: %v", lines[0], err)
+	}
+	if report.Error.Command != "hosting deployments ls" || report.Error.Category != "validation" || report.Error.ExitCode != exitUsage {
+		t.Errorf("unexpected error report %+v", report.Error)
+	}
+	if lines[1] != "::error title=apillon hosting deployments ls failed (validation)::--website is required" {
+		t.Errorf("unexpected annotation %q", lines[1])
+	}
+}
+
+func TestFailureReportingBeforeFlagParsing(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "")
+
+	for _, args := range [][]string{
+		{"--output", "json", "storage", "unknown"},
+		{"storage", "unknown", "-output=json"},
+		{"storage", "link", "--bogus", "--output", "json"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := run(context.Background(), args, &stdout, &stderr); code != exitUsage {
+			t.Errorf("run(%q) = %d, want %d", args, code, exitUsage)
+		}
+		var report struct {
+			Error struct {
+				Category string `json:"category"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(stderr.Bytes(), &report); err != nil || report.Error.Category != "validation" {
+			t.Errorf("run(%q) reported %q, want a JSON validation error", args, stderr.String())
+		}
+	}
+}
+
+func TestGithubAnnotationEscaping(t *testing.T) {
+	err := &storage.StorageError{Code: 500, Message: "upload failed", Err: &requests.APIError{Status: 503, Message: "100%\nretry"}}
+	got := githubAnnotation("storage upload", exitCode(err), err)
+	want := "::error title=apillon storage upload failed (server)::storage error (code 500): upload failed: API error (status 503): 100%25%0Aretry"
+	if got != want {
+		t.Errorf("githubAnnotation =\n%s\nwant\n%s", got, want)
+	}
+	if got := escapeProperty("a:b,c"); got != "a%3Ab%2Cc" {
+		t.Errorf("escapeProperty = %q", got)
+	}
+}
+
+func TestParseChain(t *testing.T) {
+	if chain, err := parseChain("Moonbeam", evmChains); err != nil || chain != nft.EvmChainMoonbeam {
+		t.Errorf("parseChain(Moonbeam) = %d, %v", chain, err)
+	}
+	if chain, err := parseChain("8", substrateChains); err != nil || chain != nft.SubstrateChainAstar {
+		t.Errorf("parseChain(8) = %d, %v", chain, err)
+	}
+	if _, err := parseChain("mars", evmChains); exitCode(err) != exitUsage {
+		t.Errorf("expected usage error, got %v", err)
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strconv"
+	"strings"
+
+	"github.com/Apillon/go-sdk/nft"
+)
+
+var nftCommands = map[string]*command{
+	"collections ls": {
+		usage: "nft collections ls",
+		run:   runCollectionsList,
+	},
+	"collections create": {
+		usage: "nft collections create --name NAME --symbol SYMBOL --chain CHAIN [--chain-type evm|substrate] [flags] [--wait]",
+		flags: func(fs *flag.FlagSet) {
+			fs.String("name", "", "collection name")
+			fs.String("symbol", "", "token symbol")
+			fs.String("chain", "", "chain name or ID, e.g. moonbeam or 1284")
+			fs.String("chain-type", "evm", "chain family: evm or substrate")
+			fs.String("description", "", "collection description")
+			fs.String("base-uri", "", "base URI of the token metadata")
+			fs.String("base-extension", ".json", "extension appended to token metadata URIs")
+			fs.Int("max-supply", 0, "maximum number of tokens, 0 for unlimited")
+			fs.Bool("nestable", false, "create a nestable collection")
+			fs.Bool("revokable", false, "allow the owner to burn tokens")
+			fs.Bool("soulbound", false, "make tokens non-transferable")
+			fs.Bool("auto-increment", true, "assign token IDs automatically")
+			fs.String("royalties-address", "", "address receiving royalties")
+			fs.Float64("royalties-fees", 0, "royalties in percent")
+			fs.Bool("wait", false, "wait until the collection is deployed")
+		},
+		run: runCollectionsCreate,
+	},
+	"mint": {
+		usage: "nft mint <collectionUuid> --to ADDRESS [--quantity N] [--ids 1,2] [--wait]",
+		flags: func(fs *flag.FlagSet) {
+			fs.String("to", "", "receiving address")
+			fs.Int("quantity", 1, "number of tokens to mint")
+			fs.String("ids", "", "comma-separated token IDs, for collections without auto increment")
+			fs.Bool("wait", false, "wait until the mint transaction is confirmed")
+		},
+		run: runMint,
+	},
+}
+
+// evmChains and substrateChains map chain names accepted by --chain to chain IDs.
+var (
+	evmChains = map[string]nft.EvmChain{
+		"ethereum":         nft.EvmChainEthereum,
+		"sepolia":          nft.EvmChainSepolia,
+		"moonbeam":         nft.EvmChainMoonbeam,
+		"moonbase":         nft.EvmChainMoonbase,
+		"astar":            nft.EvmChainAstar,
+		"celo":             nft.EvmChainCelo,
+		"alfajores":        nft.EvmChainAlfajores,
+		"base":             nft.EvmChainBase,
+		"base-sepolia":     nft.EvmChainBaseSepolia,
+		"arbitrum-one":     nft.EvmChainArbitrumOne,
+		"arbitrum-sepolia": nft.EvmChainArbitrumSepolia,
+		"avalanche":        nft.EvmChainAvalanche,
+		"avalanche-fuji":   nft.EvmChainAvalancheFuji,
+		"optimism":         nft.EvmChainOptimism,
+		"optimism-sepolia": nft.EvmChainOptimismSepolia,
+		"polygon":          nft.EvmChainPolygon,
+		"polygon-amoy":     nft.EvmChainPolygonAmoy,
+	}
+	substrateChains = map[string]nft.SubstrateChain{
+		"astar":  nft.SubstrateChainAstar,
+		"unique": nft.SubstrateChainUnique,
+	}
+)
+
+func runCollectionsList(ctx context.Context, e *env, args []string) (result, error) {
+	if err := wantArgs(args, 0, 0, "nft collections ls"); err != nil {
+		return result{}, err
+	}
+
+	collections, err := nft.ListCollections(ctx)
+	if err != nil {
+		return result{}, err
+	}
+
+	res := result{value: collections.Data.Items, header: []string{"UUID", "NAME", "SYMBOL", "CHAIN", "STATUS", "CONTRACT"}}
+	for _, c := range collections.Data.Items {
+		res.rows = append(res.rows, []string{
+			c.CollectionUUID, c.Name, c.Symbol, c.ChainType.String() + ":" + strconv.Itoa(c.Chain),
+			c.CollectionStatus.String(), c.ContractAddress,
+		})
+	}
+	return res, nil
+}
+
+func runCollectionsCreate(ctx context.Context, e *env, args []string) (result, error) {
+	if err := wantArgs(args, 0, 0, "nft collections create --name NAME --symbol SYMBOL --chain CHAIN [flags]"); err != nil {
+		return result{}, err
+	}
+	if e.flag("chain") == "" {
+		return result{}, usageErrorf("--chain is required")
+	}
+
+	settings := nft.CollectionSettings{
+		CollectionType:   nft.CollectionTypeGeneric,
+		Name:             e.flag("name"),
+		Symbol:           e.flag("symbol"),
+		Description:      e.flag("description"),
+		BaseURI:          e.flag("base-uri"),
+		BaseExtension:    e.flag("base-extension"),
+		MaxSupply:        e.intFlag("max-supply"),
+		IsRevokable:      e.boolFlag("revokable"),
+		IsSoulbound:      e.boolFlag("soulbound"),
+		IsAutoIncrement:  e.boolFlag("auto-increment"),
+		RoyaltiesAddress: e.flag("royalties-address"),
+		RoyaltiesFees:    e.floatFlag("royalties-fees"),
+	}
+	if e.boolFlag("nestable") {
+		settings.CollectionType = nft.CollectionTypeNestable
+	}
+
+	var created nft.CollectionResponse
+	switch e.flag("chain-type") {
+	case "evm":
+		chain, err := parseChain(e.flag("chain"), evmChains)
+		if err != nil {
+			return result{}, err
+		}
+		created, err = nft.CreateEvmCollection(ctx, nft.CreateEvmCollectionRequest{CollectionSettings: settings, Chain: chain})
+		if err != nil {
+			return result{}, err
+		}
+	case "substrate":
+		chain, err := parseChain(e.flag("chain"), substrateChains)
+		if err != nil {
+			return result{}, err
+		}
+		created, err = nft.CreateSubstrateCollection(ctx, nft.CreateSubstrateCollectionRequest{CollectionSettings: settings, Chain: chain})
+		if err != nil {
+			return result{}, err
+		}
+	default:
+		return result{}, usageErrorf("unknown chain type %q, expected evm or substrate", e.flag("chain-type"))
+	}
+
+	collection := created.Data
+	if e.boolFlag("wait") {
+		deployed, err := nft.WaitForCollectionDeployed(ctx, collection.CollectionUUID, nft.WaitOptions{
+			OnCollection: func(ev nft.CollectionEvent) {
+				e.progress("collection " + ev.Collection.CollectionStatus.String())
+			},
+		})
+		if err != nil {
+			return result{}, err
+		}
+		collection = deployed
+	}
+	return result{value: collection}, nil
+}
+
+func runMint(ctx context.Context, e *env, args []string) (result, error) {
+	if err := wantArgs(args, 1, 1, "nft mint <collectionUuid> --to ADDRESS [--quantity N] [--ids 1,2] [--wait]"); err != nil {
+		return result{}, err
+	}
+
+	req := nft.MintRequest{ReceivingAddress: e.flag("to"), Quantity: e.intFlag("quantity")}
+	if ids := e.flag("ids"); ids != "" {
+		for _, id := range strings.Split(ids, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(id))
+			if err != nil {
+				return result{}, usageErrorf("invalid token ID %q", id)
+			}
+			req.IdsToMint = append(req.IdsToMint, n)
+		}
+		if !isFlagSet(e.flags, "quantity") {
+			req.Quantity = len(req.IdsToMint)
+		}
+	}
+
+	minted, err := nft.Mint(ctx, args[0], req)
+	if err != nil {
+		return result{}, err
+	}
+
+	out := map[string]any{"transactionHash": minted.Data.TransactionHash, "success": minted.Data.Success}
+	if e.boolFlag("wait") {
+		tx, err := nft.WaitForTransaction(ctx, args[0], minted.Data.TransactionHash, nft.WaitOptions{
+			OnTransaction: func(ev nft.TransactionEvent) {
+				e.progress("transaction " + ev.Transaction.TransactionStatus.String())
+			},
+		})
+		if err != nil {
+			return result{}, err
+		}
+		out["status"] = tx.TransactionStatus.String()
+	}
+	return result{value: out}, nil
+}
+
+// parseChain parses a chain name or numeric ID.
+func parseChain[C ~int](value string, names map[string]C) (C, error) {
+	if chain, ok := names[strings.ToLower(value)]; ok {
+		return chain, nil
+	}
+	if id, err := strconv.Atoi(value); err == nil && id > 0 {
+		return C(id), nil
+	}
+	return 0, usageErrorf("unknown chain %q", value)
+}
+
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
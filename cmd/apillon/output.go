@@ -0,0 +1,189 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// result is the output of a command: value is printed as JSON or YAML, header and rows as a table.
+// A command without rows prints value in table mode as "key: value" lines.
+type result struct {
+	value  any
+	header []string
+	rows   [][]string
+}
+
+func validateOutput(format string) error {
+	switch format {
+	case "json", "table", "yaml":
+		return nil
+	}
+	return usageErrorf("unknown output format %q, expected json, table or yaml", format)
+}
+
+func writeResult(w io.Writer, format string, res result) error {
+	if res.value == nil && res.rows == nil {
+		return nil
+	}
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(res.value, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case "yaml":
+		generic, err := toGeneric(res.value)
+		if err != nil {
+			return err
+		}
+		var b strings.Builder
+		writeYAML(&b, generic, 0)
+		_, err = io.WriteString(w, b.String())
+		return err
+	default:
+		return writeTable(w, res)
+	}
+}
+
+func writeTable(w io.Writer, res result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if res.header == nil {
+		generic, err := toGeneric(res.value)
+		if err != nil {
+			return err
+		}
+		if m, ok := generic.(map[string]any); ok {
+			for _, key := range sortedKeys(m) {
+				fmt.Fprintf(tw, "%s:\t%s\n", key, scalar(m[key]))
+			}
+			return tw.Flush()
+		}
+		fmt.Fprintln(tw, scalar(generic))
+		return tw.Flush()
+	}
+
+	fmt.Fprintln(tw, strings.Join(res.header, "\t"))
+	for _, row := range res.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// toGeneric converts v to maps, slices and scalars through JSON so JSON field names are kept.
+func toGeneric(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// writeYAML writes a value produced by toGeneric as block-style YAML.
+func writeYAML(b *strings.Builder, v any, indent int) {
+	pad := strings.Repeat("  ", indent)
+	switch v := v.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			b.WriteString(pad + "{}\n")
+			return
+		}
+		for _, key := range sortedKeys(v) {
+			writeYAMLEntry(b, pad+yamlString(key)+":", v[key], indent)
+		}
+	case []any:
+		if len(v) == 0 {
+			b.WriteString(pad + "[]\n")
+			return
+		}
+		for _, item := range v {
+			writeYAMLEntry(b, pad+"-", item, indent)
+		}
+	default:
+		b.WriteString(pad + yamlScalar(v) + "\n")
+	}
+}
+
+func writeYAMLEntry(b *strings.Builder, prefix string, v any, indent int) {
+	switch c := v.(type) {
+	case map[string]any:
+		if len(c) > 0 {
+			b.WriteString(prefix + "\n")
+			writeYAML(b, c, indent+1)
+			return
+		}
+		b.WriteString(prefix + " {}\n")
+	case []any:
+		if len(c) > 0 {
+			b.WriteString(prefix + "\n")
+			writeYAML(b, c, indent+1)
+			return
+		}
+		b.WriteString(prefix + " []\n")
+	default:
+		b.WriteString(prefix + " " + yamlScalar(v) + "\n")
+	}
+}
+
+func yamlScalar(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return yamlString(v)
+	default:
+		return scalar(v)
+	}
+}
+
+// yamlString quotes s unless it is a plain YAML string that cannot be mistaken for another type.
+func yamlString(s string) string {
+	switch strings.ToLower(s) {
+	case "", "null", "~", "true", "false", "yes", "no", "on", "off":
+		return strconv.Quote(s)
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.Quote(s)
+	}
+	if strings.ContainsAny(s, ":#{}[],&*!|>'\"%@`\n\t") || strings.TrimSpace(s) != s || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "?") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// scalar formats a generic value for a table cell.
+func scalar(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]any, []any:
+		data, _ := json.Marshal(v)
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,637 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Apillon/go-sdk/requests"
+	"github.com/Apillon/go-sdk/storage"
+)
+
+// uploadBatchSize is the number of files read and uploaded per session, as in the SDK's backup restore.
+const uploadBatchSize = 50
+
+var storageCommands = map[string]*command{
+	"buckets ls": {
+		usage: "storage buckets ls",
+		run:   runBucketsList,
+	},
+	"buckets create": {
+		usage: "storage buckets create <name> [--description TEXT]",
+		flags: func(fs *flag.FlagSet) { fs.String("description", "", "bucket description") },
+		run:   runBucketsCreate,
+	},
+	"buckets delete": {
+		usage: "storage buckets delete <bucket>",
+		run:   runBucketsDelete,
+	},
+	"ls": {
+		usage: "storage ls <bucket>[/path]",
+		run:   runList,
+	},
+	"upload": {
+		usage: "storage upload <dir|file> <bucket>[/path]",
+		run:   runUpload,
+	},
+	"download": {
+		usage: "storage download <bucket>/<path> [destination]",
+		run:   runDownload,
+	},
+	"rm": {
+		usage: "storage rm <bucket>/<path> [--recursive] [--dry-run]",
+		flags: func(fs *flag.FlagSet) {
+			fs.Bool("recursive", false, "delete every file under the path")
+			fs.Bool("dry-run", false, "list the files that would be deleted")
+		},
+		run: runRemove,
+	},
+	"sync": {
+		usage: "storage sync <dir> <bucket>[/path] [--delete] [--dry-run]",
+		flags: func(fs *flag.FlagSet) {
+			fs.Bool("delete", false, "delete remote files that do not exist locally")
+			fs.Bool("dry-run", false, "list the changes without applying them")
+		},
+		run: runSync,
+	},
+	"info": {
+		usage: "storage info <fileUuid> [--bucket BUCKET]",
+		flags: func(fs *flag.FlagSet) { fs.String("bucket", "", "bucket containing the file; searched if omitted") },
+		run:   runInfo,
+	},
+	"link": {
+		usage: "storage link <cid>",
+		run:   runLink,
+	},
+}
+
+func runBucketsList(ctx context.Context, e *env, args []string) (result, error) {
+	if err := wantArgs(args, 0, 0, "storage buckets ls"); err != nil {
+		return result{}, err
+	}
+
+	buckets, err := storage.ListAllBuckets(ctx, "")
+	if err != nil {
+		return result{}, err
+	}
+
+	res := result{value: buckets, header: []string{"NAME", "UUID", "SIZE", "CREATED"}}
+	for _, b := range buckets {
+		res.rows = append(res.rows, []string{b.Name, b.BucketUUID, b.Size.String(), formatTime(b.CreateTime)})
+	}
+	return res, nil
+}
+
+func runBucketsCreate(ctx context.Context, e *env, args []string) (result, error) {
+	if err := wantArgs(args, 1, 1, "storage buckets create <name> [--description TEXT]"); err != nil {
+		return result{}, err
+	}
+
+	if err := storage.CreateBucket(ctx, args[0], e.flag("description")); err != nil {
+		return result{}, err
+	}
+	bucket, err := resolveBucket(ctx, e, args[0])
+	if err != nil {
+		return result{}, err
+	}
+	return result{value: bucket}, nil
+}
+
+func runBucketsDelete(ctx context.Context, e *env, args []string) (result, error) {
+	if err := wantArgs(args, 1, 1, "storage buckets delete <bucket>"); err != nil {
+		return result{}, err
+	}
+
+	bucket, err := resolveBucket(ctx, e, args[0])
+	if err != nil {
+		return result{}, err
+	}
+	if err := storage.DeleteBucket(ctx, bucket.BucketUUID); err != nil {
+		return result{}, err
+	}
+	return result{value: map[string]any{"bucketUuid": bucket.BucketUUID, "deleted": true}}, nil
+}
+
+func runList(ctx context.Context, e *env, args []string) (result, error) {
+	if err := wantArgs(args, 0, 1, "storage ls <bucket>[/path]"); err != nil {
+		return result{}, err
+	}
+
+	args = append(args, "")
+	_, files, prefix, err := bucketFiles(ctx, e, args[0])
+	if err != nil {
+		return result{}, err
+	}
+	files = underPath(files, prefix, true)
+
+	res := result{value: files, header: []string{"PATH", "SIZE", "CID", "UUID", "UPDATED"}}
+	for _, f := range files {
+		res.rows = append(res.rows, []string{f.FullPath(), f.Size.String(), f.CID, f.FileUUID, formatTime(f.UpdateTime)})
+	}
+	return res, nil
+}
+
+func runUpload(ctx context.Context, e *env, args []string) (result, error) {
+	if err := wantArgs(args, 2, 2, "storage upload <dir|file> <bucket>[/path]"); err != nil {
+		return result{}, err
+	}
+
+	root, files, err := listLocal(args[0])
+	if err != nil {
+		return result{}, err
+	}
+	ref, dest := splitBucketPath(args[1])
+	bucket, err := resolveBucket(ctx, e, ref)
+	if err != nil {
+		return result{}, err
+	}
+
+	type uploaded struct {
+		Path string           `json:"path"`
+		Size storage.ByteSize `json:"size"`
+	}
+	var out []uploaded
+	res := result{header: []string{"PATH", "SIZE"}}
+	for _, f := range files {
+		p := joinRemoteDir(dest, path.Dir(f.Path)) + path.Base(f.Path)
+		size := storage.ByteSize(f.Size)
+		out = append(out, uploaded{Path: p, Size: size})
+		res.rows = append(res.rows, []string{p, size.String()})
+	}
+	res.value = out
+
+	if err := uploadLocal(ctx, bucket.BucketUUID, root, files, dest); err != nil {
+		return result{}, err
+	}
+	return res, nil
+}
+
+func runDownload(ctx context.Context, e *env, args []string) (result, error) {
+	if err := wantArgs(args, 1, 2, "storage download <bucket>/<path> [destination]"); err != nil {
+		return result{}, err
+	}
+
+	_, files, prefix, err := bucketFiles(ctx, e, args[0])
+	if err != nil {
+		return result{}, err
+	}
+	dest := ""
+	if len(args) == 2 {
+		dest = args[1]
+	}
+
+	type downloaded struct {
+		Path  string           `json:"path"`
+		Local string           `json:"local"`
+		Size  storage.ByteSize `json:"size"`
+	}
+	var out []downloaded
+	res := result{header: []string{"PATH", "LOCAL", "SIZE"}}
+
+	targets, err := downloadTargets(files, prefix, dest)
+	if err != nil {
+		return result{}, err
+	}
+	for _, t := range targets {
+		if err := downloadTo(ctx, t.file.Link, t.local); err != nil {
+			return result{}, fmt.Errorf("download %s: %w", t.file.FullPath(), err)
+		}
+		out = append(out, downloaded{Path: t.file.FullPath(), Local: t.local, Size: t.file.Size})
+		res.rows = append(res.rows, []string{t.file.FullPath(), t.local, t.file.Size.String()})
+	}
+	res.value = out
+	return res, nil
+}
+
+func runRemove(ctx context.Context, e *env, args []string) (result, error) {
+	if err := wantArgs(args, 1, 1, "storage rm <bucket>/<path> [--recursive] [--dry-run]"); err != nil {
+		return result{}, err
+	}
+
+	bucket, files, prefix, err := bucketFiles(ctx, e, args[0])
+	if err != nil {
+		return result{}, err
+	}
+	recursive := e.boolFlag("recursive")
+	if prefix == "" && !recursive {
+		return result{}, usageErrorf("refusing to delete a whole bucket without --recursive")
+	}
+
+	matched := underPath(files, prefix, recursive)
+	if len(matched) == 0 {
+		if !recursive && len(underPath(files, prefix, true)) > 0 {
+			return result{}, usageErrorf("%s is a directory, use --recursive", args[0])
+		}
+		return result{}, fmt.Errorf("%s: %w", args[0], errNotFound)
+	}
+	selected := map[string]bool{}
+	for _, f := range matched {
+		selected[f.FileUUID] = true
+	}
+
+	results, err := storage.DeleteMatching(ctx, bucket.BucketUUID, func(f storage.FileInfo) bool {
+		return selected[f.FileUUID]
+	}, storage.DeleteOptions{DryRun: e.boolFlag("dry-run")})
+	if err != nil {
+		return result{}, err
+	}
+
+	type removed struct {
+		Path   string `json:"path"`
+		Status string `json:"status"`
+		Error  string `json:"error,omitempty"`
+	}
+	var out []removed
+	var firstErr error
+	res := result{header: []string{"PATH", "STATUS"}}
+	for _, r := range results {
+		item := removed{Path: r.File.FullPath(), Status: r.Status.String()}
+		if r.Err != nil {
+			item.Error = r.Err.Error()
+			if firstErr == nil {
+				firstErr = r.Err
+			}
+		}
+		out = append(out, item)
+		res.rows = append(res.rows, []string{item.Path, item.Status})
+	}
+	res.value = out
+	return res, firstErr
+}
+
+func runSync(ctx context.Context, e *env, args []string) (result, error) {
+	if err := wantArgs(args, 2, 2, "storage sync <dir> <bucket>[/path] [--delete] [--dry-run]"); err != nil {
+		return result{}, err
+	}
+
+	if info, err := os.Stat(args[0]); err != nil || !info.IsDir() {
+		return result{}, usageErrorf("%s is not a readable directory", args[0])
+	}
+	root, localFiles, err := listLocal(args[0])
+	if err != nil {
+		return result{}, err
+	}
+	bucket, files, prefix, err := bucketFiles(ctx, e, args[1])
+	if err != nil {
+		return result{}, err
+	}
+	actions := planSync(localFiles, underPath(files, prefix, true), prefix, e.boolFlag("delete"))
+
+	res := syncResult(actions)
+	if e.boolFlag("dry-run") {
+		return res, nil
+	}
+
+	uploads := map[string]bool{}
+	for _, a := range actions {
+		if a.Action == syncUpload {
+			uploads[a.local] = true
+		}
+	}
+	var toUpload []localFile
+	for _, f := range localFiles {
+		if uploads[f.Path] {
+			toUpload = append(toUpload, f)
+		}
+	}
+	if err := uploadLocal(ctx, bucket.BucketUUID, root, toUpload, prefix); err != nil {
+		return res, err
+	}
+
+	deletes := map[string]int{}
+	for i, a := range actions {
+		if a.Action == syncDelete {
+			deletes[a.FileUUID] = i
+		}
+	}
+	if len(deletes) == 0 {
+		return res, nil
+	}
+	results, err := storage.DeleteMatching(ctx, bucket.BucketUUID, func(f storage.FileInfo) bool {
+		_, ok := deletes[f.FileUUID]
+		return ok
+	}, storage.DeleteOptions{})
+	if err != nil {
+		return res, err
+	}
+
+	var firstErr error
+	failed := 0
+	for _, r := range results {
+		if r.Err == nil {
+			continue
+		}
+		a := &actions[deletes[r.File.FileUUID]]
+		a.Error = r.Err.Error()
+		failed++
+		if firstErr == nil {
+			firstErr = fmt.Errorf("delete %s: %w", a.Path, r.Err)
+		}
+	}
+	res = syncResult(actions)
+	if firstErr != nil {
+		return res, fmt.Errorf("%d of %d deletes failed, first: %w", failed, len(deletes), firstErr)
+	}
+	return res, nil
+}
+
+// syncResult lists sync actions, showing the error of failed deletes in place of their reason.
+func syncResult(actions []syncAction) result {
+	res := result{value: actions, header: []string{"ACTION", "PATH", "REASON"}}
+	for _, a := range actions {
+		reason := a.Reason
+		if a.Error != "" {
+			reason = "failed: " + a.Error
+		}
+		res.rows = append(res.rows, []string{a.Action, a.Path, reason})
+	}
+	return res
+}
+
+func runInfo(ctx context.Context, e *env, args []string) (result, error) {
+	if err := wantArgs(args, 1, 1, "storage info <fileUuid> [--bucket BUCKET]"); err != nil {
+		return result{}, err
+	}
+
+	var buckets []storage.BucketItem
+	if ref := e.flag("bucket"); ref != "" {
+		bucket, err := resolveBucket(ctx, e, ref)
+		if err != nil {
+			return result{}, err
+		}
+		buckets = []storage.BucketItem{bucket}
+	} else {
+		all, err := storage.ListAllBuckets(ctx, "")
+		if err != nil {
+			return result{}, err
+		}
+		buckets = all
+	}
+
+	for _, b := range buckets {
+		details, err := storage.GetFileDetails(ctx, b.BucketUUID, args[0])
+		if err != nil {
+			var apiErr *requests.APIError
+			if errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound {
+				continue
+			}
+			return result{}, err
+		}
+		return result{value: details.Data}, nil
+	}
+	return result{}, fmt.Errorf("file %s: %w", args[0], errNotFound)
+}
+
+func runLink(ctx context.Context, e *env, args []string) (result, error) {
+	if err := wantArgs(args, 1, 1, "storage link <cid>"); err != nil {
+		return result{}, err
+	}
+
+	link, err := storage.GetOrGenerateIPFSLink(ctx, args[0])
+	if err != nil {
+		return result{}, err
+	}
+	return result{value: map[string]string{"cid": args[0], "link": link}}, nil
+}
+
+// resolveBucket finds a bucket by UUID or exact name. An empty ref selects the default bucket.
+func resolveBucket(ctx context.Context, e *env, ref string) (storage.BucketItem, error) {
+	if ref == "" {
+		ref = e.creds.DefaultBucket
+	}
+	if ref == "" {
+		return storage.BucketItem{}, usageErrorf("no bucket given and no default bucket configured")
+	}
+
+	name := ""
+	if !isUUID(ref) {
+		name = ref
+	}
+	buckets, err := storage.ListAllBuckets(ctx, name)
+	if err != nil {
+		return storage.BucketItem{}, err
+	}
+
+	var found []storage.BucketItem
+	for _, b := range buckets {
+		if b.BucketUUID == ref || b.Name == ref {
+			found = append(found, b)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return storage.BucketItem{}, fmt.Errorf("bucket %s: %w", ref, errNotFound)
+	case 1:
+		return found[0], nil
+	default:
+		return storage.BucketItem{}, usageErrorf("%d buckets are named %q, use the bucket UUID", len(found), ref)
+	}
+}
+
+// bucketFiles resolves a <bucket>[/path] argument and lists every file in the bucket.
+func bucketFiles(ctx context.Context, e *env, arg string) (storage.BucketItem, []storage.FileInfo, string, error) {
+	ref, prefix := splitBucketPath(arg)
+	bucket, err := resolveBucket(ctx, e, ref)
+	if err != nil {
+		return storage.BucketItem{}, nil, "", err
+	}
+	files, err := storage.ListAllFilesInBucket(ctx, bucket.BucketUUID)
+	if err != nil {
+		return storage.BucketItem{}, nil, "", err
+	}
+	return bucket, files, prefix, nil
+}
+
+// splitBucketPath splits "<bucket>/<path>" into the bucket and a path without surrounding slashes.
+func splitBucketPath(arg string) (string, string) {
+	bucket, p, _ := strings.Cut(arg, "/")
+	return bucket, strings.Trim(p, "/")
+}
+
+// underPath returns the files at p or, if dirs is set, anywhere under the directory p, sorted by path.
+func underPath(files []storage.FileInfo, p string, dirs bool) []storage.FileInfo {
+	var matched []storage.FileInfo
+	for _, f := range files {
+		full := strings.TrimPrefix(f.FullPath(), "/")
+		if p == "" && dirs || full == p || dirs && strings.HasPrefix(full, p+"/") {
+			matched = append(matched, f)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].FullPath() < matched[j].FullPath() })
+	return matched
+}
+
+// joinRemoteDir joins a destination directory and a FileMetadata.Path, keeping the trailing slash.
+func joinRemoteDir(dest string, dir string) string {
+	joined := path.Join(dest, dir)
+	if joined == "." || joined == "" {
+		return ""
+	}
+	return joined + "/"
+}
+
+type downloadTarget struct {
+	file  storage.FileInfo
+	local string
+}
+
+// downloadTargets maps the files at or under prefix to local paths. A single file is written to
+// dest (or into dest if it is a directory); a directory is recreated under dest.
+func downloadTargets(files []storage.FileInfo, prefix string, dest string) ([]downloadTarget, error) {
+	if exact := underPath(files, prefix, false); prefix != "" && len(exact) == 1 {
+		local := dest
+		if local == "" {
+			local = exact[0].Name
+		} else if info, err := os.Stat(dest); err == nil && info.IsDir() {
+			local = filepath.Join(dest, exact[0].Name)
+		}
+		return []downloadTarget{{file: exact[0], local: local}}, nil
+	}
+
+	matched := underPath(files, prefix, true)
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("%s: %w", prefix, errNotFound)
+	}
+	if dest == "" {
+		dest = "."
+	}
+
+	targets := make([]downloadTarget, 0, len(matched))
+	for _, f := range matched {
+		rel := strings.TrimPrefix(strings.TrimPrefix(f.FullPath(), "/"), prefix)
+		rel = strings.TrimPrefix(rel, "/")
+		if !filepath.IsLocal(filepath.FromSlash(rel)) {
+			return nil, fmt.Errorf("refusing to write %s outside of %s", f.FullPath(), dest)
+		}
+		targets = append(targets, downloadTarget{file: f, local: filepath.Join(dest, filepath.FromSlash(rel))})
+	}
+	return targets, nil
+}
+
+func downloadTo(ctx context.Context, link string, local string) error {
+	body, err := storage.DownloadFile(ctx, link)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if dir := filepath.Dir(local); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	f, err := os.Create(local)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, body); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// listLocal lists a file, or every regular file under a directory, for upload, with paths relative to the
+// returned root. Empty files in a directory are skipped because they cannot be uploaded through a signed URL.
+// Contents are read later by uploadLocal, one batch at a time.
+func listLocal(src string) (string, []localFile, error) {
+	info, err := os.Stat(src)
+	if err != nil {
+		return "", nil, usageErrorf("cannot read %s: %v", src, err)
+	}
+	if !info.IsDir() {
+		if info.Size() == 0 {
+			return "", nil, usageErrorf("%s is empty; empty files cannot be uploaded", src)
+		}
+		return filepath.Dir(src), []localFile{{Path: filepath.Base(src), Size: info.Size(), ModTime: info.ModTime()}}, nil
+	}
+
+	var files []localFile
+	err = filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil || info.Size() == 0 {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, localFile{Path: filepath.ToSlash(rel), Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("read %s: %w", src, err)
+	}
+	return src, files, nil
+}
+
+// uploadLocal uploads files listed by listLocal under root to dest in a bucket. Files are read and uploaded
+// uploadBatchSize at a time, one session per batch, so memory use does not grow with the tree.
+// Returns an error saying how many files were uploaded before a batch failed.
+func uploadLocal(ctx context.Context, bucketUuid string, root string, files []localFile, dest string) error {
+	for start := 0; start < len(files); start += uploadBatchSize {
+		batch := files[start:min(start+uploadBatchSize, len(files))]
+		upload := make([]storage.WholeFile, len(batch))
+		for i, f := range batch {
+			content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(f.Path)))
+			if err != nil {
+				return fmt.Errorf("uploaded %d of %d files: %w", start, len(files), err)
+			}
+			upload[i] = storage.WholeFile{
+				Content: string(content),
+				Metadata: storage.FileMetadata{
+					FileName:    path.Base(f.Path),
+					ContentType: storage.DetectContentType(f.Path, content),
+					Path:        joinRemoteDir(dest, path.Dir(f.Path)),
+				},
+			}
+		}
+		if _, err := storage.UploadFileProcess(ctx, bucketUuid, upload); err != nil {
+			return fmt.Errorf("uploaded %d of %d files: %w", start, len(files), err)
+		}
+	}
+	return nil
+}
+
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, c := range s {
+		switch {
+		case i == 8 || i == 13 || i == 18 || i == 23:
+			if c != '-' {
+				return false
+			}
+		case !strings.ContainsRune("0123456789abcdefABCDEF", c):
+			return false
+		}
+	}
+	return true
+}
+
+func formatTime(t storage.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
+
+func wantArgs(args []string, minArgs, maxArgs int, usage string) error {
+	if len(args) < minArgs || len(args) > maxArgs {
+		return usageErrorf("usage: apillon %s", usage)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Apillon/go-sdk/storage"
+)
+
+const (
+	syncUpload = "upload"
+	syncDelete = "delete"
+)
+
+// localFile is a file of the local directory being synced, with its slash-separated relative path.
+type localFile struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// syncAction is a change made by storage sync.
+type syncAction struct {
+	Action   string `json:"action"`             // syncUpload or syncDelete
+	Path     string `json:"path"`               // Path in the bucket
+	Reason   string `json:"reason"`             // Why the change is needed
+	FileUUID string `json:"fileUuid,omitempty"` // Remote file to delete
+	Error    string `json:"error,omitempty"`    // Why the delete failed
+
+	local string // Local relative path of an upload
+}
+
+// planSync compares local files with the remote files under prefix. A file is uploaded if it is
+// missing remotely, has a different size, or was modified locally after the remote copy was
+// last updated; remote files without timestamps are compared by size only. With deleteExtra,
+// remote files without a local counterpart are deleted.
+func planSync(local []localFile, remote []storage.FileInfo, prefix string, deleteExtra bool) []syncAction {
+	remoteByPath := map[string]storage.FileInfo{}
+	for _, f := range remote {
+		remoteByPath[strings.TrimPrefix(f.FullPath(), "/")] = f
+	}
+
+	var actions []syncAction
+	seen := map[string]bool{}
+	for _, l := range local {
+		p := path.Join(prefix, l.Path)
+		seen[p] = true
+
+		r, ok := remoteByPath[p]
+		reason := ""
+		switch {
+		case !ok:
+			reason = "new"
+		case int64(r.Size) != l.Size:
+			reason = "size changed"
+		case !lastChange(r).IsZero() && l.ModTime.After(lastChange(r)):
+			reason = "modified"
+		default:
+			continue
+		}
+		actions = append(actions, syncAction{Action: syncUpload, Path: p, Reason: reason, local: l.Path})
+	}
+
+	if deleteExtra {
+		for p, r := range remoteByPath {
+			if !seen[p] {
+				actions = append(actions, syncAction{Action: syncDelete, Path: p, Reason: "not in source", FileUUID: r.FileUUID})
+			}
+		}
+	}
+
+	sort.Slice(actions, func(i, j int) bool {
+		if actions[i].Action != actions[j].Action {
+			return actions[i].Action > actions[j].Action
+		}
+		return actions[i].Path < actions[j].Path
+	})
+	return actions
+}
+
+// lastChange returns when a remote file was last updated, falling back to its creation time.
+func lastChange(f storage.FileInfo) time.Time {
+	if !f.UpdateTime.IsZero() {
+		return f.UpdateTime.Time
+	}
+	return f.CreateTime.Time
+}
@@ -0,0 +1,137 @@
+package computing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Apillon/go-sdk/internal/apitest"
+)
+
+func TestInputValidation(t *testing.T) {
+	ctx := context.Background()
+	validData := ContractData{
+		NftContractAddress: "0x52908400098527886E0F7030069857D2E4169EE7",
+		NftChainRPCURL:     "https://rpc.api.moonbase.moonbeam.network",
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"create without name", func() error {
+			_, err := CreateContract(ctx, CreateContractRequest{ContractData: validData})
+			return err
+		}},
+		{"create with invalid address", func() error {
+			data := validData
+			data.NftContractAddress = "0x1234"
+			_, err := CreateContract(ctx, CreateContractRequest{Name: "Secrets", ContractData: data})
+			return err
+		}},
+		{"create with invalid RPC URL", func() error {
+			data := validData
+			data.NftChainRPCURL = "moonbase"
+			_, err := CreateContract(ctx, CreateContractRequest{Name: "Secrets", ContractData: data})
+			return err
+		}},
+		{"get without UUID", func() error {
+			_, err := GetContract(ctx, "")
+			return err
+		}},
+		{"transfer to EVM address", func() error {
+			_, err := TransferOwnership(ctx, "contract", validData.NftContractAddress)
+			return err
+		}},
+		{"assign without CID", func() error {
+			_, err := AssignCIDToNFT(ctx, "contract", "", 1)
+			return err
+		}},
+		{"assign negative NFT", func() error {
+			_, err := AssignCIDToNFT(ctx, "contract", "bafy", -1)
+			return err
+		}},
+		{"encrypt empty content", func() error {
+			_, err := EncryptContent(ctx, "contract", nil)
+			return err
+		}},
+		{"encrypt file with path", func() error {
+			_, err := EncryptFile(ctx, "contract", "dir/secret.txt", []byte("secret"), 0)
+			return err
+		}},
+		{"list transactions without UUID", func() error {
+			_, err := ListTransactions(ctx, "")
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var computingErr *ComputingError
+			if err := tt.call(); !errors.As(err, &computingErr) || computingErr.Code != ErrCodeInvalidInput {
+				t.Errorf("expected invalid input error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateContractRequestJSON(t *testing.T) {
+	data, err := json.Marshal(CreateContractRequest{
+		Name:         "Secrets",
+		BucketUUID:   "bucket",
+		ContractType: ContractTypeSchrodinger,
+		ContractData: ContractData{NftContractAddress: "0xabc", NftChainRPCURL: "https://rpc"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(data, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["bucket_uuid"] != "bucket" || body["contractType"] != 1.0 {
+		t.Errorf("unexpected request body %s", data)
+	}
+	if body["contractData"].(map[string]any)["nftChainRpcUrl"] != "https://rpc" {
+		t.Errorf("unexpected contract data %s", data)
+	}
+}
+
+func TestContractResponseUnmarshal(t *testing.T) {
+	body := `{"id":"1","status":200,"data":{"contractUuid":"c1","name":"Secrets","contractStatus":3,"bucketUuid":"b1","data":{"nftContractAddress":"0xabc","nftChainRpcUrl":"https://rpc","restrictToOwner":false},"createTime":"2024-01-02T03:04:05.000Z"}}`
+
+	var res ContractResponse
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if res.Data.ContractStatus != ContractDeployed || res.Data.BucketUUID != "b1" || res.Data.Data.NftContractAddress != "0xabc" {
+		t.Errorf("unexpected contract %+v", res.Data)
+	}
+	if res.Data.Created().Year() != 2024 {
+		t.Errorf("unexpected create time %v", res.Data.Created())
+	}
+}
+
+func TestEncryptFileIgnoresStaleFile(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.ProcessAfter = 1
+	server.AddFile("b1", "secret.txt", "old ciphertext")
+	server.Mux.HandleFunc("GET /computing/contracts/c1", func(w http.ResponseWriter, r *http.Request) {
+		apitest.WriteJSON(w, Contract{ContractUUID: "c1", BucketUUID: "b1"})
+	})
+	server.Mux.HandleFunc("POST /computing/contracts/c1/encrypt", func(w http.ResponseWriter, r *http.Request) {
+		apitest.WriteJSON(w, EncryptResult{EncryptedContent: "new ciphertext"})
+	})
+
+	file, err := EncryptFile(context.Background(), "c1", "secret.txt", []byte("secret"), time.Millisecond)
+	if err != nil {
+		t.Fatalf("EncryptFile failed: %v", err)
+	}
+	if want := apitest.CID("new ciphertext"); file.CID != want {
+		t.Errorf("got file with CID %s, want %s", file.CID, want)
+	}
+}
@@ -0,0 +1,240 @@
+// Package computing provides functions to manage Apillon computing contracts (Phala schrodinger contracts):
+// creating contracts, encrypting files for NFT holders, assigning encrypted files to NFTs and
+// tracking contract transactions.
+package computing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Apillon/go-sdk/nft"
+	"github.com/Apillon/go-sdk/requests"
+)
+
+// ComputingError represents an error that occurred during computing operations
+type ComputingError struct {
+	Code    int
+	Message string
+	Err     error
+}
+
+func (e *ComputingError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("computing error (code %d): %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("computing error (code %d): %s", e.Code, e.Message)
+}
+
+func (e *ComputingError) Unwrap() error {
+	return e.Err
+}
+
+// Error codes
+const (
+	ErrCodeInvalidInput = 40000001
+)
+
+// ListContracts lists all computing contracts in the project.
+// Returns a ListContractsResponse struct or an error if the request or unmarshalling fails.
+func ListContracts(ctx context.Context) (ListContractsResponse, error) {
+	res, err := requests.GetReq(ctx, "/computing/contracts", nil)
+	if err != nil {
+		return ListContractsResponse{}, &ComputingError{
+			Code:    500,
+			Message: "failed to list contracts",
+			Err:     err,
+		}
+	}
+
+	var contracts ListContractsResponse
+	if err := json.Unmarshal([]byte(res), &contracts); err != nil {
+		return ListContractsResponse{}, &ComputingError{
+			Code:    500,
+			Message: "failed to unmarshal list contracts response",
+			Err:     err,
+		}
+	}
+
+	return contracts, nil
+}
+
+// GetContract retrieves a contract by its UUID.
+// Returns a ContractResponse struct or an error if the request or unmarshalling fails.
+func GetContract(ctx context.Context, contractUuid string) (ContractResponse, error) {
+	if contractUuid == "" {
+		return ContractResponse{}, invalidInput("contract UUID cannot be empty")
+	}
+
+	res, err := requests.GetReq(ctx, "/computing/contracts/"+contractUuid, nil)
+	if err != nil {
+		return ContractResponse{}, &ComputingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to get contract %s", contractUuid),
+			Err:     err,
+		}
+	}
+
+	var contract ContractResponse
+	if err := json.Unmarshal([]byte(res), &contract); err != nil {
+		return ContractResponse{}, &ComputingError{
+			Code:    500,
+			Message: "failed to unmarshal get contract response",
+			Err:     err,
+		}
+	}
+
+	return contract, nil
+}
+
+// CreateContract creates and deploys a schrodinger contract that lets holders of NFTs in the
+// given EVM collection decrypt files encrypted with the contract.
+// ContractType defaults to ContractTypeSchrodinger.
+// Returns a ContractResponse containing the created contract, or an error if validation or the request fails.
+func CreateContract(ctx context.Context, req CreateContractRequest) (ContractResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return ContractResponse{}, invalidInput("contract name cannot be empty")
+	}
+	if err := nft.ValidateAddress(nft.ChainTypeEVM, req.ContractData.NftContractAddress); err != nil {
+		return ContractResponse{}, &ComputingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "invalid NFT contract address",
+			Err:     err,
+		}
+	}
+	if !strings.HasPrefix(req.ContractData.NftChainRPCURL, "https://") && !strings.HasPrefix(req.ContractData.NftChainRPCURL, "http://") {
+		return ContractResponse{}, invalidInput("NFT chain RPC URL must be an http(s) URL")
+	}
+	if req.ContractType == 0 {
+		req.ContractType = ContractTypeSchrodinger
+	}
+
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return ContractResponse{}, &ComputingError{
+			Code:    500,
+			Message: "failed to marshal create contract request",
+			Err:     err,
+		}
+	}
+
+	res, err := requests.PostReq(ctx, "/computing/contracts", strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return ContractResponse{}, &ComputingError{
+			Code:    500,
+			Message: "failed to create contract",
+			Err:     err,
+		}
+	}
+
+	var contract ContractResponse
+	if err := json.Unmarshal([]byte(res), &contract); err != nil {
+		return ContractResponse{}, &ComputingError{
+			Code:    500,
+			Message: "failed to unmarshal create contract response",
+			Err:     err,
+		}
+	}
+
+	return contract, nil
+}
+
+// TransferOwnership transfers ownership of a contract to a Substrate (Phala) account.
+// After the transfer the contract can no longer be managed through Apillon.
+// Returns an ActionResponse or an error if validation or the request fails.
+func TransferOwnership(ctx context.Context, contractUuid string, accountAddress string) (ActionResponse, error) {
+	if contractUuid == "" {
+		return ActionResponse{}, invalidInput("contract UUID cannot be empty")
+	}
+	if err := nft.ValidateAddress(nft.ChainTypeSubstrate, accountAddress); err != nil {
+		return ActionResponse{}, &ComputingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "invalid account address",
+			Err:     err,
+		}
+	}
+
+	return postContractAction(ctx, contractUuid, "transfer-ownership", transferOwnershipRequest{AccountAddress: accountAddress})
+}
+
+// AssignCIDToNFT assigns an encrypted file, identified by its CID, to an NFT of the contract's collection
+// so that only the NFT owner can decrypt it.
+// Returns an ActionResponse or an error if validation or the request fails.
+func AssignCIDToNFT(ctx context.Context, contractUuid string, cid string, nftId int) (ActionResponse, error) {
+	if contractUuid == "" || cid == "" {
+		return ActionResponse{}, invalidInput("contract UUID and CID cannot be empty")
+	}
+	if nftId < 0 {
+		return ActionResponse{}, invalidInput("NFT ID cannot be negative")
+	}
+
+	return postContractAction(ctx, contractUuid, "assign-cid-to-nft", assignCIDRequest{CID: cid, NftID: nftId})
+}
+
+// ListTransactions lists the on-chain transactions of a contract.
+// Returns a ListTransactionsResponse struct or an error if the request or unmarshalling fails.
+func ListTransactions(ctx context.Context, contractUuid string) (ListTransactionsResponse, error) {
+	if contractUuid == "" {
+		return ListTransactionsResponse{}, invalidInput("contract UUID cannot be empty")
+	}
+
+	res, err := requests.GetReq(ctx, "/computing/contracts/"+contractUuid+"/transactions", nil)
+	if err != nil {
+		return ListTransactionsResponse{}, &ComputingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to list transactions of contract %s", contractUuid),
+			Err:     err,
+		}
+	}
+
+	var transactions ListTransactionsResponse
+	if err := json.Unmarshal([]byte(res), &transactions); err != nil {
+		return ListTransactionsResponse{}, &ComputingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to unmarshal list transactions response for contract %s", contractUuid),
+			Err:     err,
+		}
+	}
+
+	return transactions, nil
+}
+
+// postContractAction sends a POST request to /computing/contracts/{uuid}/{action} and decodes the action response.
+func postContractAction(ctx context.Context, contractUuid string, action string, body any) (ActionResponse, error) {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return ActionResponse{}, &ComputingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to marshal %s request", action),
+			Err:     err,
+		}
+	}
+
+	res, err := requests.PostReq(ctx, "/computing/contracts/"+contractUuid+"/"+action, strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return ActionResponse{}, &ComputingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to %s for contract %s", action, contractUuid),
+			Err:     err,
+		}
+	}
+
+	var result ActionResponse
+	if err := json.Unmarshal([]byte(res), &result); err != nil {
+		return ActionResponse{}, &ComputingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to unmarshal %s response for contract %s", action, contractUuid),
+			Err:     err,
+		}
+	}
+
+	return result, nil
+}
+
+func invalidInput(message string) *ComputingError {
+	return &ComputingError{
+		Code:    ErrCodeInvalidInput,
+		Message: message,
+	}
+}
@@ -0,0 +1,99 @@
+package computing
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Apillon/go-sdk/requests"
+	"github.com/Apillon/go-sdk/storage"
+)
+
+// EncryptContent encrypts content with a contract's key.
+// Only holders of NFTs assigned to the resulting file can decrypt it through the contract.
+// Returns an EncryptResponse with the base64-encoded ciphertext, or an error if the request or unmarshalling fails.
+func EncryptContent(ctx context.Context, contractUuid string, content []byte) (EncryptResponse, error) {
+	if contractUuid == "" {
+		return EncryptResponse{}, invalidInput("contract UUID cannot be empty")
+	}
+	if len(content) == 0 {
+		return EncryptResponse{}, invalidInput("content cannot be empty")
+	}
+
+	bodyBytes, err := json.Marshal(encryptRequest{Content: base64.StdEncoding.EncodeToString(content)})
+	if err != nil {
+		return EncryptResponse{}, &ComputingError{
+			Code:    500,
+			Message: "failed to marshal encrypt request",
+			Err:     err,
+		}
+	}
+
+	res, err := requests.PostReq(ctx, "/computing/contracts/"+contractUuid+"/encrypt", strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return EncryptResponse{}, &ComputingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to encrypt content with contract %s", contractUuid),
+			Err:     err,
+		}
+	}
+
+	var encrypted EncryptResponse
+	if err := json.Unmarshal([]byte(res), &encrypted); err != nil {
+		return EncryptResponse{}, &ComputingError{
+			Code:    500,
+			Message: "failed to unmarshal encrypt response",
+			Err:     err,
+		}
+	}
+
+	return encrypted, nil
+}
+
+// EncryptFile encrypts content with a contract and uploads the ciphertext to the contract's bucket
+// with storage.UploadAndWait, which waits until the uploaded file, not one stored earlier under
+// the same name, has a CID.
+// Pass the CID to AssignCIDToNFT to grant an NFT holder access. pollInterval defaults to 3 seconds.
+// Returns the uploaded file, or an error if encryption, the upload or waiting for the CID fails.
+func EncryptFile(ctx context.Context, contractUuid string, fileName string, content []byte, pollInterval time.Duration) (storage.FileInfo, error) {
+	if strings.TrimSpace(fileName) == "" || strings.Contains(fileName, "/") {
+		return storage.FileInfo{}, invalidInput("file name must be non-empty and cannot contain a path")
+	}
+
+	contract, err := GetContract(ctx, contractUuid)
+	if err != nil {
+		return storage.FileInfo{}, err
+	}
+	if contract.Data.BucketUUID == "" {
+		return storage.FileInfo{}, &ComputingError{
+			Code:    500,
+			Message: fmt.Sprintf("contract %s has no bucket", contractUuid),
+		}
+	}
+
+	encrypted, err := EncryptContent(ctx, contractUuid, content)
+	if err != nil {
+		return storage.FileInfo{}, err
+	}
+
+	files := []storage.WholeFile{{
+		Content: encrypted.Data.EncryptedContent,
+		Metadata: storage.FileMetadata{
+			FileName:    fileName,
+			ContentType: "text/plain",
+		},
+	}}
+	uploaded, err := storage.UploadAndWait(ctx, contract.Data.BucketUUID, files, pollInterval)
+	if err != nil {
+		return storage.FileInfo{}, &ComputingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to upload encrypted file %s to bucket %s", fileName, contract.Data.BucketUUID),
+			Err:     err,
+		}
+	}
+
+	return uploaded[fileName], nil
+}
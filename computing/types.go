@@ -0,0 +1,154 @@
+package computing
+
+import "github.com/Apillon/go-sdk/storage"
+
+// ContractType is the kind of computing contract.
+type ContractType int
+
+const (
+	ContractTypeSchrodinger ContractType = 1 // Contract that decrypts content for the owner of an NFT
+)
+
+// ContractStatus is the deployment status of a computing contract.
+type ContractStatus int
+
+const (
+	ContractCreated         ContractStatus = 0
+	ContractDeployInitiated ContractStatus = 1
+	ContractDeploying       ContractStatus = 2
+	ContractDeployed        ContractStatus = 3
+	ContractTransferred     ContractStatus = 4
+	ContractFailed          ContractStatus = 5
+)
+
+func (s ContractStatus) String() string {
+	switch s {
+	case ContractCreated:
+		return "created"
+	case ContractDeployInitiated:
+		return "deploy initiated"
+	case ContractDeploying:
+		return "deploying"
+	case ContractDeployed:
+		return "deployed"
+	case ContractTransferred:
+		return "transferred"
+	case ContractFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// TransactionType is the kind of operation a computing transaction performs.
+type TransactionType int
+
+const (
+	TransactionDeployContract    TransactionType = 1
+	TransactionTransferOwnership TransactionType = 2
+	TransactionDepositToCluster  TransactionType = 3
+	TransactionAssignCIDToNFT    TransactionType = 4
+)
+
+// TransactionStatus is the on-chain status of a computing transaction.
+type TransactionStatus int
+
+const (
+	TransactionPending   TransactionStatus = 1
+	TransactionConfirmed TransactionStatus = 2
+	TransactionFailed    TransactionStatus = 3
+	TransactionError     TransactionStatus = 4
+)
+
+func (s TransactionStatus) String() string {
+	switch s {
+	case TransactionPending:
+		return "pending"
+	case TransactionConfirmed:
+		return "confirmed"
+	case TransactionFailed:
+		return "failed"
+	case TransactionError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// ContractData holds the NFT collection a schrodinger contract checks ownership against.
+type ContractData struct {
+	NftContractAddress string `json:"nftContractAddress"` // Address of the NFT collection contract
+	NftChainRPCURL     string `json:"nftChainRpcUrl"`     // RPC URL of the chain the collection lives on
+	RestrictToOwner    bool   `json:"restrictToOwner"`    // Whether only the contract owner can use it
+}
+
+// Contract contains information about a computing contract.
+type Contract struct {
+	storage.Timestamps
+	ContractUUID    string         `json:"contractUuid"`    // Unique identifier for the contract
+	Name            string         `json:"name"`            // Name of the contract
+	Description     string         `json:"description"`     // Description of the contract
+	ContractType    ContractType   `json:"contractType"`    // Kind of contract
+	ContractStatus  ContractStatus `json:"contractStatus"`  // Deployment status
+	ContractAddress string         `json:"contractAddress"` // Address of the deployed contract
+	DeployerAddress string         `json:"deployerAddress"` // Address that deployed the contract
+	TransactionHash string         `json:"transactionHash"` // Hash of the deploy transaction
+	BucketUUID      string         `json:"bucketUuid"`      // UUID of the bucket holding encrypted files
+	Data            ContractData   `json:"data"`            // Contract settings
+}
+
+// Transaction contains information about an on-chain computing transaction.
+type Transaction struct {
+	storage.Timestamps
+	TransactionUUID   string            `json:"transactionUuid"`   // Unique identifier for the transaction
+	TransactionType   TransactionType   `json:"transactionType"`   // Kind of operation
+	TransactionStatus TransactionStatus `json:"transactionStatus"` // On-chain status
+	TransactionHash   string            `json:"transactionHash"`   // Hash of the transaction
+}
+
+// EncryptResult contains content encrypted by a contract.
+type EncryptResult struct {
+	EncryptedContent string `json:"encryptedContent"` // Encrypted content, base64 encoded
+}
+
+// ActionResult is the result of transfer and assign operations.
+type ActionResult struct {
+	Success bool `json:"success"` // Whether the transaction was submitted
+}
+
+// ContractResponse represents a response containing a single contract.
+type ContractResponse = storage.APIResponse[Contract]
+
+// ListContractsResponse represents a response containing a list of contracts.
+type ListContractsResponse = storage.APIResponse[storage.ListData[Contract]]
+
+// ListTransactionsResponse represents a response containing a list of contract transactions.
+type ListTransactionsResponse = storage.APIResponse[storage.ListData[Transaction]]
+
+// EncryptResponse represents a response containing encrypted content.
+type EncryptResponse = storage.APIResponse[EncryptResult]
+
+// ActionResponse represents a response for transfer and assign operations.
+type ActionResponse = storage.APIResponse[ActionResult]
+
+// CreateContractRequest represents the request body for creating a schrodinger contract.
+type CreateContractRequest struct {
+	Name         string       `json:"name"`
+	Description  string       `json:"description,omitempty"`
+	BucketUUID   string       `json:"bucket_uuid,omitempty"` // Existing bucket for encrypted files; a new one is created if empty
+	ContractType ContractType `json:"contractType"`
+	ContractData ContractData `json:"contractData"`
+}
+
+type encryptRequest struct {
+	Content string `json:"content"`
+}
+
+type transferOwnershipRequest struct {
+	AccountAddress string `json:"accountAddress"`
+}
+
+type assignCIDRequest struct {
+	CID   string `json:"cid"`
+	NftID int    `json:"nftId"`
+}
@@ -0,0 +1,247 @@
+// Package credentials resolves the Apillon API credentials used by the SDK and the apillon CLI.
+//
+// Credentials are looked up through a provider chain, first match wins:
+//
+//  1. an explicit value, e.g. set with requests.SetAPIKey or the CLI --api-key flag;
+//  2. the APILLON_API_KEY environment variable (with APILLON_API_SECRET, APILLON_BASE_URL and
+//     APILLON_DEFAULT_BUCKET);
+//  3. a profile in the config file (~/.config/apillon/config.toml, see ConfigPath);
+//  4. a custom provider function.
+//
+// The resolved Credentials report which source they came from, for debugging.
+package credentials
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// CredentialsError represents an error that occurred while resolving credentials
+type CredentialsError struct {
+	Code    int
+	Message string
+	Err     error
+}
+
+func (e *CredentialsError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("credentials error (code %d): %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("credentials error (code %d): %s", e.Code, e.Message)
+}
+
+func (e *CredentialsError) Unwrap() error {
+	return e.Err
+}
+
+// Error codes
+const (
+	ErrCodeInvalidInput = 40000001
+)
+
+// ErrNoCredentials is returned by Chain.Resolve when no source provides an API key.
+var ErrNoCredentials = errors.New("no Apillon API key configured")
+
+// Environment variables read by the chain.
+const (
+	EnvAPIKey        = "APILLON_API_KEY"
+	EnvAPISecret     = "APILLON_API_SECRET"
+	EnvBaseURL       = "APILLON_BASE_URL"
+	EnvDefaultBucket = "APILLON_DEFAULT_BUCKET"
+	EnvProfile       = "APILLON_PROFILE"
+	EnvConfig        = "APILLON_CONFIG"
+)
+
+// Source identifies where credentials were resolved from.
+type Source int
+
+const (
+	SourceNone        Source = iota // No source provided credentials
+	SourceExplicit                  // Set explicitly in code or on the command line
+	SourceEnvironment               // Read from environment variables
+	SourceProfile                   // Read from a config file profile
+	SourceCustom                    // Returned by a custom provider function
+)
+
+func (s Source) String() string {
+	switch s {
+	case SourceNone:
+		return "none"
+	case SourceExplicit:
+		return "explicit"
+	case SourceEnvironment:
+		return "environment"
+	case SourceProfile:
+		return "profile"
+	case SourceCustom:
+		return "custom"
+	default:
+		return "unknown"
+	}
+}
+
+// Credentials are the settings used to authenticate against the Apillon API.
+type Credentials struct {
+	APIKey        string // API key
+	APISecret     string // API secret, for keys issued as a key and secret pair
+	BaseURL       string // API base URL; empty for the default
+	DefaultBucket string // Bucket used by tools when none is given
+
+	Source Source // Where the credentials were resolved from
+	Origin string // Details of the source, e.g. the environment variable or the config file and profile
+}
+
+// Token returns the Basic auth token for the credentials. With an API secret it is the base64
+// encoding of "key:secret"; an API key given as "key:secret" is encoded the same way. Any other
+// API key is assumed to be a pre-encoded token and returned as is.
+func (c Credentials) Token() string {
+	switch {
+	case c.APIKey == "":
+		return ""
+	case c.APISecret != "":
+		return base64.StdEncoding.EncodeToString([]byte(c.APIKey + ":" + c.APISecret))
+	case strings.Contains(c.APIKey, ":"):
+		return base64.StdEncoding.EncodeToString([]byte(c.APIKey))
+	default:
+		return c.APIKey
+	}
+}
+
+// Authorization returns the value of the Authorization header, or "" without an API key.
+func (c Credentials) Authorization() string {
+	if c.APIKey == "" {
+		return ""
+	}
+	return "Basic " + c.Token()
+}
+
+// Redact replaces every occurrence of the API key, the API secret and the encoded token in text
+// with [REDACTED], so messages can be logged or returned in errors.
+func (c Credentials) Redact(text string) string {
+	for _, secret := range []string{c.Token(), c.APISecret, c.APIKey} {
+		if secret != "" {
+			text = strings.ReplaceAll(text, secret, Redacted)
+		}
+	}
+	return text
+}
+
+// String describes the credentials with the API key and secret redacted.
+func (c Credentials) String() string {
+	return fmt.Sprintf("{APIKey:%s APISecret:%s BaseURL:%s DefaultBucket:%s Source:%s Origin:%s}",
+		redact(c.APIKey), redact(c.APISecret), c.BaseURL, c.DefaultBucket, c.Source, c.Origin)
+}
+
+// GoString redacts the credentials when printed with %#v.
+func (c Credentials) GoString() string {
+	return "credentials.Credentials" + c.String()
+}
+
+// Redacted replaces secrets in redacted output.
+const Redacted = "[REDACTED]"
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return Redacted
+}
+
+// Provider returns credentials from a custom source, such as a secret manager.
+// It should return credentials with an empty APIKey if it has none.
+type Provider func() (Credentials, error)
+
+// Chain resolves credentials from its sources in order. The zero value reads the environment
+// and the default profile of the default config file.
+type Chain struct {
+	// Explicit credentials win if they contain an API key. Their Source and Origin are kept if
+	// set, e.g. for a profile selected on the command line.
+	Explicit Credentials
+	// Profile is the config file profile to read. Defaults to APILLON_PROFILE, then "default".
+	// A profile selected by name or APILLON_PROFILE must exist; the default profile may be absent.
+	Profile string
+	// ConfigPath is the config file to read. Defaults to ConfigPath().
+	ConfigPath string
+	// Custom, if set, is consulted last.
+	Custom Provider
+}
+
+// Resolve returns the credentials of the first source that provides an API key.
+// Returns ErrNoCredentials if no source does, or a CredentialsError if the config file or
+// custom provider fails.
+func (c Chain) Resolve() (Credentials, error) {
+	if c.Explicit.APIKey != "" {
+		creds := c.Explicit
+		if creds.Source == SourceNone {
+			creds.Source = SourceExplicit
+		}
+		if creds.Origin == "" {
+			creds.Origin = "explicit"
+		}
+		return creds, nil
+	}
+
+	if key := os.Getenv(EnvAPIKey); key != "" {
+		return Credentials{
+			APIKey:        key,
+			APISecret:     os.Getenv(EnvAPISecret),
+			BaseURL:       os.Getenv(EnvBaseURL),
+			DefaultBucket: os.Getenv(EnvDefaultBucket),
+			Source:        SourceEnvironment,
+			Origin:        EnvAPIKey,
+		}, nil
+	}
+
+	creds, err := c.profile()
+	if err != nil || creds.APIKey != "" {
+		return creds, err
+	}
+
+	if c.Custom != nil {
+		creds, err := c.Custom()
+		if err != nil {
+			return Credentials{}, &CredentialsError{
+				Code:    500,
+				Message: "custom credential provider failed",
+				Err:     err,
+			}
+		}
+		if creds.APIKey != "" {
+			creds.Source = SourceCustom
+			if creds.Origin == "" {
+				creds.Origin = "custom provider"
+			}
+			return creds, nil
+		}
+	}
+
+	return Credentials{}, ErrNoCredentials
+}
+
+// profile reads the selected profile, returning empty credentials if the default profile is absent.
+func (c Chain) profile() (Credentials, error) {
+	name := c.Profile
+	if name == "" {
+		name = os.Getenv(EnvProfile)
+	}
+	required := name != ""
+	if !required {
+		name = DefaultProfile
+	}
+	path := c.ConfigPath
+	if path == "" {
+		path = ConfigPath()
+	}
+
+	profile, err := LoadProfile(path, name)
+	if err != nil {
+		if !required && errors.Is(err, ErrProfileNotFound) {
+			return Credentials{}, nil
+		}
+		return Credentials{}, err
+	}
+	return profile.Credentials(path), nil
+}
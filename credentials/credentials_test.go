@@ -0,0 +1,138 @@
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, config string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestChainOrder(t *testing.T) {
+	path := writeConfig(t, "[default]\napi_key = \"profile-key\"\ndefault_bucket = \"site\"\n")
+	t.Setenv(EnvAPIKey, "env-key")
+	t.Setenv(EnvProfile, "")
+	custom := func() (Credentials, error) { return Credentials{APIKey: "custom-key"}, nil }
+
+	chain := Chain{Explicit: Credentials{APIKey: "explicit-key"}, ConfigPath: path, Custom: custom}
+	tests := []struct {
+		setup  func()
+		key    string
+		source Source
+		origin string
+	}{
+		{func() {}, "explicit-key", SourceExplicit, "explicit"},
+		{func() { chain.Explicit = Credentials{} }, "env-key", SourceEnvironment, EnvAPIKey},
+		{func() { t.Setenv(EnvAPIKey, "") }, "profile-key", SourceProfile, path + " [default]"},
+		{func() { chain.ConfigPath = filepath.Join(t.TempDir(), "missing.toml") }, "custom-key", SourceCustom, "custom provider"},
+	}
+	for _, tt := range tests {
+		tt.setup()
+		creds, err := chain.Resolve()
+		if err != nil || creds.APIKey != tt.key || creds.Source != tt.source || creds.Origin != tt.origin {
+			t.Errorf("Resolve() = %+v, %v, want key %q from %s (%s)", creds, err, tt.key, tt.source, tt.origin)
+		}
+	}
+
+	chain.Custom = nil
+	if _, err := chain.Resolve(); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("expected ErrNoCredentials, got %v", err)
+	}
+}
+
+func TestChainProfile(t *testing.T) {
+	path := writeConfig(t, "[profiles.ci]\napi_key = \"ci-key\"\nbase_url = \"https://api.example.com\"\n")
+	t.Setenv(EnvAPIKey, "")
+	t.Setenv(EnvProfile, "ci")
+
+	creds, err := Chain{ConfigPath: path}.Resolve()
+	if err != nil || creds.APIKey != "ci-key" || creds.BaseURL != "https://api.example.com" {
+		t.Errorf("Resolve() = %+v, %v", creds, err)
+	}
+
+	_, err = Chain{Profile: "prod", ConfigPath: path}.Resolve()
+	var credsErr *CredentialsError
+	if !errors.As(err, &credsErr) || credsErr.Code != ErrCodeInvalidInput || !errors.Is(err, ErrProfileNotFound) {
+		t.Errorf("expected missing profile error, got %v", err)
+	}
+}
+
+func TestParseProfiles(t *testing.T) {
+	config := `# Apillon
+[default]
+api_key = "key # not a comment" # trailing comment
+api_secret = "se\"cret"
+default_bucket = site
+
+[profiles.staging]
+api_key = 'staging-key'
+base_url = "https://api.example.com"
+`
+	profiles, err := ParseProfiles(strings.NewReader(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]Profile{
+		"default": {Name: "default", APIKey: "key # not a comment", APISecret: `se"cret`, DefaultBucket: "site"},
+		"staging": {Name: "staging", APIKey: "staging-key", BaseURL: "https://api.example.com"},
+	}
+	for name, p := range want {
+		if profiles[name] != p {
+			t.Errorf("profile %s = %+v, want %+v", name, profiles[name], p)
+		}
+	}
+
+	for _, invalid := range []string{"api_key = \"x\"", "[default]\napi_key = \"x", "[default\n", "[default]\napi_key"} {
+		if _, err := ParseProfiles(strings.NewReader(invalid)); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestAuthorization(t *testing.T) {
+	tests := []struct {
+		creds Credentials
+		want  string
+	}{
+		{Credentials{}, ""},
+		{Credentials{APIKey: "key", APISecret: "secret"}, "Basic a2V5OnNlY3JldA=="},
+		{Credentials{APIKey: "key:secret"}, "Basic a2V5OnNlY3JldA=="},
+		{Credentials{APIKey: "a2V5OnNlY3JldA=="}, "Basic a2V5OnNlY3JldA=="},
+	}
+	for _, tt := range tests {
+		if got := tt.creds.Authorization(); got != tt.want {
+			t.Errorf("Authorization() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestRedaction(t *testing.T) {
+	creds := Credentials{APIKey: "key-123", APISecret: "secret-456", Source: SourceExplicit}
+	for _, s := range []string{fmt.Sprint(creds), fmt.Sprintf("%+v", creds), fmt.Sprintf("%#v", creds), fmt.Sprint(Profile{APIKey: "key-123", APISecret: "secret-456"})} {
+		if strings.Contains(s, "key-123") || strings.Contains(s, "secret-456") || !strings.Contains(s, Redacted) {
+			t.Errorf("credentials not redacted: %s", s)
+		}
+	}
+
+	for _, config := range []string{"[default]\napi_secret = \"secret-456", "[default]\napi_key = 'key-123", "[default]\napi_secret = \"secret-456\\q\""} {
+		_, err := ParseProfiles(strings.NewReader(config))
+		if err == nil || strings.Contains(err.Error(), "123") || strings.Contains(err.Error(), "456") || !strings.Contains(err.Error(), "line 2: api_") {
+			t.Errorf("parse error does not redact the value: %v", err)
+		}
+	}
+
+	text := "bad header Basic " + creds.Token() + " for key-123 / secret-456"
+	if got := creds.Redact(text); got != "bad header Basic [REDACTED] for [REDACTED] / [REDACTED]" {
+		t.Errorf("Redact = %q", got)
+	}
+}
@@ -0,0 +1,215 @@
+package credentials
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultProfile is the profile read when none is selected.
+const DefaultProfile = "default"
+
+// ErrProfileNotFound is wrapped by LoadProfile errors when the config file or the profile does not exist.
+var ErrProfileNotFound = errors.New("profile not found")
+
+// Profile is a named set of settings in the config file.
+// Like Credentials, it is printed with the API key and secret redacted.
+type Profile struct {
+	Name          string
+	APIKey        string // api_key
+	APISecret     string // api_secret
+	BaseURL       string // base_url
+	DefaultBucket string // default_bucket
+}
+
+// Credentials returns the profile as credentials read from the config file at path.
+func (p Profile) Credentials(path string) Credentials {
+	return Credentials{
+		APIKey:        p.APIKey,
+		APISecret:     p.APISecret,
+		BaseURL:       p.BaseURL,
+		DefaultBucket: p.DefaultBucket,
+		Source:        SourceProfile,
+		Origin:        fmt.Sprintf("%s [%s]", path, p.Name),
+	}
+}
+
+// String describes the profile with the API key and secret redacted.
+func (p Profile) String() string {
+	return fmt.Sprintf("{Name:%s APIKey:%s APISecret:%s BaseURL:%s DefaultBucket:%s}",
+		p.Name, redact(p.APIKey), redact(p.APISecret), p.BaseURL, p.DefaultBucket)
+}
+
+// GoString redacts the profile when printed with %#v.
+func (p Profile) GoString() string {
+	return "credentials.Profile" + p.String()
+}
+
+// ConfigPath returns the config file path: $APILLON_CONFIG, or apillon/config.toml in
+// $XDG_CONFIG_HOME, falling back to ~/.config.
+func ConfigPath() string {
+	if p := os.Getenv(EnvConfig); p != "" {
+		return p
+	}
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "apillon", "config.toml")
+}
+
+// LoadProfile reads a single profile from the config file at path.
+// Returns an error wrapping ErrProfileNotFound if the file or profile does not exist.
+func LoadProfile(path string, name string) (Profile, error) {
+	profiles, err := LoadProfiles(path)
+	if err != nil {
+		return Profile{}, err
+	}
+	profile, ok := profiles[name]
+	if !ok {
+		return Profile{}, &CredentialsError{
+			Code:    ErrCodeInvalidInput,
+			Message: fmt.Sprintf("profile %q not found in %s", name, path),
+			Err:     ErrProfileNotFound,
+		}
+	}
+	return profile, nil
+}
+
+// LoadProfiles reads every profile of the config file at path.
+// Returns an error wrapping ErrProfileNotFound if the file does not exist.
+func LoadProfiles(path string) (map[string]Profile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			err = fmt.Errorf("%w: %w", ErrProfileNotFound, err)
+		}
+		return nil, &CredentialsError{
+			Code:    ErrCodeInvalidInput,
+			Message: "cannot read config file " + path,
+			Err:     err,
+		}
+	}
+	defer f.Close()
+
+	profiles, err := ParseProfiles(f)
+	if err != nil {
+		return nil, &CredentialsError{
+			Code:    ErrCodeInvalidInput,
+			Message: "invalid config file " + path,
+			Err:     err,
+		}
+	}
+	return profiles, nil
+}
+
+// ParseProfiles parses the subset of TOML used by the config file: [name] or [profiles.name]
+// tables of key = "string" pairs, with # comments.
+//
+//	[default]
+//	api_key = "..."
+//	api_secret = "..."
+//	default_bucket = "..."
+//
+//	[profiles.staging]
+//	api_key = "..."
+//	base_url = "https://api.example.com"
+func ParseProfiles(r io.Reader) (map[string]Profile, error) {
+	profiles := map[string]Profile{}
+	current := ""
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") {
+			end := strings.Index(text, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated table header", line)
+			}
+			current = strings.TrimSpace(text[1:end])
+			current = strings.Trim(strings.TrimPrefix(current, "profiles."), `"`)
+			if _, ok := profiles[current]; !ok {
+				profiles[current] = Profile{Name: current}
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", line)
+		}
+		if current == "" {
+			return nil, fmt.Errorf("line %d: key outside of a profile table", line)
+		}
+		key = strings.TrimSpace(key)
+		value, err := parseValue(strings.TrimSpace(value))
+		if err != nil {
+			// The value is left out as it may be the API key or secret
+			return nil, fmt.Errorf("line %d: %s: %w", line, key, err)
+		}
+
+		profile := profiles[current]
+		switch key {
+		case "api_key":
+			profile.APIKey = value
+		case "api_secret":
+			profile.APISecret = value
+		case "base_url":
+			profile.BaseURL = value
+		case "default_bucket":
+			profile.DefaultBucket = value
+		}
+		profiles[current] = profile
+	}
+	return profiles, scanner.Err()
+}
+
+// parseValue parses a basic ("...") or literal ('...') TOML string, or a bare value, dropping trailing comments.
+// Errors never include the value.
+func parseValue(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		end := 0
+		for i := 1; i < len(value) && end == 0; i++ {
+			switch value[i] {
+			case '\\':
+				i++
+			case '"':
+				end = i
+			}
+		}
+		if end == 0 {
+			return "", errors.New("unterminated string")
+		}
+		unquoted, err := strconv.Unquote(value[:end+1])
+		if err != nil {
+			return "", errors.New("invalid string")
+		}
+		return unquoted, nil
+	case strings.HasPrefix(value, "'"):
+		end := strings.Index(value[1:], "'")
+		if end < 0 {
+			return "", errors.New("unterminated string")
+		}
+		return value[1 : end+1], nil
+	default:
+		if i := strings.Index(value, "#"); i >= 0 {
+			value = value[:i]
+		}
+		return strings.TrimSpace(value), nil
+	}
+}
@@ -0,0 +1,186 @@
+package hosting
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Apillon/go-sdk/storage"
+)
+
+// defaultPollInterval is the delay between deployment status checks when DeployOptions.PollInterval is not set.
+const defaultPollInterval = 5 * time.Second
+
+// DeployStage identifies the step of DeployDirectory a progress event belongs to.
+type DeployStage int
+
+const (
+	StageUploading DeployStage = iota // Files are being uploaded
+	StageDeploying                    // The deployment is being started
+	StageWaiting                      // The deployment status was polled
+	StageDone                         // The deployment finished successfully
+)
+
+func (s DeployStage) String() string {
+	switch s {
+	case StageUploading:
+		return "uploading"
+	case StageDeploying:
+		return "deploying"
+	case StageWaiting:
+		return "waiting"
+	case StageDone:
+		return "done"
+	default:
+		return "unknown"
+	}
+}
+
+// DeployEvent reports progress of DeployDirectory.
+type DeployEvent struct {
+	Stage      DeployStage // Current step
+	Files      int         // Number of files being uploaded
+	Skipped    []string    // Paths of empty files left out of the upload (uploading stage only)
+	Deployment *Deployment // Latest deployment state, nil while uploading
+}
+
+// DeployOptions configures DeployDirectory and WaitForDeployment.
+type DeployOptions struct {
+	// PollInterval is the delay between deployment status checks. Defaults to 5 seconds.
+	PollInterval time.Duration
+	// OnProgress, if set, is called for every progress event.
+	OnProgress func(DeployEvent)
+}
+
+func (o DeployOptions) progress(event DeployEvent) {
+	if o.OnProgress != nil {
+		o.OnProgress(event)
+	}
+}
+
+// DeployResult is the outcome of a successful DeployDirectory call.
+type DeployResult struct {
+	Deployment Deployment // Final state of the deployment
+	Website    Website    // Website after the deployment
+	URLs       []string   // URLs the deployed environment is reachable at
+	Skipped    []string   // Paths of empty files in the directory, which cannot be uploaded and were left out
+}
+
+// DeployDirectory uploads every file in dir to a website, deploys it to the given environment
+// and waits until the deployment succeeds or fails.
+// Files are walked with storage.WalkDirectory and streamed through the storage upload session flow,
+// so the directory does not need to fit in memory. Empty files cannot be uploaded; they are reported
+// in DeployResult.Skipped and in the uploading event.
+// Returns the final deployment and the environment URLs, or an error if any step fails or ctx is cancelled.
+func DeployDirectory(ctx context.Context, websiteUuid string, dir string, env Environment, opts DeployOptions) (DeployResult, error) {
+	if websiteUuid == "" {
+		return DeployResult{}, &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "website UUID cannot be empty",
+		}
+	}
+
+	target := DeployToStaging
+	switch env {
+	case EnvironmentStaging:
+	case EnvironmentProduction:
+		target = DeployDirectlyToProduction
+	default:
+		return DeployResult{}, &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: fmt.Sprintf("invalid environment %d", env),
+		}
+	}
+
+	files, skipped, err := storage.WalkDirectory(dir)
+	if err != nil {
+		return DeployResult{}, &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "failed to read website directory",
+			Err:     err,
+		}
+	}
+
+	opts.progress(DeployEvent{Stage: StageUploading, Files: len(files), Skipped: skipped})
+	if _, err := UploadWebsiteSources(ctx, websiteUuid, files); err != nil {
+		return DeployResult{}, err
+	}
+
+	started, err := DeployWebsite(ctx, websiteUuid, target)
+	if err != nil {
+		return DeployResult{}, err
+	}
+	opts.progress(DeployEvent{Stage: StageDeploying, Files: len(files), Deployment: &started.Data})
+
+	deployment, err := WaitForDeployment(ctx, websiteUuid, started.Data.DeploymentUUID, opts)
+	if err != nil {
+		return DeployResult{}, err
+	}
+
+	website, err := GetWebsite(ctx, websiteUuid)
+	if err != nil {
+		return DeployResult{}, err
+	}
+
+	return DeployResult{
+		Deployment: deployment,
+		Website:    website.Data,
+		URLs:       website.Data.URLs(env),
+		Skipped:    skipped,
+	}, nil
+}
+
+// WaitForDeployment polls a deployment until it succeeds or fails.
+// Returns the final deployment, a HostingError if the deployment failed, or the context error if ctx is done first.
+func WaitForDeployment(ctx context.Context, websiteUuid string, deploymentUuid string, opts DeployOptions) (Deployment, error) {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	for {
+		resp, err := GetDeployment(ctx, websiteUuid, deploymentUuid)
+		if err != nil {
+			return Deployment{}, err
+		}
+		deployment := resp.Data
+
+		switch deployment.DeploymentStatus {
+		case DeploymentSuccessful:
+			opts.progress(DeployEvent{Stage: StageDone, Deployment: &deployment})
+			return deployment, nil
+		case DeploymentFailed:
+			return deployment, &HostingError{
+				Code:    500,
+				Message: fmt.Sprintf("deployment %s failed", deploymentUuid),
+			}
+		}
+		opts.progress(DeployEvent{Stage: StageWaiting, Deployment: &deployment})
+
+		select {
+		case <-ctx.Done():
+			return deployment, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// URLs returns the URLs an environment of the website is reachable at:
+// the Apillon gateway link and, for production, the custom domain if one is set.
+func (w Website) URLs(env Environment) []string {
+	var urls []string
+	switch env {
+	case EnvironmentStaging:
+		if w.W3StagingLink != "" {
+			urls = append(urls, w.W3StagingLink)
+		}
+	case EnvironmentProduction:
+		if w.W3ProductionLink != "" {
+			urls = append(urls, w.W3ProductionLink)
+		}
+		if w.Domain != "" {
+			urls = append(urls, "https://"+w.Domain)
+		}
+	}
+	return urls
+}
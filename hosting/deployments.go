@@ -0,0 +1,165 @@
+package hosting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Apillon/go-sdk/requests"
+	"github.com/Apillon/go-sdk/storage"
+)
+
+// UploadWebsiteFiles uploads files to a website through the same upload session flow as storage.UploadFileProcess.
+// Set FileMetadata.Path to place files in subdirectories. Uploaded files are published by DeployWebsite.
+// Returns the final API response or an error.
+func UploadWebsiteFiles(ctx context.Context, websiteUuid string, files []storage.WholeFile) (string, error) {
+	if websiteUuid == "" {
+		return "", &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "website UUID cannot be empty",
+		}
+	}
+
+	res, err := storage.UploadSessionProcess(ctx, "/hosting/websites/"+websiteUuid+"/upload", files)
+	if err != nil {
+		return "", &HostingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to upload files to website %s", websiteUuid),
+			Err:     err,
+		}
+	}
+
+	return res, nil
+}
+
+// UploadWebsiteSources uploads files to a website like UploadWebsiteFiles, streaming each file from its source.
+// Returns the final API response or an error.
+func UploadWebsiteSources(ctx context.Context, websiteUuid string, files []storage.FileSource) (string, error) {
+	if websiteUuid == "" {
+		return "", &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "website UUID cannot be empty",
+		}
+	}
+
+	res, err := storage.UploadSessionSources(ctx, "/hosting/websites/"+websiteUuid+"/upload", files)
+	if err != nil {
+		return "", &HostingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to upload files to website %s", websiteUuid),
+			Err:     err,
+		}
+	}
+
+	return res, nil
+}
+
+// DeployWebsite starts a deployment of a website to the given target.
+// Returns a DeploymentResponse describing the new deployment, or an error if the request or unmarshalling fails.
+func DeployWebsite(ctx context.Context, websiteUuid string, target DeployTarget) (DeploymentResponse, error) {
+	if websiteUuid == "" {
+		return DeploymentResponse{}, &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "website UUID cannot be empty",
+		}
+	}
+	if target < DeployToStaging || target > DeployDirectlyToProduction {
+		return DeploymentResponse{}, &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: fmt.Sprintf("invalid deploy target %d", target),
+		}
+	}
+
+	bodyBytes, err := json.Marshal(deployRequest{Environment: target})
+	if err != nil {
+		return DeploymentResponse{}, &HostingError{
+			Code:    500,
+			Message: "failed to marshal deploy website request",
+			Err:     err,
+		}
+	}
+
+	res, err := requests.PostReq(ctx, "/hosting/websites/"+websiteUuid+"/deploy", strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return DeploymentResponse{}, &HostingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to deploy website %s", websiteUuid),
+			Err:     err,
+		}
+	}
+
+	var deployment DeploymentResponse
+	if err := json.Unmarshal([]byte(res), &deployment); err != nil {
+		return DeploymentResponse{}, &HostingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to unmarshal deploy response for website %s", websiteUuid),
+			Err:     err,
+		}
+	}
+
+	return deployment, nil
+}
+
+// ListDeployments lists the deployments of a website.
+// Returns a ListDeploymentsResponse struct or an error if the request or unmarshalling fails.
+func ListDeployments(ctx context.Context, websiteUuid string) (ListDeploymentsResponse, error) {
+	if websiteUuid == "" {
+		return ListDeploymentsResponse{}, &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "website UUID cannot be empty",
+		}
+	}
+
+	res, err := requests.GetReq(ctx, "/hosting/websites/"+websiteUuid+"/deployments", nil)
+	if err != nil {
+		return ListDeploymentsResponse{}, &HostingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to list deployments for website %s", websiteUuid),
+			Err:     err,
+		}
+	}
+
+	var deployments ListDeploymentsResponse
+	if err := json.Unmarshal([]byte(res), &deployments); err != nil {
+		return ListDeploymentsResponse{}, &HostingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to unmarshal list deployments response for website %s", websiteUuid),
+			Err:     err,
+		}
+	}
+
+	return deployments, nil
+}
+
+// GetDeployment retrieves a single deployment of a website.
+// Returns a DeploymentResponse struct or an error if the request or unmarshalling fails.
+func GetDeployment(ctx context.Context, websiteUuid string, deploymentUuid string) (DeploymentResponse, error) {
+	if websiteUuid == "" || deploymentUuid == "" {
+		return DeploymentResponse{}, &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "website UUID and deployment UUID cannot be empty",
+		}
+	}
+
+	path := "/hosting/websites/" + websiteUuid + "/deployments/" + deploymentUuid
+	res, err := requests.GetReq(ctx, path, nil)
+	if err != nil {
+		return DeploymentResponse{}, &HostingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to get deployment %s for website %s", deploymentUuid, websiteUuid),
+			Err:     err,
+		}
+	}
+
+	var deployment DeploymentResponse
+	if err := json.Unmarshal([]byte(res), &deployment); err != nil {
+		return DeploymentResponse{}, &HostingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to unmarshal get deployment response for deployment %s", deploymentUuid),
+			Err:     err,
+		}
+	}
+
+	return deployment, nil
+}
@@ -0,0 +1,265 @@
+package hosting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Apillon/go-sdk/requests"
+	"github.com/Apillon/go-sdk/storage"
+)
+
+// DomainStatus is the verification status of a custom domain.
+type DomainStatus int
+
+const (
+	DomainPending  DomainStatus = 1 // DNS records have not been verified yet
+	DomainVerified DomainStatus = 2 // DNS records point to Apillon and the domain is live
+	DomainInvalid  DomainStatus = 3 // DNS records were checked and are not set correctly
+)
+
+func (s DomainStatus) String() string {
+	switch s {
+	case DomainPending:
+		return "pending"
+	case DomainVerified:
+		return "verified"
+	case DomainInvalid:
+		return "invalid"
+	default:
+		return "unknown"
+	}
+}
+
+// DNSRecord is a DNS record the domain owner must set for a custom domain.
+type DNSRecord struct {
+	Type  string `json:"type"`  // Record type: "A", "CNAME" or "TXT"
+	Name  string `json:"name"`  // Fully qualified record name, e.g. "www.example.com"
+	Value string `json:"value"` // Expected record value
+}
+
+// Domain describes the custom domain of a website.
+type Domain struct {
+	Domain           string       `json:"domain"`           // Custom domain name
+	DomainStatus     DomainStatus `json:"domainStatus"`     // Verification status
+	DomainChangeDate storage.Time `json:"domainChangeDate"` // Time the domain was last changed
+	Records          []DNSRecord  `json:"records"`          // DNS records that must be set
+}
+
+// DomainResponse represents a response containing the custom domain of a website.
+type DomainResponse = storage.APIResponse[Domain]
+
+type attachDomainRequest struct {
+	Domain string `json:"domain"`
+}
+
+// domainPattern matches fully qualified domain names such as "www.example.com".
+var domainPattern = regexp.MustCompile(`^(?i)([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+// AttachDomain sets a custom domain on a website. The domain goes live once its DNS records,
+// available through GetDomain, are set and verified.
+// Returns a DomainResponse struct or an error if the domain is invalid or the request fails.
+func AttachDomain(ctx context.Context, websiteUuid string, domain string) (DomainResponse, error) {
+	if websiteUuid == "" {
+		return DomainResponse{}, &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "website UUID cannot be empty",
+		}
+	}
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	if len(domain) > 253 || !domainPattern.MatchString(domain) {
+		return DomainResponse{}, &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: fmt.Sprintf("invalid domain name %q", domain),
+		}
+	}
+
+	bodyBytes, err := json.Marshal(attachDomainRequest{Domain: domain})
+	if err != nil {
+		return DomainResponse{}, &HostingError{
+			Code:    500,
+			Message: "failed to marshal attach domain request",
+			Err:     err,
+		}
+	}
+
+	res, err := requests.PostReq(ctx, domainPath(websiteUuid), strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return DomainResponse{}, &HostingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to attach domain %s to website %s", domain, websiteUuid),
+			Err:     err,
+		}
+	}
+
+	return unmarshalDomain(res, "attach domain")
+}
+
+// GetDomain retrieves the custom domain of a website, including its status and the DNS records to set.
+// Returns a DomainResponse struct or an error if the request or unmarshalling fails.
+func GetDomain(ctx context.Context, websiteUuid string) (DomainResponse, error) {
+	if websiteUuid == "" {
+		return DomainResponse{}, &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "website UUID cannot be empty",
+		}
+	}
+
+	res, err := requests.GetReq(ctx, domainPath(websiteUuid), nil)
+	if err != nil {
+		return DomainResponse{}, &HostingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to get domain of website %s", websiteUuid),
+			Err:     err,
+		}
+	}
+
+	return unmarshalDomain(res, "get domain")
+}
+
+// VerifyDomain asks the API to check the DNS records of a website's custom domain.
+// Returns a DomainResponse with the updated status or an error if the request or unmarshalling fails.
+func VerifyDomain(ctx context.Context, websiteUuid string) (DomainResponse, error) {
+	if websiteUuid == "" {
+		return DomainResponse{}, &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "website UUID cannot be empty",
+		}
+	}
+
+	res, err := requests.PostReq(ctx, domainPath(websiteUuid)+"/verify", nil)
+	if err != nil {
+		return DomainResponse{}, &HostingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to verify domain of website %s", websiteUuid),
+			Err:     err,
+		}
+	}
+
+	return unmarshalDomain(res, "verify domain")
+}
+
+// DetachDomain removes the custom domain from a website.
+// Returns the raw response as a string, or an error if the request fails.
+func DetachDomain(ctx context.Context, websiteUuid string) (string, error) {
+	if websiteUuid == "" {
+		return "", &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "website UUID cannot be empty",
+		}
+	}
+
+	res, err := requests.DeleteReq(ctx, domainPath(websiteUuid))
+	if err != nil {
+		return "", &HostingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to detach domain from website %s", websiteUuid),
+			Err:     err,
+		}
+	}
+
+	return res, nil
+}
+
+func domainPath(websiteUuid string) string {
+	return "/hosting/websites/" + websiteUuid + "/domain"
+}
+
+// unmarshalDomain decodes a domain response; operation names the call for error messages.
+func unmarshalDomain(res string, operation string) (DomainResponse, error) {
+	var domain DomainResponse
+	if err := json.Unmarshal([]byte(res), &domain); err != nil {
+		return DomainResponse{}, &HostingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to unmarshal %s response", operation),
+			Err:     err,
+		}
+	}
+	return domain, nil
+}
+
+// Resolver looks up DNS records. *net.Resolver implements it, so a resolver dialing a
+// custom (or fake) DNS server can be plugged in.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+var _ Resolver = (*net.Resolver)(nil)
+
+// DNSCheck is the result of checking a single DNS record.
+type DNSCheck struct {
+	Record DNSRecord // Expected record
+	Found  []string  // Values returned by the resolver
+	OK     bool      // Whether the expected value was found
+	Err    error     // Lookup error, if any
+}
+
+// CheckDNSRecords looks up each expected record with resolver and reports whether it is set.
+// Names and CNAME targets are compared case-insensitively and without trailing dots.
+// Returns one check per record and true if all records are set.
+func CheckDNSRecords(ctx context.Context, resolver Resolver, records []DNSRecord) ([]DNSCheck, bool) {
+	checks := make([]DNSCheck, len(records))
+	allOK := true
+	for i, record := range records {
+		check := DNSCheck{Record: record}
+
+		switch strings.ToUpper(record.Type) {
+		case "A", "AAAA":
+			check.Found, check.Err = resolver.LookupHost(ctx, record.Name)
+			check.OK = slices.Contains(check.Found, record.Value)
+		case "CNAME":
+			var cname string
+			cname, check.Err = resolver.LookupCNAME(ctx, record.Name)
+			if cname != "" {
+				check.Found = []string{cname}
+			}
+			check.OK = check.Err == nil && normalizeDNSName(cname) == normalizeDNSName(record.Value)
+		case "TXT":
+			check.Found, check.Err = resolver.LookupTXT(ctx, record.Name)
+			check.OK = slices.Contains(check.Found, record.Value)
+		default:
+			check.Err = fmt.Errorf("unsupported DNS record type %q", record.Type)
+		}
+
+		if !check.OK {
+			allOK = false
+		}
+		checks[i] = check
+	}
+	return checks, allOK
+}
+
+// CheckDomain fetches the DNS records a website's custom domain needs and checks them locally with resolver.
+// Returns one check per record and true if all records are set, or an error if the domain cannot be retrieved.
+func CheckDomain(ctx context.Context, websiteUuid string, resolver Resolver) ([]DNSCheck, bool, error) {
+	if resolver == nil {
+		return nil, false, &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "resolver cannot be nil",
+		}
+	}
+
+	domain, err := GetDomain(ctx, websiteUuid)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(domain.Data.Records) == 0 {
+		return nil, false, &HostingError{
+			Code:    404,
+			Message: fmt.Sprintf("website %s has no DNS records to check", websiteUuid),
+		}
+	}
+
+	checks, ok := CheckDNSRecords(ctx, resolver, domain.Data.Records)
+	return checks, ok, nil
+}
+
+func normalizeDNSName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
@@ -0,0 +1,65 @@
+package hosting
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeResolver struct {
+	hosts  map[string][]string
+	cnames map[string]string
+	txts   map[string][]string
+}
+
+func (r fakeResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+func (r fakeResolver) LookupCNAME(_ context.Context, host string) (string, error) {
+	if cname, ok := r.cnames[host]; ok {
+		return cname, nil
+	}
+	return "", errors.New("no such host")
+}
+
+func (r fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	return r.txts[name], nil
+}
+
+func TestCheckDNSRecords(t *testing.T) {
+	resolver := fakeResolver{
+		hosts:  map[string][]string{"example.com": {"52.19.92.40"}},
+		cnames: map[string]string{"www.example.com": "Sites.Apillon.io."},
+		txts:   map[string][]string{"_apillon.example.com": {"other", "apillon-verify=abc"}},
+	}
+
+	records := []DNSRecord{
+		{Type: "A", Name: "example.com", Value: "52.19.92.40"},
+		{Type: "CNAME", Name: "www.example.com", Value: "sites.apillon.io"},
+		{Type: "TXT", Name: "_apillon.example.com", Value: "apillon-verify=abc"},
+	}
+	checks, ok := CheckDNSRecords(context.Background(), resolver, records)
+	if !ok {
+		t.Fatalf("expected all records to be set: %+v", checks)
+	}
+
+	records = append(records, DNSRecord{Type: "CNAME", Name: "app.example.com", Value: "sites.apillon.io"})
+	checks, ok = CheckDNSRecords(context.Background(), resolver, records)
+	if ok || checks[3].OK || checks[3].Err == nil {
+		t.Errorf("expected the missing CNAME to fail: %+v", checks[3])
+	}
+}
+
+func TestAttachDomainValidation(t *testing.T) {
+	for _, domain := range []string{"", "localhost", "exa mple.com", "-bad.example.com"} {
+		_, err := AttachDomain(context.Background(), "uuid", domain)
+		var hostingErr *HostingError
+		if !errors.As(err, &hostingErr) || hostingErr.Code != ErrCodeInvalidInput {
+			t.Errorf("AttachDomain(%q): expected ErrCodeInvalidInput, got %v", domain, err)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package hosting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Apillon/go-sdk/internal/apitest"
+	"github.com/Apillon/go-sdk/requests"
+	"github.com/Apillon/go-sdk/storage"
+)
+
+func TestInputValidation(t *testing.T) {
+	server := apitest.NewServer(t)
+	ctx := context.Background()
+	empty := ""
+
+	checks := map[string]error{}
+	_, checks["CreateWebsite"] = CreateWebsite(ctx, "", "")
+	_, checks["GetWebsite"] = GetWebsite(ctx, "")
+	_, checks["UpdateWebsite"] = UpdateWebsite(ctx, "uuid", UpdateWebsiteRequest{Name: &empty})
+	_, checks["UploadWebsiteFiles"] = UploadWebsiteFiles(ctx, "", nil)
+	_, checks["DeployWebsite"] = DeployWebsite(ctx, "uuid", DeployTarget(7))
+	_, checks["ListDeployments"] = ListDeployments(ctx, "")
+	_, checks["GetDeployment"] = GetDeployment(ctx, "uuid", "")
+
+	for name, err := range checks {
+		var hostingErr *HostingError
+		if !errors.As(err, &hostingErr) || hostingErr.Code != ErrCodeInvalidInput {
+			t.Errorf("%s: expected ErrCodeInvalidInput, got %v", name, err)
+		}
+	}
+	if got := server.Received(); len(got) != 0 {
+		t.Errorf("expected no requests for invalid input, got %+v", got)
+	}
+}
+
+func TestWebsiteRequests(t *testing.T) {
+	ctx := context.Background()
+	server := apitest.NewServer(t)
+	server.Reply("GET /hosting/websites", map[string]any{"items": []Website{{WebsiteUUID: "w-1"}, {WebsiteUUID: "w-2"}}, "total": 2})
+	server.Reply("POST /hosting/websites", Website{WebsiteUUID: "w-3", Name: "Docs"})
+	server.Reply("PATCH /hosting/websites/{uuid}", Website{WebsiteUUID: "w-1", Name: "Renamed"})
+
+	list, err := ListWebsites(ctx)
+	if err != nil || list.Data.Total != 2 {
+		t.Fatalf("ListWebsites = %+v, %v", list.Data, err)
+	}
+	created, err := CreateWebsite(ctx, "Docs", "")
+	if err != nil || created.Data.WebsiteUUID != "w-3" {
+		t.Fatalf("CreateWebsite = %+v, %v", created.Data, err)
+	}
+	name := "Renamed"
+	updated, err := UpdateWebsite(ctx, "w-1", UpdateWebsiteRequest{Name: &name})
+	if err != nil || updated.Data.Name != "Renamed" {
+		t.Fatalf("UpdateWebsite = %+v, %v", updated.Data, err)
+	}
+
+	want := []apitest.Request{
+		{Method: "GET", Path: "/hosting/websites"},
+		{Method: "POST", Path: "/hosting/websites", Body: `{"name":"Docs"}`},
+		{Method: "PATCH", Path: "/hosting/websites/w-1", Body: `{"name":"Renamed"}`},
+	}
+	if got := server.Received(); !slices.Equal(got, want) {
+		t.Errorf("requests %+v, want %+v", got, want)
+	}
+}
+
+func TestGetMissingWebsite(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.ReplyError("GET /hosting/websites/{uuid}", http.StatusNotFound, 40400000, "WEBSITE_NOT_FOUND")
+
+	_, err := GetWebsite(context.Background(), "missing")
+	var hostingErr *HostingError
+	var apiErr *requests.APIError
+	if !errors.As(err, &hostingErr) || hostingErr.Code != 500 || !errors.As(err, &apiErr) || apiErr.Status != http.StatusNotFound {
+		t.Fatalf("expected a wrapped not found error, got %v", err)
+	}
+}
+
+func TestUploadWebsiteFiles(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.HandleUploads("/hosting/websites")
+
+	files := []storage.WholeFile{
+		{Metadata: storage.FileMetadata{FileName: "index.html", ContentType: "text/html"}, Content: "<h1>Docs</h1>"},
+		{Metadata: storage.FileMetadata{FileName: "app.css", ContentType: "text/css", Path: "assets"}, Content: "h1 {}"},
+	}
+	if _, err := UploadWebsiteFiles(context.Background(), "w-1", files); err != nil {
+		t.Fatalf("UploadWebsiteFiles failed: %v", err)
+	}
+
+	var got []string
+	for _, f := range server.Files("w-1") {
+		got = append(got, f.Path()+"="+f.Content)
+	}
+	if want := []string{"assets/app.css=h1 {}", "index.html=<h1>Docs</h1>"}; !slices.Equal(got, want) {
+		t.Errorf("uploaded %q, want %q", got, want)
+	}
+}
+
+func TestDeploymentRequests(t *testing.T) {
+	ctx := context.Background()
+	server := apitest.NewServer(t)
+	server.Reply("POST /hosting/websites/{uuid}/deploy", Deployment{DeploymentUUID: "d-1", DeploymentStatus: DeploymentInitiated})
+	server.Reply("GET /hosting/websites/{uuid}/deployments", map[string]any{"items": []Deployment{{DeploymentUUID: "d-1", Number: 1}}, "total": 1})
+	server.Reply("GET /hosting/websites/{uuid}/deployments/{deployment}", Deployment{DeploymentUUID: "d-1", DeploymentStatus: DeploymentInProgress})
+
+	started, err := DeployWebsite(ctx, "w-1", DeployStagingToProduction)
+	if err != nil || started.Data.DeploymentUUID != "d-1" {
+		t.Fatalf("DeployWebsite = %+v, %v", started.Data, err)
+	}
+	list, err := ListDeployments(ctx, "w-1")
+	if err != nil || list.Data.Items[0].Number != 1 {
+		t.Fatalf("ListDeployments = %+v, %v", list.Data, err)
+	}
+	deployment, err := GetDeployment(ctx, "w-1", "d-1")
+	if err != nil || deployment.Data.DeploymentStatus.Done() {
+		t.Fatalf("GetDeployment = %+v, %v; expected a deployment in progress", deployment.Data, err)
+	}
+
+	want := []apitest.Request{
+		{Method: "POST", Path: "/hosting/websites/w-1/deploy", Body: `{"environment":2}`},
+		{Method: "GET", Path: "/hosting/websites/w-1/deployments"},
+		{Method: "GET", Path: "/hosting/websites/w-1/deployments/d-1"},
+	}
+	if got := server.Received(); !slices.Equal(got, want) {
+		t.Errorf("requests %+v, want %+v", got, want)
+	}
+}
+
+func TestDeploymentResponseUnmarshal(t *testing.T) {
+	data := `{"id":"1","status":200,"data":{"deploymentUuid":"d-1","environment":2,"deploymentStatus":10,` +
+		`"cid":"Qm123","size":4096,"number":3,"createTime":"2024-05-01T08:00:00.000Z","updateTime":null}}`
+
+	var resp DeploymentResponse
+	if err := json.Unmarshal([]byte(data), &resp); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	d := resp.Data
+	if d.Environment != EnvironmentProduction || d.DeploymentStatus != DeploymentSuccessful || !d.DeploymentStatus.Done() {
+		t.Errorf("unexpected deployment %+v", d)
+	}
+	if d.Size.String() != "4.0 KiB" || d.Created().IsZero() {
+		t.Errorf("unexpected size or timestamps: %s, %v", d.Size, d.Created())
+	}
+}
+
+func TestWebsiteURLs(t *testing.T) {
+	w := Website{
+		W3StagingLink:    "https://staging.example/ipns/k51",
+		W3ProductionLink: "https://prod.example/ipns/k52",
+		Domain:           "example.com",
+	}
+	if got := w.URLs(EnvironmentStaging); len(got) != 1 || got[0] != w.W3StagingLink {
+		t.Errorf("staging URLs = %v", got)
+	}
+	if got := w.URLs(EnvironmentProduction); len(got) != 2 || got[1] != "https://example.com" {
+		t.Errorf("production URLs = %v", got)
+	}
+}
+
+func TestDeployDirectory(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.HandleUploads("/hosting/websites")
+	server.Reply("POST /hosting/websites/{uuid}/deploy", Deployment{DeploymentUUID: "d-1", DeploymentStatus: DeploymentInitiated})
+	server.Reply("GET /hosting/websites/{uuid}", Website{WebsiteUUID: "w-1", W3StagingLink: "https://staging.example/ipns/k51"})
+	polls := 0
+	server.Mux.HandleFunc("GET /hosting/websites/{uuid}/deployments/{deployment}", func(w http.ResponseWriter, r *http.Request) {
+		status := DeploymentInProgress
+		if polls++; polls >= 2 {
+			status = DeploymentSuccessful
+		}
+		apitest.WriteJSON(w, Deployment{DeploymentUUID: r.PathValue("deployment"), DeploymentStatus: status})
+	})
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte("<h1>Docs</h1>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".nojekyll"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stages []string
+	opts := DeployOptions{
+		PollInterval: time.Millisecond,
+		OnProgress:   func(e DeployEvent) { stages = append(stages, e.Stage.String()) },
+	}
+	result, err := DeployDirectory(context.Background(), "w-1", dir, EnvironmentStaging, opts)
+	if err != nil {
+		t.Fatalf("DeployDirectory failed: %v", err)
+	}
+	if result.Deployment.DeploymentStatus != DeploymentSuccessful || len(result.URLs) != 1 || result.URLs[0] != "https://staging.example/ipns/k51" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0] != ".nojekyll" {
+		t.Errorf("skipped %v, want [.nojekyll]", result.Skipped)
+	}
+	if got := strings.Join(stages, ","); got != "uploading,deploying,waiting,done" {
+		t.Errorf("progress stages %s", got)
+	}
+	if files := server.Files("w-1"); len(files) != 1 || files[0].Content != "<h1>Docs</h1>" {
+		t.Errorf("uploaded %+v", files)
+	}
+
+	got := server.Received()
+	if deploy := got[len(got)-4]; deploy.Path != "/hosting/websites/w-1/deploy" || deploy.Body != `{"environment":1}` {
+		t.Errorf("expected a staging deployment, got %+v", deploy)
+	}
+	if polled := server.Count("GET /hosting/websites/w-1/deployments/d-1"); polled != 2 {
+		t.Errorf("polled the deployment %d times, want 2", polled)
+	}
+}
+
+func TestDeployDirectoryValidation(t *testing.T) {
+	ctx := context.Background()
+	if _, err := DeployDirectory(ctx, "uuid", t.TempDir(), Environment(9), DeployOptions{}); err == nil {
+		t.Error("expected an error for an invalid environment")
+	}
+	if _, err := DeployDirectory(ctx, "uuid", "/does/not/exist", EnvironmentStaging, DeployOptions{}); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
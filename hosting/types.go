@@ -0,0 +1,121 @@
+package hosting
+
+import "github.com/Apillon/go-sdk/storage"
+
+// Environment identifies a website environment that a deployment lives in.
+type Environment int
+
+const (
+	EnvironmentStaging    Environment = 1 // Staging environment
+	EnvironmentProduction Environment = 2 // Production environment
+)
+
+func (e Environment) String() string {
+	switch e {
+	case EnvironmentStaging:
+		return "staging"
+	case EnvironmentProduction:
+		return "production"
+	default:
+		return "unknown"
+	}
+}
+
+// DeployTarget selects where DeployWebsite deploys to.
+type DeployTarget int
+
+const (
+	DeployToStaging            DeployTarget = 1 // Deploy uploaded files to staging
+	DeployStagingToProduction  DeployTarget = 2 // Promote the current staging deployment to production
+	DeployDirectlyToProduction DeployTarget = 3 // Deploy uploaded files straight to production
+)
+
+// DeploymentStatus is the processing status of a deployment.
+type DeploymentStatus int
+
+const (
+	DeploymentInitiated  DeploymentStatus = 0   // Deployment was created
+	DeploymentInProgress DeploymentStatus = 1   // Deployment is being processed
+	DeploymentSuccessful DeploymentStatus = 10  // Deployment finished successfully
+	DeploymentFailed     DeploymentStatus = 100 // Deployment failed
+)
+
+func (s DeploymentStatus) String() string {
+	switch s {
+	case DeploymentInitiated:
+		return "initiated"
+	case DeploymentInProgress:
+		return "in progress"
+	case DeploymentSuccessful:
+		return "successful"
+	case DeploymentFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// Done reports whether the deployment reached a final status.
+func (s DeploymentStatus) Done() bool {
+	return s == DeploymentSuccessful || s == DeploymentFailed
+}
+
+// Website contains information about a hosted website.
+type Website struct {
+	storage.Timestamps
+	WebsiteUUID          string       `json:"websiteUuid"`          // Unique identifier for the website
+	Name                 string       `json:"name"`                 // Name of the website
+	Description          string       `json:"description"`          // Description of the website
+	Domain               string       `json:"domain"`               // Custom domain of the website (empty if none)
+	DomainStatus         DomainStatus `json:"domainStatus"`         // Verification status of the custom domain
+	Status               int          `json:"status"`               // Status code of the website
+	BucketUUID           string       `json:"bucketUuid"`           // UUID of the bucket holding uploaded files
+	StagingBucketUUID    string       `json:"stagingBucketUuid"`    // UUID of the bucket holding the staging deployment
+	ProductionBucketUUID string       `json:"productionBucketUuid"` // UUID of the bucket holding the production deployment
+	IPNSStaging          string       `json:"ipnsStaging"`          // IPNS name of the staging environment
+	IPNSProduction       string       `json:"ipnsProduction"`       // IPNS name of the production environment
+	W3StagingLink        string       `json:"w3StagingLink"`        // Gateway link to the staging environment
+	W3ProductionLink     string       `json:"w3ProductionLink"`     // Gateway link to the production environment
+}
+
+// Deployment contains information about a website deployment.
+type Deployment struct {
+	storage.Timestamps
+	DeploymentUUID   string           `json:"deploymentUuid"`   // Unique identifier for the deployment
+	BucketUUID       string           `json:"bucketUuid"`       // UUID of the bucket that was deployed
+	Environment      Environment      `json:"environment"`      // Environment the deployment targets
+	DeploymentStatus DeploymentStatus `json:"deploymentStatus"` // Processing status of the deployment
+	CID              string           `json:"cid"`              // CID (v0) of the deployed content
+	CIDv1            string           `json:"cidv1"`            // CID (v1) of the deployed content
+	Size             storage.ByteSize `json:"size"`             // Size of the deployed content
+	Number           int              `json:"number"`           // Sequential number of the deployment
+}
+
+// WebsiteResponse represents a response containing a single website.
+type WebsiteResponse = storage.APIResponse[Website]
+
+// ListWebsitesResponse represents a response containing a list of websites.
+type ListWebsitesResponse = storage.APIResponse[storage.ListData[Website]]
+
+// DeploymentResponse represents a response containing a single deployment.
+type DeploymentResponse = storage.APIResponse[Deployment]
+
+// ListDeploymentsResponse represents a response containing a list of deployments.
+type ListDeploymentsResponse = storage.APIResponse[storage.ListData[Deployment]]
+
+// CreateWebsiteRequest represents the request body for creating a website.
+type CreateWebsiteRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// UpdateWebsiteRequest represents the request body for updating a website.
+// Nil fields are left unchanged.
+type UpdateWebsiteRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+type deployRequest struct {
+	Environment DeployTarget `json:"environment"`
+}
@@ -0,0 +1,162 @@
+// Package hosting provides functions to manage websites hosted on Apillon:
+// creating and updating websites, uploading their files and deploying them to staging or production.
+package hosting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Apillon/go-sdk/requests"
+)
+
+// HostingError represents an error that occurred during hosting operations
+type HostingError struct {
+	Code    int
+	Message string
+	Err     error
+}
+
+func (e *HostingError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("hosting error (code %d): %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("hosting error (code %d): %s", e.Code, e.Message)
+}
+
+func (e *HostingError) Unwrap() error {
+	return e.Err
+}
+
+// Error codes
+const (
+	ErrCodeInvalidInput = 40000001
+)
+
+// ListWebsites lists all websites in the project.
+// Returns a ListWebsitesResponse struct or an error if the request or unmarshalling fails.
+func ListWebsites(ctx context.Context) (ListWebsitesResponse, error) {
+	res, err := requests.GetReq(ctx, "/hosting/websites", nil)
+	if err != nil {
+		return ListWebsitesResponse{}, &HostingError{
+			Code:    500,
+			Message: "failed to list websites",
+			Err:     err,
+		}
+	}
+
+	var websites ListWebsitesResponse
+	if err := json.Unmarshal([]byte(res), &websites); err != nil {
+		return ListWebsitesResponse{}, &HostingError{
+			Code:    500,
+			Message: "failed to unmarshal list websites response",
+			Err:     err,
+		}
+	}
+
+	return websites, nil
+}
+
+// CreateWebsite creates a new website with the specified name and optional description.
+// Returns a WebsiteResponse containing the created website, or an error if the request or unmarshalling fails.
+func CreateWebsite(ctx context.Context, name string, description string) (WebsiteResponse, error) {
+	if name == "" {
+		return WebsiteResponse{}, &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "website name cannot be empty",
+		}
+	}
+
+	bodyBytes, err := json.Marshal(CreateWebsiteRequest{Name: name, Description: description})
+	if err != nil {
+		return WebsiteResponse{}, &HostingError{
+			Code:    500,
+			Message: "failed to marshal create website request",
+			Err:     err,
+		}
+	}
+
+	res, err := requests.PostReq(ctx, "/hosting/websites", strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return WebsiteResponse{}, &HostingError{
+			Code:    500,
+			Message: "failed to create website",
+			Err:     err,
+		}
+	}
+
+	return unmarshalWebsite(res, "create website")
+}
+
+// GetWebsite retrieves a website by its UUID.
+// Returns a WebsiteResponse struct or an error if the request or unmarshalling fails.
+func GetWebsite(ctx context.Context, websiteUuid string) (WebsiteResponse, error) {
+	if websiteUuid == "" {
+		return WebsiteResponse{}, &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "website UUID cannot be empty",
+		}
+	}
+
+	res, err := requests.GetReq(ctx, "/hosting/websites/"+websiteUuid, nil)
+	if err != nil {
+		return WebsiteResponse{}, &HostingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to get website %s", websiteUuid),
+			Err:     err,
+		}
+	}
+
+	return unmarshalWebsite(res, "get website")
+}
+
+// UpdateWebsite updates the name and/or description of a website. Nil fields in update are left unchanged.
+// Returns a WebsiteResponse containing the updated website, or an error if the request or unmarshalling fails.
+func UpdateWebsite(ctx context.Context, websiteUuid string, update UpdateWebsiteRequest) (WebsiteResponse, error) {
+	if websiteUuid == "" {
+		return WebsiteResponse{}, &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "website UUID cannot be empty",
+		}
+	}
+	if update.Name != nil && *update.Name == "" {
+		return WebsiteResponse{}, &HostingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "website name cannot be empty",
+		}
+	}
+
+	bodyBytes, err := json.Marshal(update)
+	if err != nil {
+		return WebsiteResponse{}, &HostingError{
+			Code:    500,
+			Message: "failed to marshal update website request",
+			Err:     err,
+		}
+	}
+
+	res, err := requests.PatchReq(ctx, "/hosting/websites/"+websiteUuid, strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return WebsiteResponse{}, &HostingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to update website %s", websiteUuid),
+			Err:     err,
+		}
+	}
+
+	return unmarshalWebsite(res, "update website")
+}
+
+// unmarshalWebsite decodes a single website response; operation names the call for error messages.
+func unmarshalWebsite(res string, operation string) (WebsiteResponse, error) {
+	var website WebsiteResponse
+	if err := json.Unmarshal([]byte(res), &website); err != nil {
+		return WebsiteResponse{}, &HostingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to unmarshal %s response", operation),
+			Err:     err,
+		}
+	}
+	return website, nil
+}
@@ -0,0 +1,173 @@
+package indexing
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/Apillon/go-sdk/requests"
+	"github.com/Apillon/go-sdk/storage"
+)
+
+// manifestFile is the SQD manifest every indexer project must contain.
+const manifestFile = "squid.yaml"
+
+// skippedDirs are project directories that are never packaged; they are rebuilt during deployment.
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	".git":         true,
+}
+
+// sourceArchiveName is the file name the packaged project is uploaded under.
+const sourceArchiveName = "source.tar.gz"
+
+// PackageDirectory writes an indexer project directory to w as a gzipped tarball ready for deployment.
+// node_modules and .git directories are skipped. The directory must contain a squid.yaml manifest.
+// Files are streamed into the archive one at a time, so the project is never held in memory.
+// Returns an error if the directory cannot be read or writing fails.
+func PackageDirectory(dir string, w io.Writer) error {
+	if _, err := os.Stat(filepath.Join(dir, manifestFile)); err != nil {
+		return &IndexingError{
+			Code:    ErrCodeInvalidInput,
+			Message: fmt.Sprintf("%s not found in indexer directory %s", manifestFile, dir),
+			Err:     err,
+		}
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && skippedDirs[d.Name()] {
+			return filepath.SkipDir
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err == nil {
+		err = tw.Close()
+	}
+	if err == nil {
+		err = gz.Close()
+	}
+	if err != nil {
+		return &IndexingError{
+			Code:    500,
+			Message: "failed to package indexer directory " + dir,
+			Err:     err,
+		}
+	}
+
+	return nil
+}
+
+// DeployDirectory packages an indexer project directory with PackageDirectory, uploads the archive through
+// an upload session of the indexer with storage.UploadSessionSources and starts a deployment.
+// The archive is staged in a temporary file, as signed URLs need the upload length up front.
+// Follow progress with GetIndexer, ListDeployments and GetLogs.
+// Returns a DeploymentResponse describing the new deployment, or an error if any step fails.
+func DeployDirectory(ctx context.Context, indexerUuid string, dir string) (DeploymentResponse, error) {
+	if indexerUuid == "" {
+		return DeploymentResponse{}, invalidInput("indexer UUID cannot be empty")
+	}
+
+	archive, err := os.CreateTemp("", "apillon-indexer-*.tar.gz")
+	if err != nil {
+		return DeploymentResponse{}, &IndexingError{
+			Code:    500,
+			Message: "failed to create temporary archive",
+			Err:     err,
+		}
+	}
+	defer os.Remove(archive.Name())
+	err = PackageDirectory(dir, archive)
+	if closeErr := archive.Close(); err == nil && closeErr != nil {
+		err = &IndexingError{
+			Code:    500,
+			Message: "failed to write temporary archive",
+			Err:     closeErr,
+		}
+	}
+	if err != nil {
+		return DeploymentResponse{}, err
+	}
+	info, err := os.Stat(archive.Name())
+	if err != nil {
+		return DeploymentResponse{}, &IndexingError{
+			Code:    500,
+			Message: "failed to read temporary archive",
+			Err:     err,
+		}
+	}
+
+	source := storage.FileSource{
+		Metadata: storage.FileMetadata{FileName: sourceArchiveName, ContentType: "application/gzip"},
+		Size:     info.Size(),
+		Open: func() (io.ReadCloser, error) {
+			return os.Open(archive.Name())
+		},
+	}
+	if _, err := storage.UploadSessionSources(ctx, "/indexing/indexers/"+indexerUuid+"/upload", []storage.FileSource{source}); err != nil {
+		return DeploymentResponse{}, &IndexingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to upload source code of indexer %s", indexerUuid),
+			Err:     err,
+		}
+	}
+
+	res, err := requests.PostReq(ctx, "/indexing/indexers/"+indexerUuid+"/deploy", nil)
+	if err != nil {
+		return DeploymentResponse{}, &IndexingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to deploy indexer %s", indexerUuid),
+			Err:     err,
+		}
+	}
+
+	var deployment DeploymentResponse
+	if err := json.Unmarshal([]byte(res), &deployment); err != nil {
+		return DeploymentResponse{}, &IndexingError{
+			Code:    500,
+			Message: "failed to unmarshal deploy indexer response",
+			Err:     err,
+		}
+	}
+
+	return deployment, nil
+}
@@ -0,0 +1,116 @@
+package indexing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// graphQLError is an entry of the "errors" field of a GraphQL response.
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+type graphQLResponse[T any] struct {
+	Data   T              `json:"data"`
+	Errors []graphQLError `json:"errors"`
+}
+
+// Query runs a GraphQL query against a deployed indexer's endpoint (Indexer.GraphQLURL)
+// and decodes the "data" field of the response into T.
+// Returns an IndexingError wrapping the request failure, or listing the GraphQL errors if the endpoint reported any.
+func Query[T any](ctx context.Context, endpoint string, query string, variables map[string]any) (T, error) {
+	var zero T
+	if endpoint == "" {
+		return zero, invalidInput("GraphQL endpoint cannot be empty")
+	}
+	if strings.TrimSpace(query) == "" {
+		return zero, invalidInput("GraphQL query cannot be empty")
+	}
+
+	body, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return zero, &IndexingError{
+			Code:    500,
+			Message: "failed to marshal GraphQL request",
+			Err:     err,
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return zero, &IndexingError{
+			Code:    ErrCodeInvalidInput,
+			Message: "invalid GraphQL endpoint " + endpoint,
+			Err:     err,
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return zero, &IndexingError{
+			Code:    500,
+			Message: "failed to send GraphQL query",
+			Err:     err,
+		}
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return zero, &IndexingError{
+			Code:    500,
+			Message: "failed to read GraphQL response",
+			Err:     err,
+		}
+	}
+
+	var result graphQLResponse[T]
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		if resp.StatusCode >= 400 {
+			return zero, &IndexingError{
+				Code:    resp.StatusCode,
+				Message: fmt.Sprintf("GraphQL endpoint returned status %d", resp.StatusCode),
+			}
+		}
+		return zero, &IndexingError{
+			Code:    500,
+			Message: "failed to unmarshal GraphQL response",
+			Err:     err,
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		messages := make([]string, len(result.Errors))
+		for i, e := range result.Errors {
+			messages[i] = e.Message
+		}
+		code := resp.StatusCode
+		if code < 400 {
+			code = 400
+		}
+		return result.Data, &IndexingError{
+			Code:    code,
+			Message: "GraphQL query failed: " + strings.Join(messages, "; "),
+		}
+	}
+	if resp.StatusCode >= 400 {
+		return zero, &IndexingError{
+			Code:    resp.StatusCode,
+			Message: fmt.Sprintf("GraphQL endpoint returned status %d", resp.StatusCode),
+		}
+	}
+
+	return result.Data, nil
+}
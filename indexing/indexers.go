@@ -0,0 +1,171 @@
+// Package indexing provides functions to manage Apillon (SQD-based) blockchain indexers:
+// creating indexers, deploying them from a local project directory, reading deployment
+// status and logs, and querying a deployed indexer's GraphQL endpoint.
+package indexing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Apillon/go-sdk/requests"
+)
+
+// IndexingError represents an error that occurred during indexing operations
+type IndexingError struct {
+	Code    int
+	Message string
+	Err     error
+}
+
+func (e *IndexingError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("indexing error (code %d): %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("indexing error (code %d): %s", e.Code, e.Message)
+}
+
+func (e *IndexingError) Unwrap() error {
+	return e.Err
+}
+
+// Error codes
+const (
+	ErrCodeInvalidInput = 40000001
+)
+
+// ListIndexers lists all indexers in the project.
+// Returns a ListIndexersResponse struct or an error if the request or unmarshalling fails.
+func ListIndexers(ctx context.Context) (ListIndexersResponse, error) {
+	var indexers ListIndexersResponse
+	if err := get(ctx, "/indexing/indexers", nil, &indexers, "list indexers"); err != nil {
+		return ListIndexersResponse{}, err
+	}
+	return indexers, nil
+}
+
+// CreateIndexer creates a new indexer. Deploy code to it with DeployDirectory.
+// Returns an IndexerResponse containing the created indexer, or an error if the request or unmarshalling fails.
+func CreateIndexer(ctx context.Context, req CreateIndexerRequest) (IndexerResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return IndexerResponse{}, invalidInput("indexer name cannot be empty")
+	}
+
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return IndexerResponse{}, &IndexingError{
+			Code:    500,
+			Message: "failed to marshal create indexer request",
+			Err:     err,
+		}
+	}
+
+	res, err := requests.PostReq(ctx, "/indexing/indexers", strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return IndexerResponse{}, &IndexingError{
+			Code:    500,
+			Message: "failed to create indexer",
+			Err:     err,
+		}
+	}
+
+	var indexer IndexerResponse
+	if err := json.Unmarshal([]byte(res), &indexer); err != nil {
+		return IndexerResponse{}, &IndexingError{
+			Code:    500,
+			Message: "failed to unmarshal create indexer response",
+			Err:     err,
+		}
+	}
+
+	return indexer, nil
+}
+
+// GetIndexer retrieves an indexer, including the status of its latest deployment, by its UUID.
+// Returns an IndexerResponse struct or an error if the request or unmarshalling fails.
+func GetIndexer(ctx context.Context, indexerUuid string) (IndexerResponse, error) {
+	if indexerUuid == "" {
+		return IndexerResponse{}, invalidInput("indexer UUID cannot be empty")
+	}
+
+	var indexer IndexerResponse
+	if err := get(ctx, "/indexing/indexers/"+indexerUuid, nil, &indexer, "get indexer"); err != nil {
+		return IndexerResponse{}, err
+	}
+	return indexer, nil
+}
+
+// ListDeployments lists the deployments of an indexer.
+// Returns a ListDeploymentsResponse struct or an error if the request or unmarshalling fails.
+func ListDeployments(ctx context.Context, indexerUuid string) (ListDeploymentsResponse, error) {
+	if indexerUuid == "" {
+		return ListDeploymentsResponse{}, invalidInput("indexer UUID cannot be empty")
+	}
+
+	var deployments ListDeploymentsResponse
+	if err := get(ctx, "/indexing/indexers/"+indexerUuid+"/deployments", nil, &deployments, "list deployments"); err != nil {
+		return ListDeploymentsResponse{}, err
+	}
+	return deployments, nil
+}
+
+// GetLogs reads the logs of a deployed indexer, filtered by opts.
+// Returns a LogsResponse struct or an error if the request or unmarshalling fails.
+func GetLogs(ctx context.Context, indexerUuid string, opts LogOptions) (LogsResponse, error) {
+	if indexerUuid == "" {
+		return LogsResponse{}, invalidInput("indexer UUID cannot be empty")
+	}
+	if opts.Limit < 0 {
+		return LogsResponse{}, invalidInput("log limit cannot be negative")
+	}
+
+	params := map[string]string{}
+	if opts.Container != "" {
+		params["container"] = opts.Container
+	}
+	if opts.Level != "" {
+		params["level"] = opts.Level
+	}
+	if !opts.From.IsZero() {
+		params["from"] = opts.From.UTC().Format("2006-01-02T15:04:05.000Z07:00")
+	}
+	if opts.Limit > 0 {
+		params["limit"] = strconv.Itoa(opts.Limit)
+	}
+
+	var logs LogsResponse
+	if err := get(ctx, "/indexing/indexers/"+indexerUuid+"/logs", params, &logs, "get indexer logs"); err != nil {
+		return LogsResponse{}, err
+	}
+	return logs, nil
+}
+
+// get sends a GET request and decodes the response into out; operation names the call for error messages.
+func get(ctx context.Context, path string, params map[string]string, out any, operation string) error {
+	res, err := requests.GetReq(ctx, path, params)
+	if err != nil {
+		return &IndexingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to %s", operation),
+			Err:     err,
+		}
+	}
+
+	if err := json.Unmarshal([]byte(res), out); err != nil {
+		return &IndexingError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to unmarshal %s response", operation),
+			Err:     err,
+		}
+	}
+	return nil
+}
+
+func invalidInput(message string) *IndexingError {
+	return &IndexingError{
+		Code:    ErrCodeInvalidInput,
+		Message: message,
+	}
+}
@@ -0,0 +1,257 @@
+package indexing
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Apillon/go-sdk/internal/apitest"
+	"github.com/Apillon/go-sdk/requests"
+)
+
+// archiveNames returns the sorted names of the files in a gzipped tarball.
+func archiveNames(r io.Reader) ([]string, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, header.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// writeProject writes files to a new project directory and returns it.
+func writeProject(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestInputValidation(t *testing.T) {
+	server := apitest.NewServer(t)
+	ctx := context.Background()
+
+	checks := map[string]error{}
+	_, checks["CreateIndexer"] = CreateIndexer(ctx, CreateIndexerRequest{Name: " "})
+	_, checks["GetIndexer"] = GetIndexer(ctx, "")
+	_, checks["ListDeployments"] = ListDeployments(ctx, "")
+	_, checks["GetLogs"] = GetLogs(ctx, "uuid", LogOptions{Limit: -1})
+	_, checks["DeployDirectory"] = DeployDirectory(ctx, "uuid", t.TempDir())
+	_, checks["Query"] = Query[map[string]any](ctx, "http://localhost", " ", nil)
+
+	for name, err := range checks {
+		var indexingErr *IndexingError
+		if !errors.As(err, &indexingErr) || indexingErr.Code != ErrCodeInvalidInput {
+			t.Errorf("%s: expected ErrCodeInvalidInput, got %v", name, err)
+		}
+	}
+	if got := server.Received(); len(got) != 0 {
+		t.Errorf("expected no requests for invalid input, got %+v", got)
+	}
+}
+
+func TestIndexerRequests(t *testing.T) {
+	ctx := context.Background()
+	server := apitest.NewServer(t)
+	server.Reply("GET /indexing/indexers", map[string]any{"items": []Indexer{{IndexerUUID: "i-1"}, {IndexerUUID: "i-2"}}, "total": 2})
+	server.Reply("POST /indexing/indexers", Indexer{IndexerUUID: "i-3", Name: "transfers"})
+	server.Reply("GET /indexing/indexers/{uuid}", Indexer{IndexerUUID: "i-1", Status: IndexerActive})
+	server.Reply("GET /indexing/indexers/{uuid}/deployments", map[string]any{"items": []Deployment{{DeploymentID: 3, Status: "OK"}}, "total": 1})
+
+	list, err := ListIndexers(ctx)
+	if err != nil || list.Data.Total != 2 {
+		t.Fatalf("ListIndexers = %+v, %v", list.Data, err)
+	}
+	created, err := CreateIndexer(ctx, CreateIndexerRequest{Name: "transfers"})
+	if err != nil || created.Data.IndexerUUID != "i-3" {
+		t.Fatalf("CreateIndexer = %+v, %v", created.Data, err)
+	}
+	indexer, err := GetIndexer(ctx, "i-1")
+	if err != nil || indexer.Data.Status != IndexerActive {
+		t.Fatalf("GetIndexer = %+v, %v", indexer.Data, err)
+	}
+	deployments, err := ListDeployments(ctx, "i-1")
+	if err != nil || deployments.Data.Items[0].DeploymentID != 3 || !deployments.Data.Items[0].Status.Done() {
+		t.Fatalf("ListDeployments = %+v, %v", deployments.Data, err)
+	}
+
+	want := []apitest.Request{
+		{Method: "GET", Path: "/indexing/indexers"},
+		{Method: "POST", Path: "/indexing/indexers", Body: `{"name":"transfers"}`},
+		{Method: "GET", Path: "/indexing/indexers/i-1"},
+		{Method: "GET", Path: "/indexing/indexers/i-1/deployments"},
+	}
+	if got := server.Received(); !slices.Equal(got, want) {
+		t.Errorf("requests %+v, want %+v", got, want)
+	}
+}
+
+func TestGetLogs(t *testing.T) {
+	ctx := context.Background()
+	server := apitest.NewServer(t)
+	server.Reply("GET /indexing/indexers/{uuid}/logs", map[string]any{"items": []LogEntry{{Container: "processor", Message: "started"}}, "total": 1})
+
+	from := time.Date(2025, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+	logs, err := GetLogs(ctx, "i-1", LogOptions{Container: "processor", Level: "ERROR", From: from, Limit: 50})
+	if err != nil || logs.Data.Items[0].Message != "started" {
+		t.Fatalf("GetLogs = %+v, %v", logs.Data, err)
+	}
+	if _, err := GetLogs(ctx, "i-1", LogOptions{}); err != nil {
+		t.Fatalf("GetLogs without filters failed: %v", err)
+	}
+
+	got := server.Received()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 requests, got %+v", got)
+	}
+	if want := "container=processor&from=2025-01-02T02%3A04%3A05.000Z&level=ERROR&limit=50"; got[0].Query != want {
+		t.Errorf("filtered query %q, want %q", got[0].Query, want)
+	}
+	if got[1].Query != "" {
+		t.Errorf("expected no filters, got %q", got[1].Query)
+	}
+}
+
+func TestDeployDirectory(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.HandleUploads("/indexing/indexers")
+	server.Reply("POST /indexing/indexers/{uuid}/deploy", Deployment{DeploymentID: 4, Status: "PENDING"})
+
+	project := writeProject(t, map[string]string{"squid.yaml": "manifest: 1", "src/main.ts": "run()", "node_modules/pkg/index.js": "skipped"})
+	res, err := DeployDirectory(context.Background(), "i-1", project)
+	if err != nil || res.Data.DeploymentID != 4 || res.Data.Status.Done() {
+		t.Fatalf("DeployDirectory = %+v, %v", res.Data, err)
+	}
+
+	files := server.Files("i-1")
+	if len(files) != 1 || files[0].Path() != "source.tar.gz" {
+		t.Fatalf("uploaded %+v, want the source archive", files)
+	}
+	names, err := archiveNames(strings.NewReader(files[0].Content))
+	if err != nil || strings.Join(names, ",") != "squid.yaml,src/main.ts" {
+		t.Errorf("uploaded archive with %v, %v", names, err)
+	}
+	want := []string{
+		"POST /indexing/indexers/i-1/upload",
+		"PUT /signed/session-1/0",
+		"POST /indexing/indexers/i-1/upload/session-1/end",
+		"POST /indexing/indexers/i-1/deploy",
+	}
+	if got := server.Requests(); !slices.Equal(got, want) {
+		t.Errorf("requests %q, want %q", got, want)
+	}
+}
+
+func TestDeployToMissingIndexer(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.ReplyError("POST /indexing/indexers/{uuid}/upload", http.StatusNotFound, 40400000, "INDEXER_NOT_FOUND")
+
+	project := writeProject(t, map[string]string{"squid.yaml": "manifest: 1"})
+	_, err := DeployDirectory(context.Background(), "missing", project)
+	var apiErr *requests.APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusNotFound {
+		t.Fatalf("expected the upload session error, got %v", err)
+	}
+	if got := server.Requests(); len(got) != 1 {
+		t.Errorf("expected no upload or deploy after the failed session, got %q", got)
+	}
+}
+
+func TestUnmarshalIndexer(t *testing.T) {
+	body := `{"id":"1","status":200,"data":{"indexerUuid":"abc","name":"squid","status":5,"lastDeploymentStatus":"OK","graphqlUrl":"https://squid.example/graphql","createTime":"2025-01-02T03:04:05.000Z"}}`
+
+	var res IndexerResponse
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if res.Data.Status != IndexerActive || !res.Data.LastDeploymentStatus.Done() || res.Data.GraphQLURL == "" {
+		t.Errorf("unexpected indexer %+v", res.Data)
+	}
+}
+
+func TestPackageDirectory(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"squid.yaml":                "manifest: 1",
+		"src/main.ts":               "run()",
+		"node_modules/pkg/index.js": "skipped",
+		".git/HEAD":                 "skipped",
+	})
+
+	var archive bytes.Buffer
+	if err := PackageDirectory(dir, &archive); err != nil {
+		t.Fatalf("PackageDirectory failed: %v", err)
+	}
+	names, err := archiveNames(&archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(names, ",") != "squid.yaml,src/main.ts" {
+		t.Errorf("unexpected archive contents %v", names)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if strings.Contains(req.Query, "broken") {
+			w.Write([]byte(`{"data":null,"errors":[{"message":"Cannot query field \"broken\""}]}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"transfers":[{"id":"` + req.Variables["id"].(string) + `"}]}}`))
+	}))
+	defer server.Close()
+
+	type result struct {
+		Transfers []struct {
+			ID string `json:"id"`
+		} `json:"transfers"`
+	}
+
+	ctx := context.Background()
+	res, err := Query[result](ctx, server.URL, "query($id: String!) { transfers(id: $id) { id } }", map[string]any{"id": "42"})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(res.Transfers) != 1 || res.Transfers[0].ID != "42" {
+		t.Errorf("unexpected result %+v", res)
+	}
+
+	_, err = Query[result](ctx, server.URL, "{ broken }", nil)
+	var indexingErr *IndexingError
+	if !errors.As(err, &indexingErr) || !strings.Contains(indexingErr.Message, "broken") {
+		t.Errorf("expected GraphQL error, got %v", err)
+	}
+}
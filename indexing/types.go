@@ -0,0 +1,104 @@
+package indexing
+
+import (
+	"time"
+
+	"github.com/Apillon/go-sdk/storage"
+)
+
+// IndexerStatus is the status of an indexer.
+type IndexerStatus int
+
+const (
+	IndexerDraft    IndexerStatus = 1 // Created, never deployed
+	IndexerActive   IndexerStatus = 5 // Deployed and running
+	IndexerInactive IndexerStatus = 7 // Hibernated or stopped
+)
+
+func (s IndexerStatus) String() string {
+	switch s {
+	case IndexerDraft:
+		return "draft"
+	case IndexerActive:
+		return "active"
+	case IndexerInactive:
+		return "inactive"
+	default:
+		return "unknown"
+	}
+}
+
+// DeploymentStatus is the status of an indexer deployment as reported by SQD.
+type DeploymentStatus string
+
+const (
+	DeploymentUnpacking DeploymentStatus = "UNPACKING"
+	DeploymentBuilding  DeploymentStatus = "IMAGE_BUILDING"
+	DeploymentDeploying DeploymentStatus = "DEPLOYING"
+	DeploymentOK        DeploymentStatus = "OK"
+	DeploymentFailed    DeploymentStatus = "FAILED"
+)
+
+// Done reports whether the deployment reached a final status.
+func (s DeploymentStatus) Done() bool {
+	return s == DeploymentOK || s == DeploymentFailed
+}
+
+// Indexer contains information about an indexer.
+type Indexer struct {
+	storage.Timestamps
+	IndexerUUID          string           `json:"indexerUuid"`          // Unique identifier for the indexer
+	Name                 string           `json:"name"`                 // Name of the indexer
+	Description          string           `json:"description"`          // Description of the indexer
+	Status               IndexerStatus    `json:"status"`               // Status of the indexer
+	SquidID              int              `json:"squidId"`              // ID of the squid in SQD Cloud
+	SquidReference       string           `json:"squidReference"`       // Reference of the squid in SQD Cloud
+	LastDeploymentID     int              `json:"lastDeploymentId"`     // ID of the latest deployment
+	LastDeploymentStatus DeploymentStatus `json:"lastDeploymentStatus"` // Status of the latest deployment
+	GraphQLURL           string           `json:"graphqlUrl"`           // GraphQL endpoint of the deployed indexer
+}
+
+// Deployment contains information about an indexer deployment.
+type Deployment struct {
+	storage.Timestamps
+	DeploymentID int              `json:"deploymentId"` // ID of the deployment
+	Status       DeploymentStatus `json:"status"`       // Status of the deployment
+	Failed       string           `json:"failed"`       // Failure reason, if the deployment failed
+}
+
+// LogEntry is a log line of a deployed indexer.
+type LogEntry struct {
+	Timestamp storage.Time `json:"timestamp"` // When the line was logged
+	Container string       `json:"container"` // Container that logged the line, e.g. "processor" or "api"
+	Level     string       `json:"level"`     // Log level, e.g. "INFO"
+	Message   string       `json:"message"`   // Log message
+}
+
+// LogOptions filters the logs returned by GetLogs. Zero values are not sent.
+type LogOptions struct {
+	Container string    // Only logs of this container
+	Level     string    // Only logs of this level
+	From      time.Time // Only logs after this time
+	Limit     int       // Maximum number of lines
+}
+
+// IndexerResponse represents a response containing a single indexer.
+type IndexerResponse = storage.APIResponse[Indexer]
+
+// ListIndexersResponse represents a response containing a list of indexers.
+type ListIndexersResponse = storage.APIResponse[storage.ListData[Indexer]]
+
+// DeploymentResponse represents a response containing a single deployment.
+type DeploymentResponse = storage.APIResponse[Deployment]
+
+// ListDeploymentsResponse represents a response containing a list of deployments.
+type ListDeploymentsResponse = storage.APIResponse[storage.ListData[Deployment]]
+
+// LogsResponse represents a response containing indexer logs.
+type LogsResponse = storage.APIResponse[storage.ListData[LogEntry]]
+
+// CreateIndexerRequest represents the request body for creating an indexer.
+type CreateIndexerRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
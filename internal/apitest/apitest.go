@@ -0,0 +1,422 @@
+// Package apitest fakes the Apillon API for tests. It serves the storage bucket routes used by the
+// SDK (listing, upload sessions, signed URL uploads, downloads and deletes) from memory, and other
+// routes can be added to Mux.
+package apitest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Apillon/go-sdk/credentials"
+	"github.com/Apillon/go-sdk/requests"
+)
+
+// ErrCodeFileNotFound is returned when deleting or getting a file that does not exist.
+const ErrCodeFileNotFound = 40406005
+
+// File is a file stored in a fake bucket.
+type File struct {
+	UUID    string
+	Dir     string // Directory, empty or ending in "/"
+	Name    string
+	Content string
+	CID     string // Empty while the file is being processed
+	Created time.Time
+	Updated time.Time
+}
+
+// Path returns the full path of the file.
+func (f *File) Path() string {
+	return f.Dir + f.Name
+}
+
+type upload struct {
+	Path        string `json:"path"`
+	FileName    string `json:"fileName"`
+	ContentType string `json:"contentType"`
+	content     string
+}
+
+// Request is a request received by a Server.
+type Request struct {
+	Method string
+	Path   string
+	Query  string // Raw query, without "?"
+	Body   string
+}
+
+type pending struct {
+	file     *File
+	listings int
+}
+
+// Server is a fake Apillon API. The zero value is not usable; create one with NewServer.
+type Server struct {
+	*httptest.Server
+	Mux *http.ServeMux
+
+	// ProcessAfter is the number of bucket listings during which uploaded files are not visible yet,
+	// like files the API is still processing after an upload session ends.
+	ProcessAfter int
+
+	mu       sync.Mutex
+	buckets  map[string][]*File
+	pending  map[string][]pending
+	sessions map[string][]upload
+	requests []Request
+	nextID   int
+	now      time.Time
+}
+
+// NewServer starts a fake API and points the SDK requests at it until the test ends.
+func NewServer(t testing.TB) *Server {
+	s := &Server{
+		Mux:      http.NewServeMux(),
+		buckets:  map[string][]*File{},
+		pending:  map[string][]pending{},
+		sessions: map[string][]upload{},
+		now:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	s.Mux.HandleFunc("GET /storage/buckets/{bucket}/files", s.listFiles)
+	s.Mux.HandleFunc("GET /storage/buckets/{bucket}/files/{file}", s.getFile)
+	s.Mux.HandleFunc("DELETE /storage/buckets/{bucket}/files/{file}", s.deleteFile)
+	s.Mux.HandleFunc("GET /storage/buckets/{bucket}/content", s.listContent)
+	s.HandleUploads("/storage/buckets")
+	s.Mux.HandleFunc("PUT /signed/{session}/{index}", s.put)
+	s.Mux.HandleFunc("GET /ipfs/{cid}", s.download)
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{r.Method, r.URL.Path, r.URL.RawQuery, string(body)})
+		s.mu.Unlock()
+		s.Mux.ServeHTTP(w, r)
+	}))
+	requests.SetCredentials(credentials.Credentials{APIKey: "test", BaseURL: s.URL})
+	t.Cleanup(func() {
+		requests.SetCredentials(credentials.Credentials{})
+		s.Close()
+	})
+	return s
+}
+
+// HandleUploads serves upload sessions under prefix like the storage bucket routes. Files uploaded to
+// prefix+"/{uuid}/upload" are stored in the fake bucket named uuid, e.g. the files of a website with
+// prefix "/hosting/websites".
+func (s *Server) HandleUploads(prefix string) {
+	s.Mux.HandleFunc("POST "+prefix+"/{bucket}/upload", s.startSession)
+	s.Mux.HandleFunc("POST "+prefix+"/{bucket}/upload/{session}/end", s.endSession)
+}
+
+// AddFile stores a processed file at a full path of a bucket and returns it.
+func (s *Server) AddFile(bucket, path, content string) *File {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f := s.newFile(path, content)
+	f.CID = CID(content)
+	s.buckets[bucket] = append(s.buckets[bucket], f)
+	return f
+}
+
+// Files returns the visible files of a bucket, sorted by path.
+func (s *Server) Files(bucket string) []*File {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files := append([]*File(nil), s.buckets[bucket]...)
+	sort.Slice(files, func(i, j int) bool { return files[i].Path() < files[j].Path() })
+	return files
+}
+
+// Reply answers requests matching pattern (see http.ServeMux) with data wrapped in an API response.
+func (s *Server) Reply(pattern string, data any) {
+	s.Mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		WriteJSON(w, data)
+	})
+}
+
+// ReplyError answers requests matching pattern with an API error response.
+func (s *Server) ReplyError(pattern string, status int, code int, message string) {
+	s.Mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		WriteError(w, status, code, message)
+	})
+}
+
+// Received returns the requests received so far, with their query and body.
+func (s *Server) Received() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Requests returns the requests received so far as "METHOD /path".
+func (s *Server) Requests() []string {
+	var lines []string
+	for _, r := range s.Received() {
+		lines = append(lines, r.Method+" "+r.Path)
+	}
+	return lines
+}
+
+// Count returns the number of requests received whose "METHOD /path" starts with prefix.
+func (s *Server) Count(prefix string) int {
+	n := 0
+	for _, r := range s.Requests() {
+		if strings.HasPrefix(r, prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+// CID returns the fake CID of content.
+func CID(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "bafy" + hex.EncodeToString(sum[:8])
+}
+
+// WriteJSON writes data wrapped in an API response.
+func WriteJSON(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"id": "test", "status": http.StatusOK, "data": data})
+}
+
+// WriteError writes an API error response.
+func WriteError(w http.ResponseWriter, status int, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{"status": status, "code": code, "message": message})
+}
+
+func (s *Server) newFile(path, content string) *File {
+	s.nextID++
+	s.now = s.now.Add(time.Second)
+	dir, name := "", path
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		dir, name = path[:i+1], path[i+1:]
+	}
+	return &File{
+		UUID:    fmt.Sprintf("file-%d", s.nextID),
+		Dir:     dir,
+		Name:    name,
+		Content: content,
+		Created: s.now,
+		Updated: s.now,
+	}
+}
+
+// process publishes the pending files of a bucket that have waited long enough, replacing files at the same path
+// unless they already hold the same content.
+func (s *Server) process(bucket string) {
+	var waiting []pending
+	for _, p := range s.pending[bucket] {
+		if p.listings < s.ProcessAfter {
+			p.listings++
+			waiting = append(waiting, p)
+			continue
+		}
+		// Uploading the content a path already holds leaves the file as it is
+		files := s.buckets[bucket][:0]
+		identical := false
+		for _, f := range s.buckets[bucket] {
+			if f.Path() != p.file.Path() {
+				files = append(files, f)
+			} else if f.CID != "" && f.Content == p.file.Content {
+				identical = true
+				files = append(files, f)
+			}
+		}
+		if !identical {
+			p.file.CID = CID(p.file.Content)
+			files = append(files, p.file)
+		}
+		s.buckets[bucket] = files
+	}
+	s.pending[bucket] = waiting
+}
+
+func (s *Server) fileJSON(f *File) map[string]any {
+	var dir any
+	if f.Dir != "" {
+		dir = f.Dir
+	}
+	return map[string]any{
+		"fileUuid":    f.UUID,
+		"CID":         f.CID,
+		"name":        f.Name,
+		"path":        dir,
+		"size":        len(f.Content),
+		"contentType": "text/plain",
+		"link":        s.URL + "/ipfs/" + f.CID,
+		"createTime":  f.Created.Format(time.RFC3339),
+		"updateTime":  f.Updated.Format(time.RFC3339),
+	}
+}
+
+func (s *Server) find(bucket, uuid string) (int, *File) {
+	for i, f := range s.buckets[bucket] {
+		if f.UUID == uuid {
+			return i, f
+		}
+	}
+	return -1, nil
+}
+
+func (s *Server) listFiles(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bucket := r.PathValue("bucket")
+	s.process(bucket)
+
+	items := []map[string]any{}
+	for _, f := range s.buckets[bucket] {
+		items = append(items, s.fileJSON(f))
+	}
+	WriteJSON(w, map[string]any{"items": items, "total": len(items)})
+}
+
+func (s *Server) getFile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, f := s.find(r.PathValue("bucket"), r.PathValue("file")); f != nil {
+		WriteJSON(w, s.fileJSON(f))
+		return
+	}
+	WriteError(w, http.StatusNotFound, ErrCodeFileNotFound, "FILE_NOT_FOUND")
+}
+
+func (s *Server) deleteFile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bucket := r.PathValue("bucket")
+	i, f := s.find(bucket, r.PathValue("file"))
+	if f == nil {
+		WriteError(w, http.StatusNotFound, ErrCodeFileNotFound, "FILE_NOT_FOUND")
+		return
+	}
+	s.buckets[bucket] = append(s.buckets[bucket][:i], s.buckets[bucket][i+1:]...)
+	WriteJSON(w, s.fileJSON(f))
+}
+
+// listContent lists the directories and files directly inside ?directoryUuid, where directory
+// UUIDs are "dir:" followed by the directory path. Directory CIDs are derived from their files.
+func (s *Server) listContent(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bucket := r.PathValue("bucket")
+	s.process(bucket)
+	parent := strings.TrimPrefix(r.URL.Query().Get("directoryUuid"), "dir:")
+
+	items := []map[string]any{}
+	dirs := map[string][]string{}
+	for _, f := range s.buckets[bucket] {
+		if !strings.HasPrefix(f.Dir, parent) {
+			continue
+		}
+		rest := strings.TrimPrefix(f.Path(), parent)
+		if name, _, ok := strings.Cut(rest, "/"); ok {
+			dirs[name] = append(dirs[name], f.CID)
+			continue
+		}
+		item := s.fileJSON(f)
+		item["type"] = 2
+		item["uuid"] = f.UUID
+		items = append(items, item)
+	}
+	for name, cids := range dirs {
+		sort.Strings(cids)
+		cid := ""
+		if !slices.Contains(cids, "") {
+			cid = CID(strings.Join(cids, ","))
+		}
+		items = append(items, map[string]any{"type": 1, "uuid": "dir:" + parent + name + "/", "name": name, "CID": cid})
+	}
+	WriteJSON(w, map[string]any{"items": items, "total": len(items)})
+}
+
+func (s *Server) startSession(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Files []upload `json:"files"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		WriteError(w, http.StatusBadRequest, 40000001, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	s.nextID++
+	session := "session-" + strconv.Itoa(s.nextID)
+	s.sessions[session] = body.Files
+	s.mu.Unlock()
+
+	files := []map[string]any{}
+	for i, f := range body.Files {
+		files = append(files, map[string]any{"fileName": f.FileName, "path": f.Path, "url": fmt.Sprintf("%s/signed/%s/%d?X-Amz-Signature=x", s.URL, session, i)})
+	}
+	WriteJSON(w, map[string]any{"sessionUuid": session, "files": files})
+}
+
+func (s *Server) put(w http.ResponseWriter, r *http.Request) {
+	content, _ := io.ReadAll(r.Body)
+	i, _ := strconv.Atoi(r.PathValue("index"))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files := s.sessions[r.PathValue("session")]
+	if i >= len(files) {
+		http.Error(w, "unknown upload", http.StatusForbidden)
+		return
+	}
+	files[i].content = string(content)
+}
+
+func (s *Server) endSession(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bucket := r.PathValue("bucket")
+	files, ok := s.sessions[r.PathValue("session")]
+	if !ok {
+		WriteError(w, http.StatusNotFound, 40406002, "FILE_UPLOAD_SESSION_NOT_FOUND")
+		return
+	}
+	delete(s.sessions, r.PathValue("session"))
+
+	for _, u := range files {
+		dir := strings.Trim(u.Path, "/")
+		if dir != "" {
+			dir += "/"
+		}
+		s.pending[bucket] = append(s.pending[bucket], pending{file: s.newFile(dir+u.FileName, u.content)})
+	}
+	if s.ProcessAfter == 0 {
+		s.process(bucket)
+	}
+	WriteJSON(w, true)
+}
+
+func (s *Server) download(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, files := range s.buckets {
+		for _, f := range files {
+			if f.CID == r.PathValue("cid") {
+				io.WriteString(w, f.Content)
+				return
+			}
+		}
+	}
+	http.NotFound(w, r)
+}
@@ -0,0 +1,133 @@
+// Package nft provides functions to manage Apillon NFT collections on EVM and Substrate chains:
+// creating and listing collections, minting, nest-minting, burning and transferring ownership.
+package nft
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Apillon/go-sdk/requests"
+)
+
+// NFTError represents an error that occurred during NFT operations
+type NFTError struct {
+	Code    int
+	Message string
+	Err     error
+}
+
+func (e *NFTError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("nft error (code %d): %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("nft error (code %d): %s", e.Code, e.Message)
+}
+
+func (e *NFTError) Unwrap() error {
+	return e.Err
+}
+
+// Error codes
+const (
+	ErrCodeInvalidInput = 40000001
+)
+
+// ListCollections lists all NFT collections in the project.
+// Returns a ListCollectionsResponse struct or an error if the request or unmarshalling fails.
+func ListCollections(ctx context.Context) (ListCollectionsResponse, error) {
+	res, err := requests.GetReq(ctx, "/nfts/collections", nil)
+	if err != nil {
+		return ListCollectionsResponse{}, &NFTError{
+			Code:    500,
+			Message: "failed to list collections",
+			Err:     err,
+		}
+	}
+
+	var collections ListCollectionsResponse
+	if err := json.Unmarshal([]byte(res), &collections); err != nil {
+		return ListCollectionsResponse{}, &NFTError{
+			Code:    500,
+			Message: "failed to unmarshal list collections response",
+			Err:     err,
+		}
+	}
+
+	return collections, nil
+}
+
+// GetCollection retrieves a collection by its UUID.
+// Returns a CollectionResponse struct or an error if the request or unmarshalling fails.
+func GetCollection(ctx context.Context, collectionUuid string) (CollectionResponse, error) {
+	if collectionUuid == "" {
+		return CollectionResponse{}, invalidInput("collection UUID cannot be empty")
+	}
+
+	res, err := requests.GetReq(ctx, "/nfts/collections/"+collectionUuid, nil)
+	if err != nil {
+		return CollectionResponse{}, &NFTError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to get collection %s", collectionUuid),
+			Err:     err,
+		}
+	}
+
+	return unmarshalCollection(res, "get collection")
+}
+
+// CreateEvmCollection creates and deploys a new collection on an EVM chain.
+// The request is validated locally (name, symbol, royalties, drop settings and addresses) before it is sent.
+// Returns a CollectionResponse containing the created collection, or an error if validation or the request fails.
+func CreateEvmCollection(ctx context.Context, req CreateEvmCollectionRequest) (CollectionResponse, error) {
+	if err := req.validate(); err != nil {
+		return CollectionResponse{}, err
+	}
+	return createCollection(ctx, "/nfts/collections/evm", req)
+}
+
+// CreateSubstrateCollection creates and deploys a new collection on a Substrate chain.
+// The request is validated locally (name, symbol, royalties, drop settings and addresses) before it is sent.
+// Returns a CollectionResponse containing the created collection, or an error if validation or the request fails.
+func CreateSubstrateCollection(ctx context.Context, req CreateSubstrateCollectionRequest) (CollectionResponse, error) {
+	if err := req.validate(); err != nil {
+		return CollectionResponse{}, err
+	}
+	return createCollection(ctx, "/nfts/collections/substrate", req)
+}
+
+func createCollection(ctx context.Context, path string, req any) (CollectionResponse, error) {
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return CollectionResponse{}, &NFTError{
+			Code:    500,
+			Message: "failed to marshal create collection request",
+			Err:     err,
+		}
+	}
+
+	res, err := requests.PostReq(ctx, path, strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return CollectionResponse{}, &NFTError{
+			Code:    500,
+			Message: "failed to create collection",
+			Err:     err,
+		}
+	}
+
+	return unmarshalCollection(res, "create collection")
+}
+
+// unmarshalCollection decodes a single collection response; operation names the call for error messages.
+func unmarshalCollection(res string, operation string) (CollectionResponse, error) {
+	var collection CollectionResponse
+	if err := json.Unmarshal([]byte(res), &collection); err != nil {
+		return CollectionResponse{}, &NFTError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to unmarshal %s response", operation),
+			Err:     err,
+		}
+	}
+	return collection, nil
+}
@@ -0,0 +1,197 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Apillon/go-sdk/nft"
+	"github.com/Apillon/go-sdk/storage"
+)
+
+// Builder uploads token images and metadata to a storage bucket.
+// Only BucketUUID and ImageDir are required; the other fields have defaults.
+type Builder struct {
+	BucketUUID   string        // Bucket receiving images and metadata
+	ImageDir     string        // Local directory containing the images referenced by Token.Image
+	ImagesPath   string        // Bucket directory for images (default "images/")
+	MetadataPath string        // Bucket directory for metadata files (default "metadata/")
+	Extension    string        // Metadata file extension (default ".json"); set the collection's BaseExtension to match
+	UseLinks     bool          // Use gateway links instead of ipfs:// URIs for images
+	PollInterval time.Duration // Delay between checks while waiting for CIDs
+}
+
+// Result is the outcome of a successful Build.
+type Result struct {
+	BaseURI     string  // Base URI to use when creating the collection, e.g. "ipfs://<cid>/"
+	MetadataCID string  // CID of the metadata directory
+	Tokens      []Token // Tokens with image fields rewritten to the uploaded URIs
+}
+
+// Build uploads the images referenced by tokens, rewrites the image fields to their CIDs
+// (or links when UseLinks is set), then uploads one metadata file per token named after its ID.
+// Images are uploaded first because the metadata must contain their final URIs.
+// The input slice is not modified.
+// Returns a Result with the base URI of the metadata directory, or an error if validation,
+// reading images, uploading or waiting for CIDs fails.
+func (b Builder) Build(ctx context.Context, tokens []Token) (Result, error) {
+	if b.BucketUUID == "" {
+		return Result{}, invalidInput("bucket UUID cannot be empty")
+	}
+	if err := validateTokens(tokens); err != nil {
+		return Result{}, err
+	}
+	b = b.withDefaults()
+	if strings.Count(b.MetadataPath, "/") != 1 {
+		return Result{}, invalidInput("metadata path must be a single top-level directory, got %q", b.MetadataPath)
+	}
+
+	images, err := b.readImages(tokens)
+	if err != nil {
+		return Result{}, err
+	}
+	metadataPaths := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		metadataPaths = append(metadataPaths, b.MetadataPath+strconv.Itoa(token.ID)+b.Extension)
+	}
+
+	// Record the metadata directory before uploading, so one left by an earlier build is not mistaken for this one
+	dirName := strings.TrimSuffix(b.MetadataPath, "/")
+	before, err := storage.SnapshotFiles(ctx, b.BucketUUID, metadataPaths)
+	if err != nil {
+		return Result{}, err
+	}
+	previousDir, _, err := storage.FindDirectory(ctx, b.BucketUUID, dirName)
+	if err != nil {
+		return Result{}, err
+	}
+
+	uploaded, err := storage.UploadAndWait(ctx, b.BucketUUID, images, b.PollInterval)
+	if err != nil {
+		return Result{}, err
+	}
+
+	built := make([]Token, len(tokens))
+	metadataFiles := make([]storage.WholeFile, 0, len(tokens))
+	for i, token := range tokens {
+		file := uploaded[b.ImagesPath+path.Base(filepath.ToSlash(token.Image))]
+		token.Image = b.fileURI(file)
+		built[i] = token
+
+		content, err := token.JSON()
+		if err != nil {
+			return Result{}, &nft.NFTError{
+				Code:    500,
+				Message: fmt.Sprintf("failed to marshal metadata of token %d", token.ID),
+				Err:     err,
+			}
+		}
+		metadataFiles = append(metadataFiles, storage.WholeFile{
+			Content: string(content),
+			Metadata: storage.FileMetadata{
+				FileName:    strconv.Itoa(token.ID) + b.Extension,
+				ContentType: "application/json",
+				Path:        b.MetadataPath,
+			},
+		})
+	}
+
+	metadata, err := storage.UploadAndWait(ctx, b.BucketUUID, metadataFiles, b.PollInterval)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// The directory CID only changes if a metadata file did
+	previousCID := previousDir.CID
+	if unchanged(metadata, before) {
+		previousCID = ""
+	}
+	dir, err := storage.WaitForDirectoryCID(ctx, b.BucketUUID, dirName, previousCID, b.PollInterval)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		BaseURI:     "ipfs://" + dir.CID + "/",
+		MetadataCID: dir.CID,
+		Tokens:      built,
+	}, nil
+}
+
+// withDefaults fills in unset optional fields and normalises bucket paths to end in a slash.
+func (b Builder) withDefaults() Builder {
+	if b.ImagesPath == "" {
+		b.ImagesPath = "images/"
+	}
+	if b.MetadataPath == "" {
+		b.MetadataPath = "metadata/"
+	}
+	if b.Extension == "" {
+		b.Extension = ".json"
+	}
+	b.ImagesPath = strings.TrimPrefix(strings.TrimSuffix(b.ImagesPath, "/"), "/") + "/"
+	b.MetadataPath = strings.TrimPrefix(strings.TrimSuffix(b.MetadataPath, "/"), "/") + "/"
+	return b
+}
+
+// readImages loads each distinct image referenced by tokens from ImageDir.
+// Images are uploaded flat into ImagesPath, so two different images may not share a file name.
+func (b Builder) readImages(tokens []Token) ([]storage.WholeFile, error) {
+	seen := map[string]string{}
+	var files []storage.WholeFile
+	for _, token := range tokens {
+		name := path.Base(filepath.ToSlash(token.Image))
+		if source, ok := seen[name]; ok {
+			if source != token.Image {
+				return nil, invalidInput("images %q and %q share the file name %s", source, token.Image, name)
+			}
+			continue
+		}
+		seen[name] = token.Image
+
+		content, err := os.ReadFile(filepath.Join(b.ImageDir, filepath.FromSlash(token.Image)))
+		if err != nil {
+			return nil, &nft.NFTError{
+				Code:    nft.ErrCodeInvalidInput,
+				Message: fmt.Sprintf("failed to read image of token %d", token.ID),
+				Err:     err,
+			}
+		}
+		files = append(files, storage.WholeFile{
+			Content: string(content),
+			Metadata: storage.FileMetadata{
+				FileName:    name,
+				ContentType: storage.DetectContentType(name, content),
+				Path:        b.ImagesPath,
+			},
+		})
+	}
+	return files, nil
+}
+
+// unchanged reports whether every uploaded file has the same CID as the file it replaced.
+func unchanged(uploaded map[string]storage.FileInfo, before storage.FileSnapshot) bool {
+	for p, file := range uploaded {
+		same := false
+		for _, old := range before[p] {
+			same = same || old.CID == file.CID
+		}
+		if !same {
+			return false
+		}
+	}
+	return true
+}
+
+// fileURI returns the URI stored in the metadata image field for an uploaded file.
+func (b Builder) fileURI(file storage.FileInfo) string {
+	if b.UseLinks && file.Link != "" {
+		return file.Link
+	}
+	return "ipfs://" + file.CID
+}
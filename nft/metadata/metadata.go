@@ -0,0 +1,144 @@
+// Package metadata builds ERC-721 / OpenSea style token metadata for NFT collections.
+// It uploads token images and metadata JSON files to an Apillon storage bucket and returns
+// the base URI to use when creating the collection.
+package metadata
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Apillon/go-sdk/nft"
+)
+
+// Attribute is a token trait in OpenSea metadata format.
+type Attribute struct {
+	TraitType   string `json:"trait_type"`             // Name of the trait
+	Value       any    `json:"value"`                  // Trait value (string or number)
+	DisplayType string `json:"display_type,omitempty"` // Optional display hint, e.g. "number"
+}
+
+// Token is the metadata of a single NFT.
+// Before building, Image is the name of a file in the image directory; afterwards it holds the uploaded image URI.
+type Token struct {
+	ID           int         `json:"-"`                       // Token ID, also the metadata file name
+	Name         string      `json:"name"`                    // Token name
+	Description  string      `json:"description,omitempty"`   // Token description
+	Image        string      `json:"image"`                   // Image file name or URI
+	ExternalURL  string      `json:"external_url,omitempty"`  // Link to the token on an external site
+	AnimationURL string      `json:"animation_url,omitempty"` // Link to a multimedia attachment
+	Attributes   []Attribute `json:"attributes,omitempty"`    // Token traits
+}
+
+// JSON returns the metadata document of the token.
+func (t Token) JSON() ([]byte, error) {
+	return json.MarshalIndent(t, "", "  ")
+}
+
+// ParseCSV reads tokens from CSV with a header row. The columns id, name, description, image,
+// external_url and animation_url map to Token fields; any other column becomes an attribute
+// named after the column, with numeric values kept as numbers. Empty attribute cells are skipped.
+// Rows without an id column value are numbered from 1 in file order.
+func ParseCSV(r io.Reader) ([]Token, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, invalidInput("failed to parse CSV: %v", err)
+	}
+	if len(records) < 2 {
+		return nil, invalidInput("CSV must contain a header row and at least one token")
+	}
+
+	header := records[0]
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+
+	tokens := make([]Token, 0, len(records)-1)
+	for row, record := range records[1:] {
+		token := Token{ID: row + 1}
+		for i, column := range header {
+			value := strings.TrimSpace(record[i])
+			switch strings.ToLower(column) {
+			case "id":
+				if value == "" {
+					continue
+				}
+				id, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, invalidInput("row %d: invalid token id %q", row+2, value)
+				}
+				token.ID = id
+			case "name":
+				token.Name = value
+			case "description":
+				token.Description = value
+			case "image":
+				token.Image = value
+			case "external_url":
+				token.ExternalURL = value
+			case "animation_url":
+				token.AnimationURL = value
+			default:
+				if value == "" {
+					continue
+				}
+				token.Attributes = append(token.Attributes, Attribute{TraitType: column, Value: attributeValue(value)})
+			}
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+// attributeValue keeps numeric CSV values as numbers so marketplaces can display them as such.
+func attributeValue(value string) any {
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		return n
+	}
+	return value
+}
+
+// validateTokens checks that tokens have names and images and that their IDs are unique.
+func validateTokens(tokens []Token) error {
+	if len(tokens) == 0 {
+		return invalidInput("no tokens provided")
+	}
+
+	seen := map[int]bool{}
+	var errs []error
+	for _, token := range tokens {
+		if token.ID < 0 {
+			errs = append(errs, fmt.Errorf("token %d: id cannot be negative", token.ID))
+		}
+		if seen[token.ID] {
+			errs = append(errs, fmt.Errorf("token %d: duplicate id", token.ID))
+		}
+		seen[token.ID] = true
+		if token.Name == "" {
+			errs = append(errs, fmt.Errorf("token %d: name cannot be empty", token.ID))
+		}
+		if token.Image == "" {
+			errs = append(errs, fmt.Errorf("token %d: image cannot be empty", token.ID))
+		}
+	}
+	if len(errs) > 0 {
+		return &nft.NFTError{
+			Code:    nft.ErrCodeInvalidInput,
+			Message: "invalid token metadata",
+			Err:     errors.Join(errs...),
+		}
+	}
+	return nil
+}
+
+func invalidInput(format string, args ...any) *nft.NFTError {
+	return &nft.NFTError{
+		Code:    nft.ErrCodeInvalidInput,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,197 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Apillon/go-sdk/internal/apitest"
+	"github.com/Apillon/go-sdk/nft"
+)
+
+func TestParseCSV(t *testing.T) {
+	input := `name,description,image,Background,Level
+Cat #1,First cat,cat1.png,Blue,3
+Cat #2,,cat2.png,,7.5
+`
+	tokens, err := ParseCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	if len(tokens) != 2 {
+		t.Fatalf("expected 2 tokens, got %d", len(tokens))
+	}
+
+	first := tokens[0]
+	if first.ID != 1 || first.Name != "Cat #1" || first.Image != "cat1.png" || first.Description != "First cat" {
+		t.Errorf("unexpected first token: %+v", first)
+	}
+	if len(first.Attributes) != 2 || first.Attributes[0].TraitType != "Background" || first.Attributes[0].Value != "Blue" {
+		t.Errorf("unexpected first token attributes: %+v", first.Attributes)
+	}
+	if first.Attributes[1].Value != 3.0 {
+		t.Errorf("expected numeric level, got %#v", first.Attributes[1].Value)
+	}
+
+	second := tokens[1]
+	if second.ID != 2 || len(second.Attributes) != 1 || second.Attributes[0].Value != 7.5 {
+		t.Errorf("unexpected second token: %+v", second)
+	}
+}
+
+func TestParseCSVExplicitIDs(t *testing.T) {
+	tokens, err := ParseCSV(strings.NewReader("id,name,image\n10,Ten,10.png\n20,Twenty,20.png\n"))
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	if tokens[0].ID != 10 || tokens[1].ID != 20 {
+		t.Errorf("expected IDs 10 and 20, got %d and %d", tokens[0].ID, tokens[1].ID)
+	}
+
+	if _, err := ParseCSV(strings.NewReader("id,name,image\nabc,Bad,bad.png\n")); err == nil {
+		t.Error("expected error for non-numeric id")
+	}
+	if _, err := ParseCSV(strings.NewReader("name,image\n")); err == nil {
+		t.Error("expected error for CSV without tokens")
+	}
+}
+
+func TestTokenJSON(t *testing.T) {
+	token := Token{
+		ID:         5,
+		Name:       "Cat #5",
+		Image:      "ipfs://bafyimage",
+		Attributes: []Attribute{{TraitType: "Eyes", Value: "Green"}},
+	}
+	data, err := token.JSON()
+	if err != nil {
+		t.Fatalf("JSON failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if doc["name"] != "Cat #5" || doc["image"] != "ipfs://bafyimage" {
+		t.Errorf("unexpected metadata: %s", data)
+	}
+	if _, ok := doc["id"]; ok {
+		t.Error("token ID should not be part of the metadata")
+	}
+	if _, ok := doc["description"]; ok {
+		t.Error("empty description should be omitted")
+	}
+	attrs := doc["attributes"].([]any)
+	if attrs[0].(map[string]any)["trait_type"] != "Eyes" {
+		t.Errorf("unexpected attributes: %v", attrs)
+	}
+}
+
+func TestBuildValidation(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "a"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		builder Builder
+		tokens  []Token
+	}{
+		{"missing bucket", Builder{ImageDir: dir}, []Token{{ID: 1, Name: "One", Image: "1.png"}}},
+		{"no tokens", Builder{BucketUUID: "bucket", ImageDir: dir}, nil},
+		{"missing name", Builder{BucketUUID: "bucket", ImageDir: dir}, []Token{{ID: 1, Image: "1.png"}}},
+		{"missing image", Builder{BucketUUID: "bucket", ImageDir: dir}, []Token{{ID: 1, Name: "One"}}},
+		{"duplicate id", Builder{BucketUUID: "bucket", ImageDir: dir}, []Token{
+			{ID: 1, Name: "One", Image: "1.png"},
+			{ID: 1, Name: "Also one", Image: "1.png"},
+		}},
+		{"nested metadata path", Builder{BucketUUID: "bucket", ImageDir: dir, MetadataPath: "nft/metadata"}, []Token{
+			{ID: 1, Name: "One", Image: "1.png"},
+		}},
+		{"missing image file", Builder{BucketUUID: "bucket", ImageDir: dir}, []Token{{ID: 1, Name: "One", Image: "1.png"}}},
+		{"clashing image names", Builder{BucketUUID: "bucket", ImageDir: dir}, []Token{
+			{ID: 1, Name: "One", Image: "1.png"},
+			{ID: 2, Name: "Two", Image: "a/1.png"},
+		}},
+	}
+	if err := os.WriteFile(filepath.Join(dir, "1.png"), []byte("png"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a", "1.png"), []byte("png"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests[6].builder.ImageDir = t.TempDir()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build(ctx, tt.tokens)
+			var nftErr *nft.NFTError
+			if !errors.As(err, &nftErr) || nftErr.Code != nft.ErrCodeInvalidInput {
+				t.Errorf("expected invalid input error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestBuildIgnoresPreviousBuild(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.ProcessAfter = 1
+	server.AddFile("b1", "images/1.png", "old png")
+	server.AddFile("b1", "metadata/1.json", `{"name":"Old"}`)
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "1.png"), []byte("new png"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := Builder{BucketUUID: "b1", ImageDir: dir, PollInterval: time.Millisecond}
+	result, err := builder.Build(context.Background(), []Token{{ID: 1, Name: "One", Image: "1.png"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "ipfs://" + apitest.CID("new png"); result.Tokens[0].Image != want {
+		t.Errorf("image = %s, want %s", result.Tokens[0].Image, want)
+	}
+
+	var metadataCID string
+	for _, f := range server.Files("b1") {
+		if f.Path() == "metadata/1.json" {
+			metadataCID = f.CID
+		}
+	}
+	if metadataCID == apitest.CID(`{"name":"Old"}`) || result.MetadataCID != apitest.CID(metadataCID) {
+		t.Errorf("metadata directory CID %s does not contain the new metadata file %s", result.MetadataCID, metadataCID)
+	}
+}
+
+func TestBuildSameTokensTwice(t *testing.T) {
+	server := apitest.NewServer(t)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "1.png"), []byte("png"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	builder := Builder{BucketUUID: "b1", ImageDir: dir, PollInterval: time.Millisecond}
+	tokens := []Token{{ID: 1, Name: "One", Image: "1.png"}}
+	first, err := builder.Build(ctx, tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Nothing changes in the bucket, so the second build must not wait for new CIDs
+	second, err := builder.Build(ctx, tokens)
+	if err != nil {
+		t.Fatalf("rebuilding the same tokens failed: %v", err)
+	}
+	if second.MetadataCID != first.MetadataCID || len(server.Files("b1")) != 2 {
+		t.Errorf("rebuild gave %+v, first build %+v", second, first)
+	}
+}
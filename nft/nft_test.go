@@ -0,0 +1,102 @@
+package nft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+const (
+	testEvmAddress       = "0x52908400098527886E0F7030069857D2E4169EE7"
+	testSubstrateAddress = "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+)
+
+func validSettings() CollectionSettings {
+	return CollectionSettings{
+		CollectionType:   CollectionTypeGeneric,
+		Name:             "Space Cats",
+		Symbol:           "CATS",
+		BaseURI:          "ipfs://base/",
+		MaxSupply:        100,
+		RoyaltiesAddress: testEvmAddress,
+		RoyaltiesFees:    5,
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	if err := ValidateAddress(ChainTypeEVM, testEvmAddress); err != nil {
+		t.Errorf("valid EVM address rejected: %v", err)
+	}
+	if err := ValidateAddress(ChainTypeSubstrate, testSubstrateAddress); err != nil {
+		t.Errorf("valid Substrate address rejected: %v", err)
+	}
+	if err := ValidateAddress(ChainTypeEVM, "0x1234"); err == nil {
+		t.Error("short EVM address accepted")
+	}
+	if err := ValidateAddress(ChainTypeSubstrate, testEvmAddress); err == nil {
+		t.Error("EVM address accepted as Substrate address")
+	}
+}
+
+func TestCollectionValidation(t *testing.T) {
+	if err := (CreateEvmCollectionRequest{CollectionSettings: validSettings(), Chain: EvmChainMoonbase}).validate(); err != nil {
+		t.Fatalf("valid request rejected: %v", err)
+	}
+
+	tests := map[string]func(s *CollectionSettings){
+		"empty name":         func(s *CollectionSettings) { s.Name = "" },
+		"empty symbol":       func(s *CollectionSettings) { s.Symbol = " " },
+		"royalties too high": func(s *CollectionSettings) { s.RoyaltiesFees = 150 },
+		"missing royalties":  func(s *CollectionSettings) { s.RoyaltiesAddress = "" },
+		"bad royalties addr": func(s *CollectionSettings) { s.RoyaltiesAddress = "0xnothex" },
+		"bad type":           func(s *CollectionSettings) { s.CollectionType = 9 },
+		"drop without start": func(s *CollectionSettings) { s.Drop = true },
+		"reserve over supply": func(s *CollectionSettings) {
+			s.Drop, s.DropStart, s.DropReserve = true, 1700000000, 101
+		},
+	}
+	for name, mutate := range tests {
+		settings := validSettings()
+		mutate(&settings)
+		err := CreateEvmCollectionRequest{CollectionSettings: settings, Chain: EvmChainMoonbase}.validate()
+		var nftErr *NFTError
+		if !errors.As(err, &nftErr) || nftErr.Code != ErrCodeInvalidInput {
+			t.Errorf("%s: expected ErrCodeInvalidInput, got %v", name, err)
+		}
+	}
+
+	substrate := CreateSubstrateCollectionRequest{CollectionSettings: validSettings(), Chain: SubstrateChainAstar}
+	if err := substrate.validate(); err == nil {
+		t.Error("EVM royalties address accepted for a Substrate collection")
+	}
+}
+
+func TestMintValidation(t *testing.T) {
+	ctx := context.Background()
+	if _, err := Mint(ctx, "uuid", MintRequest{ReceivingAddress: "nope", Quantity: 1}); err == nil {
+		t.Error("invalid address accepted")
+	}
+	if _, err := Mint(ctx, "uuid", MintRequest{ReceivingAddress: testEvmAddress, Quantity: 0}); err == nil {
+		t.Error("zero quantity accepted")
+	}
+	if _, err := Mint(ctx, "uuid", MintRequest{ReceivingAddress: testEvmAddress, Quantity: 2, IdsToMint: []int{1}}); err == nil {
+		t.Error("mismatched token IDs accepted")
+	}
+}
+
+func TestCreateRequestJSON(t *testing.T) {
+	req := CreateEvmCollectionRequest{CollectionSettings: validSettings(), Chain: EvmChainAstar}
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if fields["chain"] != float64(592) || fields["symbol"] != "CATS" || fields["royaltiesFees"] != float64(5) {
+		t.Errorf("unexpected request body %s", data)
+	}
+}
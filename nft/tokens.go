@@ -0,0 +1,191 @@
+package nft
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Apillon/go-sdk/requests"
+)
+
+// transactionPageSize is the page size used when searching the transactions of a collection.
+const transactionPageSize = 100
+
+// Mint mints NFTs of a collection to the receiving address.
+// The address must be a valid EVM or Substrate address and the quantity must be positive.
+// Returns a MintResponse with the transaction hash, or an error if validation or the request fails.
+func Mint(ctx context.Context, collectionUuid string, req MintRequest) (MintResponse, error) {
+	if collectionUuid == "" {
+		return MintResponse{}, invalidInput("collection UUID cannot be empty")
+	}
+	if err := validateAnyAddress(req.ReceivingAddress); err != nil {
+		return MintResponse{}, err
+	}
+	if req.Quantity <= 0 {
+		return MintResponse{}, invalidInput("quantity must be positive")
+	}
+	if len(req.IdsToMint) > 0 && len(req.IdsToMint) != req.Quantity {
+		return MintResponse{}, invalidInput("expected %d token IDs, got %d", req.Quantity, len(req.IdsToMint))
+	}
+
+	return postCollectionAction(ctx, collectionUuid, "mint", req)
+}
+
+// NestMint mints NFTs of a collection as children of an NFT in a nestable parent collection.
+// Returns a MintResponse with the transaction hash, or an error if validation or the request fails.
+func NestMint(ctx context.Context, collectionUuid string, req NestMintRequest) (MintResponse, error) {
+	if collectionUuid == "" || req.ParentCollectionUUID == "" {
+		return MintResponse{}, invalidInput("collection UUID and parent collection UUID cannot be empty")
+	}
+	if req.ParentNftID < 0 {
+		return MintResponse{}, invalidInput("parent NFT ID cannot be negative")
+	}
+	if req.Quantity <= 0 {
+		return MintResponse{}, invalidInput("quantity must be positive")
+	}
+
+	return postCollectionAction(ctx, collectionUuid, "nest-mint", req)
+}
+
+// Burn burns a token of a revokable collection.
+// Returns a MintResponse with the transaction hash, or an error if the request fails.
+func Burn(ctx context.Context, collectionUuid string, tokenId int) (MintResponse, error) {
+	if collectionUuid == "" {
+		return MintResponse{}, invalidInput("collection UUID cannot be empty")
+	}
+	if tokenId < 0 {
+		return MintResponse{}, invalidInput("token ID cannot be negative")
+	}
+
+	return postCollectionAction(ctx, collectionUuid, "burn", burnRequest{TokenID: tokenId})
+}
+
+// TransferOwnership transfers ownership of a collection's contract to address.
+// After the transfer the collection can no longer be managed through Apillon.
+// Returns a MintResponse with the transaction hash, or an error if validation or the request fails.
+func TransferOwnership(ctx context.Context, collectionUuid string, address string) (MintResponse, error) {
+	if collectionUuid == "" {
+		return MintResponse{}, invalidInput("collection UUID cannot be empty")
+	}
+	if err := validateAnyAddress(address); err != nil {
+		return MintResponse{}, err
+	}
+
+	return postCollectionAction(ctx, collectionUuid, "transfer", transferRequest{Address: address})
+}
+
+// ListTransactions lists the on-chain transactions of a collection.
+// Returns a ListTransactionsResponse struct or an error if the request or unmarshalling fails.
+func ListTransactions(ctx context.Context, collectionUuid string) (ListTransactionsResponse, error) {
+	if collectionUuid == "" {
+		return ListTransactionsResponse{}, invalidInput("collection UUID cannot be empty")
+	}
+
+	res, err := requests.GetReq(ctx, "/nfts/collections/"+collectionUuid+"/transactions", nil)
+	if err != nil {
+		return ListTransactionsResponse{}, &NFTError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to list transactions of collection %s", collectionUuid),
+			Err:     err,
+		}
+	}
+
+	var transactions ListTransactionsResponse
+	if err := json.Unmarshal([]byte(res), &transactions); err != nil {
+		return ListTransactionsResponse{}, &NFTError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to unmarshal list transactions response for collection %s", collectionUuid),
+			Err:     err,
+		}
+	}
+
+	return transactions, nil
+}
+
+// findTransaction searches the transactions of a collection for the given hash, newest first, walking
+// every page until it is found. The bool is false if the collection has no such transaction yet.
+// Returns an error if a request or unmarshalling fails.
+func findTransaction(ctx context.Context, collectionUuid string, hash string) (Transaction, bool, error) {
+	path := "/nfts/collections/" + collectionUuid + "/transactions"
+	seen := 0
+	for page := 1; ; page++ {
+		params := map[string]string{
+			"search":  hash,
+			"orderBy": "createTime",
+			"desc":    "true",
+			"page":    strconv.Itoa(page),
+			"limit":   strconv.Itoa(transactionPageSize),
+		}
+		res, err := requests.GetReq(ctx, path, params)
+		if err != nil {
+			return Transaction{}, false, &NFTError{
+				Code:    500,
+				Message: fmt.Sprintf("failed to list transactions of collection %s (page %d)", collectionUuid, page),
+				Err:     err,
+			}
+		}
+
+		var transactions ListTransactionsResponse
+		if err := json.Unmarshal([]byte(res), &transactions); err != nil {
+			return Transaction{}, false, &NFTError{
+				Code:    500,
+				Message: fmt.Sprintf("failed to unmarshal list transactions response for collection %s (page %d)", collectionUuid, page),
+				Err:     err,
+			}
+		}
+
+		for _, tx := range transactions.Data.Items {
+			if tx.TransactionHash == hash {
+				return tx, true, nil
+			}
+		}
+		seen += len(transactions.Data.Items)
+		if len(transactions.Data.Items) == 0 || seen >= transactions.Data.Total {
+			return Transaction{}, false, nil
+		}
+	}
+}
+
+// postCollectionAction sends a POST request to /nfts/collections/{uuid}/{action} and decodes the mint response.
+func postCollectionAction(ctx context.Context, collectionUuid string, action string, body any) (MintResponse, error) {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return MintResponse{}, &NFTError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to marshal %s request", action),
+			Err:     err,
+		}
+	}
+
+	path := "/nfts/collections/" + collectionUuid + "/" + action
+	res, err := requests.PostReq(ctx, path, strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return MintResponse{}, &NFTError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to %s for collection %s", action, collectionUuid),
+			Err:     err,
+		}
+	}
+
+	var result MintResponse
+	if err := json.Unmarshal([]byte(res), &result); err != nil {
+		return MintResponse{}, &NFTError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to unmarshal %s response for collection %s", action, collectionUuid),
+			Err:     err,
+		}
+	}
+
+	return result, nil
+}
+
+// validateAnyAddress accepts addresses valid on either chain type, for calls where the
+// collection's chain type is not known locally.
+func validateAnyAddress(address string) error {
+	if ValidateAddress(ChainTypeEVM, address) == nil || ValidateAddress(ChainTypeSubstrate, address) == nil {
+		return nil
+	}
+	return invalidInput("invalid address %q", address)
+}
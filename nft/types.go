@@ -0,0 +1,241 @@
+package nft
+
+import "github.com/Apillon/go-sdk/storage"
+
+// ChainType identifies the blockchain family of a collection.
+type ChainType int
+
+const (
+	ChainTypeSubstrate ChainType = 1 // Substrate-based chain
+	ChainTypeEVM       ChainType = 2 // EVM-compatible chain
+)
+
+func (c ChainType) String() string {
+	switch c {
+	case ChainTypeSubstrate:
+		return "substrate"
+	case ChainTypeEVM:
+		return "evm"
+	default:
+		return "unknown"
+	}
+}
+
+// EvmChain is the chain ID of an EVM network supported for NFT collections.
+type EvmChain int
+
+const (
+	EvmChainEthereum        EvmChain = 1
+	EvmChainSepolia         EvmChain = 11155111
+	EvmChainMoonbeam        EvmChain = 1284
+	EvmChainMoonbase        EvmChain = 1287
+	EvmChainAstar           EvmChain = 592
+	EvmChainCelo            EvmChain = 42220
+	EvmChainAlfajores       EvmChain = 44787
+	EvmChainBase            EvmChain = 8453
+	EvmChainBaseSepolia     EvmChain = 84532
+	EvmChainArbitrumOne     EvmChain = 42161
+	EvmChainArbitrumSepolia EvmChain = 421614
+	EvmChainAvalanche       EvmChain = 43114
+	EvmChainAvalancheFuji   EvmChain = 43113
+	EvmChainOptimism        EvmChain = 10
+	EvmChainOptimismSepolia EvmChain = 11155420
+	EvmChainPolygon         EvmChain = 137
+	EvmChainPolygonAmoy     EvmChain = 80002
+)
+
+// SubstrateChain identifies a Substrate network supported for NFT collections.
+type SubstrateChain int
+
+const (
+	SubstrateChainAstar  SubstrateChain = 8
+	SubstrateChainUnique SubstrateChain = 11
+)
+
+// CollectionType is the smart contract flavour of a collection.
+type CollectionType int
+
+const (
+	CollectionTypeGeneric  CollectionType = 1 // Standard collection
+	CollectionTypeNestable CollectionType = 2 // Collection whose NFTs can own other NFTs
+)
+
+// CollectionStatus is the deployment status of a collection.
+type CollectionStatus int
+
+const (
+	CollectionCreated         CollectionStatus = 0
+	CollectionDeployInitiated CollectionStatus = 1
+	CollectionDeploying       CollectionStatus = 2
+	CollectionDeployed        CollectionStatus = 3
+	CollectionTransferred     CollectionStatus = 4
+	CollectionFailed          CollectionStatus = 5
+)
+
+func (s CollectionStatus) String() string {
+	switch s {
+	case CollectionCreated:
+		return "created"
+	case CollectionDeployInitiated:
+		return "deploy initiated"
+	case CollectionDeploying:
+		return "deploying"
+	case CollectionDeployed:
+		return "deployed"
+	case CollectionTransferred:
+		return "transferred"
+	case CollectionFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// TransactionStatus is the on-chain status of a collection transaction.
+type TransactionStatus int
+
+const (
+	TransactionPending   TransactionStatus = 1
+	TransactionConfirmed TransactionStatus = 2
+	TransactionFailed    TransactionStatus = 3
+	TransactionError     TransactionStatus = 4
+)
+
+func (s TransactionStatus) String() string {
+	switch s {
+	case TransactionPending:
+		return "pending"
+	case TransactionConfirmed:
+		return "confirmed"
+	case TransactionFailed:
+		return "failed"
+	case TransactionError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// TransactionType is the kind of operation a collection transaction performs.
+type TransactionType int
+
+const (
+	TransactionDeployContract    TransactionType = 1
+	TransactionTransferOwnership TransactionType = 2
+	TransactionMint              TransactionType = 3
+	TransactionSetBaseURI        TransactionType = 4
+	TransactionBurn              TransactionType = 5
+	TransactionNestMint          TransactionType = 6
+)
+
+// Collection contains information about an NFT collection.
+type Collection struct {
+	storage.Timestamps
+	CollectionUUID        string           `json:"collectionUuid"`        // Unique identifier for the collection
+	CollectionType        CollectionType   `json:"collectionType"`        // Smart contract flavour
+	CollectionStatus      CollectionStatus `json:"collectionStatus"`      // Deployment status
+	ChainType             ChainType        `json:"chainType"`             // Blockchain family
+	Chain                 int              `json:"chain"`                 // EvmChain or SubstrateChain, depending on ChainType
+	Name                  string           `json:"name"`                  // Name of the collection
+	Symbol                string           `json:"symbol"`                // Token symbol
+	Description           string           `json:"description"`           // Description of the collection
+	BaseURI               string           `json:"baseUri"`               // Base URI of the token metadata
+	BaseExtension         string           `json:"baseExtension"`         // Extension appended to token metadata URIs
+	MaxSupply             int              `json:"maxSupply"`             // Maximum number of tokens (0 for unlimited)
+	IsRevokable           bool             `json:"isRevokable"`           // Whether the owner can burn tokens
+	IsSoulbound           bool             `json:"isSoulbound"`           // Whether tokens are non-transferable
+	IsAutoIncrement       bool             `json:"isAutoIncrement"`       // Whether token IDs are assigned automatically
+	RoyaltiesAddress      string           `json:"royaltiesAddress"`      // Address receiving royalties
+	RoyaltiesFees         float64          `json:"royaltiesFees"`         // Royalties in percent
+	Drop                  bool             `json:"drop"`                  // Whether public minting (drop) is enabled
+	DropStart             int64            `json:"dropStart"`             // Unix timestamp when the drop starts
+	DropPrice             float64          `json:"dropPrice"`             // Price per token during the drop
+	DropReserve           int              `json:"dropReserve"`           // Tokens reserved for the owner
+	ContractAddress       string           `json:"contractAddress"`       // Address of the deployed contract
+	DeployerAddress       string           `json:"deployerAddress"`       // Address that deployed the contract
+	TransactionHash       string           `json:"transactionHash"`       // Hash of the deploy transaction
+	BucketUUID            string           `json:"bucketUuid"`            // UUID of the bucket holding the metadata
+	UseApillonIPFSGateway bool             `json:"useApillonIpfsGateway"` // Whether metadata is served through the Apillon gateway
+}
+
+// Transaction contains information about an on-chain collection transaction.
+type Transaction struct {
+	storage.Timestamps
+	TransactionHash   string            `json:"transactionHash"`   // Hash of the transaction
+	TransactionType   TransactionType   `json:"transactionType"`   // Kind of operation
+	TransactionStatus TransactionStatus `json:"transactionStatus"` // On-chain status
+	ChainID           int               `json:"chainId"`           // Chain the transaction was sent to
+}
+
+// MintResult is the result of mint, burn and transfer operations.
+type MintResult struct {
+	Success         bool   `json:"success"`         // Whether the transaction was submitted
+	TransactionHash string `json:"transactionHash"` // Hash of the submitted transaction
+}
+
+// CollectionResponse represents a response containing a single collection.
+type CollectionResponse = storage.APIResponse[Collection]
+
+// ListCollectionsResponse represents a response containing a list of collections.
+type ListCollectionsResponse = storage.APIResponse[storage.ListData[Collection]]
+
+// ListTransactionsResponse represents a response containing a list of collection transactions.
+type ListTransactionsResponse = storage.APIResponse[storage.ListData[Transaction]]
+
+// MintResponse represents a response for mint, burn and transfer operations.
+type MintResponse = storage.APIResponse[MintResult]
+
+// CollectionSettings holds the settings shared by EVM and Substrate collections.
+type CollectionSettings struct {
+	CollectionType        CollectionType `json:"collectionType"`
+	Name                  string         `json:"name"`
+	Symbol                string         `json:"symbol"`
+	Description           string         `json:"description,omitempty"`
+	BaseURI               string         `json:"baseUri"`
+	BaseExtension         string         `json:"baseExtension,omitempty"`
+	MaxSupply             int            `json:"maxSupply"`
+	IsRevokable           bool           `json:"isRevokable"`
+	IsSoulbound           bool           `json:"isSoulbound"`
+	IsAutoIncrement       bool           `json:"isAutoIncrement"`
+	RoyaltiesAddress      string         `json:"royaltiesAddress,omitempty"`
+	RoyaltiesFees         float64        `json:"royaltiesFees"`
+	Drop                  bool           `json:"drop"`
+	DropStart             int64          `json:"dropStart,omitempty"`
+	DropPrice             float64        `json:"dropPrice,omitempty"`
+	DropReserve           int            `json:"dropReserve,omitempty"`
+	UseApillonIPFSGateway bool           `json:"useApillonIpfsGateway"`
+}
+
+// CreateEvmCollectionRequest represents the request body for creating a collection on an EVM chain.
+type CreateEvmCollectionRequest struct {
+	CollectionSettings
+	Chain EvmChain `json:"chain"`
+}
+
+// CreateSubstrateCollectionRequest represents the request body for creating a collection on a Substrate chain.
+type CreateSubstrateCollectionRequest struct {
+	CollectionSettings
+	Chain SubstrateChain `json:"chain"`
+}
+
+// MintRequest represents the request body for minting NFTs.
+type MintRequest struct {
+	ReceivingAddress string `json:"receivingAddress"`
+	Quantity         int    `json:"quantity"`
+	IdsToMint        []int  `json:"idsToMint,omitempty"` // Token IDs to mint, for collections without auto increment
+}
+
+// NestMintRequest represents the request body for minting NFTs nested under a parent NFT.
+type NestMintRequest struct {
+	ParentCollectionUUID string `json:"parentCollectionUuid"`
+	ParentNftID          int    `json:"parentNftId"`
+	Quantity             int    `json:"quantity"`
+}
+
+type burnRequest struct {
+	TokenID int `json:"tokenId"`
+}
+
+type transferRequest struct {
+	Address string `json:"address"`
+}
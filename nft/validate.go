@@ -0,0 +1,92 @@
+package nft
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// evmAddressPattern matches a hex EVM address such as "0x52908400098527886E0F7030069857D2E4169EE7".
+	evmAddressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	// substrateAddressPattern matches the base58 shape of an SS58 address.
+	substrateAddressPattern = regexp.MustCompile(`^[1-9A-HJ-NP-Za-km-z]{46,50}$`)
+)
+
+// ValidateAddress checks that address is well formed for the given chain type.
+// EVM addresses must be 0x-prefixed hex; Substrate addresses must look like SS58 strings.
+func ValidateAddress(chainType ChainType, address string) error {
+	var ok bool
+	switch chainType {
+	case ChainTypeEVM:
+		ok = evmAddressPattern.MatchString(address)
+	case ChainTypeSubstrate:
+		ok = substrateAddressPattern.MatchString(address)
+	default:
+		return invalidInput("unknown chain type %d", chainType)
+	}
+	if !ok {
+		return invalidInput("invalid %s address %q", chainType, address)
+	}
+	return nil
+}
+
+// validate checks the shared collection settings.
+func (s CollectionSettings) validate(chainType ChainType) error {
+	if strings.TrimSpace(s.Name) == "" {
+		return invalidInput("collection name cannot be empty")
+	}
+	if strings.TrimSpace(s.Symbol) == "" {
+		return invalidInput("collection symbol cannot be empty")
+	}
+	if s.CollectionType != CollectionTypeGeneric && s.CollectionType != CollectionTypeNestable {
+		return invalidInput("invalid collection type %d", s.CollectionType)
+	}
+	if s.MaxSupply < 0 {
+		return invalidInput("max supply cannot be negative")
+	}
+	if s.RoyaltiesFees < 0 || s.RoyaltiesFees > 100 {
+		return invalidInput("royalties fees must be between 0 and 100 percent, got %v", s.RoyaltiesFees)
+	}
+	if s.RoyaltiesFees > 0 && s.RoyaltiesAddress == "" {
+		return invalidInput("royalties address is required when royalties fees are set")
+	}
+	if s.RoyaltiesAddress != "" {
+		if err := ValidateAddress(chainType, s.RoyaltiesAddress); err != nil {
+			return err
+		}
+	}
+	if s.Drop {
+		if s.DropPrice < 0 {
+			return invalidInput("drop price cannot be negative")
+		}
+		if s.DropStart <= 0 {
+			return invalidInput("drop start must be a unix timestamp")
+		}
+		if s.DropReserve < 0 || (s.MaxSupply > 0 && s.DropReserve > s.MaxSupply) {
+			return invalidInput("drop reserve must be between 0 and max supply")
+		}
+	}
+	return nil
+}
+
+func (r CreateEvmCollectionRequest) validate() error {
+	if r.Chain <= 0 {
+		return invalidInput("EVM chain must be set")
+	}
+	return r.CollectionSettings.validate(ChainTypeEVM)
+}
+
+func (r CreateSubstrateCollectionRequest) validate() error {
+	if r.Chain != SubstrateChainAstar && r.Chain != SubstrateChainUnique {
+		return invalidInput("unsupported substrate chain %d", r.Chain)
+	}
+	return r.CollectionSettings.validate(ChainTypeSubstrate)
+}
+
+func invalidInput(format string, args ...any) *NFTError {
+	return &NFTError{
+		Code:    ErrCodeInvalidInput,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,235 @@
+package nft
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// defaultPollInterval is the first delay between status checks when WaitOptions.PollInterval is not set.
+	defaultPollInterval = 2 * time.Second
+	// defaultMaxPollInterval caps the backoff when WaitOptions.MaxInterval is not set.
+	defaultMaxPollInterval = 30 * time.Second
+)
+
+// explorerURLs maps chains to the transaction URL prefix of their block explorer.
+var explorerURLs = map[ChainType]map[int]string{
+	ChainTypeEVM: {
+		int(EvmChainEthereum):        "https://etherscan.io/tx/",
+		int(EvmChainSepolia):         "https://sepolia.etherscan.io/tx/",
+		int(EvmChainMoonbeam):        "https://moonbeam.moonscan.io/tx/",
+		int(EvmChainMoonbase):        "https://moonbase.moonscan.io/tx/",
+		int(EvmChainAstar):           "https://astar.blockscout.com/tx/",
+		int(EvmChainCelo):            "https://celoscan.io/tx/",
+		int(EvmChainAlfajores):       "https://alfajores.celoscan.io/tx/",
+		int(EvmChainBase):            "https://basescan.org/tx/",
+		int(EvmChainBaseSepolia):     "https://sepolia.basescan.org/tx/",
+		int(EvmChainArbitrumOne):     "https://arbiscan.io/tx/",
+		int(EvmChainArbitrumSepolia): "https://sepolia.arbiscan.io/tx/",
+		int(EvmChainAvalanche):       "https://snowtrace.io/tx/",
+		int(EvmChainAvalancheFuji):   "https://testnet.snowtrace.io/tx/",
+		int(EvmChainOptimism):        "https://optimistic.etherscan.io/tx/",
+		int(EvmChainOptimismSepolia): "https://sepolia-optimism.etherscan.io/tx/",
+		int(EvmChainPolygon):         "https://polygonscan.com/tx/",
+		int(EvmChainPolygonAmoy):     "https://amoy.polygonscan.com/tx/",
+	},
+	ChainTypeSubstrate: {
+		int(SubstrateChainAstar):  "https://astar.subscan.io/extrinsic/",
+		int(SubstrateChainUnique): "https://unique.subscan.io/extrinsic/",
+	},
+}
+
+// ExplorerURL returns the block explorer link of a transaction, or an empty string
+// if the hash is empty or the chain has no known explorer.
+func ExplorerURL(chainType ChainType, chain int, transactionHash string) string {
+	prefix, ok := explorerURLs[chainType][chain]
+	if !ok || transactionHash == "" {
+		return ""
+	}
+	return prefix + transactionHash
+}
+
+// ExplorerURL returns the block explorer link of a transaction sent to the collection's chain.
+func (c Collection) ExplorerURL(transactionHash string) string {
+	return ExplorerURL(c.ChainType, c.Chain, transactionHash)
+}
+
+// FailedTransactionError is returned when an on-chain transaction fails or is reverted.
+type FailedTransactionError struct {
+	TransactionHash string            // Hash of the failed transaction
+	Status          TransactionStatus // TransactionFailed or TransactionError
+	ExplorerURL     string            // Block explorer link, if the chain is known
+	Message         string            // Description of the failed operation
+}
+
+func (e *FailedTransactionError) Error() string {
+	msg := fmt.Sprintf("nft transaction error: %s: transaction %s %s", e.Message, e.TransactionHash, e.Status)
+	if e.ExplorerURL != "" {
+		msg += " (" + e.ExplorerURL + ")"
+	}
+	return msg
+}
+
+// TransactionEvent reports a status change of a transaction observed by WaitForTransaction.
+type TransactionEvent struct {
+	Transaction Transaction // Latest state of the transaction
+	ExplorerURL string      // Block explorer link, if the chain is known
+}
+
+// CollectionEvent reports a status change of a collection observed by WaitForCollectionDeployed.
+type CollectionEvent struct {
+	Collection  Collection // Latest state of the collection
+	ExplorerURL string     // Block explorer link of the deploy transaction, if known
+}
+
+// WaitOptions configures WaitForTransaction and WaitForCollectionDeployed.
+type WaitOptions struct {
+	// PollInterval is the first delay between status checks; it doubles after every check. Defaults to 2 seconds.
+	PollInterval time.Duration
+	// MaxInterval caps the delay between status checks. Defaults to 30 seconds.
+	MaxInterval time.Duration
+	// OnTransaction, if set, is called when WaitForTransaction observes a new transaction status.
+	OnTransaction func(TransactionEvent)
+	// OnCollection, if set, is called when WaitForCollectionDeployed observes a new collection status.
+	OnCollection func(CollectionEvent)
+}
+
+// WaitForTransaction polls the transactions of a collection until the transaction with the given hash is confirmed.
+// The hash is the one returned by Mint, NestMint, Burn or TransferOwnership. Each check searches the
+// transactions by hash, newest first, and pages through them until the transaction is found.
+// Returns the confirmed transaction, a *FailedTransactionError if it failed or was reverted,
+// or an error if a request fails or ctx is done first.
+func WaitForTransaction(ctx context.Context, collectionUuid string, transactionHash string, opts WaitOptions) (Transaction, error) {
+	if collectionUuid == "" || transactionHash == "" {
+		return Transaction{}, invalidInput("collection UUID and transaction hash cannot be empty")
+	}
+
+	collection, err := GetCollection(ctx, collectionUuid)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	return waitForTransaction(ctx, collection.Data, transactionHash, opts, func(ctx context.Context) ([]Transaction, error) {
+		tx, ok, err := findTransaction(ctx, collectionUuid, transactionHash)
+		if err != nil || !ok {
+			return nil, err
+		}
+		return []Transaction{tx}, nil
+	})
+}
+
+// waitForTransaction implements WaitForTransaction on top of a function listing the collection's transactions.
+func waitForTransaction(ctx context.Context, collection Collection, hash string, opts WaitOptions, list func(context.Context) ([]Transaction, error)) (Transaction, error) {
+	var found Transaction
+	explorerURL := collection.ExplorerURL(hash)
+
+	err := poll(ctx, opts, func() (bool, error) {
+		transactions, err := list(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		for _, tx := range transactions {
+			if tx.TransactionHash != hash {
+				continue
+			}
+			if tx.TransactionStatus != found.TransactionStatus && opts.OnTransaction != nil {
+				opts.OnTransaction(TransactionEvent{Transaction: tx, ExplorerURL: explorerURL})
+			}
+			found = tx
+
+			switch tx.TransactionStatus {
+			case TransactionConfirmed:
+				return true, nil
+			case TransactionFailed, TransactionError:
+				return true, &FailedTransactionError{
+					TransactionHash: hash,
+					Status:          tx.TransactionStatus,
+					ExplorerURL:     explorerURL,
+					Message:         fmt.Sprintf("collection %s", collection.CollectionUUID),
+				}
+			}
+			return false, nil
+		}
+		return false, nil
+	})
+	return found, err
+}
+
+// WaitForCollectionDeployed polls a collection until its contract is deployed.
+// Returns the deployed collection, a *FailedTransactionError if deployment failed,
+// or an error if a request fails or ctx is done first.
+func WaitForCollectionDeployed(ctx context.Context, collectionUuid string, opts WaitOptions) (Collection, error) {
+	if collectionUuid == "" {
+		return Collection{}, invalidInput("collection UUID cannot be empty")
+	}
+
+	return waitForCollectionDeployed(ctx, opts, func(ctx context.Context) (Collection, error) {
+		res, err := GetCollection(ctx, collectionUuid)
+		return res.Data, err
+	})
+}
+
+// waitForCollectionDeployed implements WaitForCollectionDeployed on top of a function fetching the collection.
+func waitForCollectionDeployed(ctx context.Context, opts WaitOptions, get func(context.Context) (Collection, error)) (Collection, error) {
+	var collection Collection
+	last := CollectionStatus(-1)
+
+	err := poll(ctx, opts, func() (bool, error) {
+		var err error
+		collection, err = get(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		explorerURL := collection.ExplorerURL(collection.TransactionHash)
+		if collection.CollectionStatus != last && opts.OnCollection != nil {
+			opts.OnCollection(CollectionEvent{Collection: collection, ExplorerURL: explorerURL})
+		}
+		last = collection.CollectionStatus
+
+		switch collection.CollectionStatus {
+		case CollectionDeployed, CollectionTransferred:
+			return true, nil
+		case CollectionFailed:
+			return true, &FailedTransactionError{
+				TransactionHash: collection.TransactionHash,
+				Status:          TransactionFailed,
+				ExplorerURL:     explorerURL,
+				Message:         fmt.Sprintf("deploy collection %s", collection.CollectionUUID),
+			}
+		}
+		return false, nil
+	})
+
+	return collection, err
+}
+
+// poll calls check until it reports done or returns an error, doubling the delay between calls up to opts.MaxInterval.
+// Returns the context error if ctx is done first.
+func poll(ctx context.Context, opts WaitOptions, check func() (bool, error)) error {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultMaxPollInterval
+	}
+	interval = min(interval, maxInterval)
+
+	for {
+		done, err := check()
+		if done || err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+		interval = min(interval*2, maxInterval)
+	}
+}
@@ -0,0 +1,142 @@
+package nft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Apillon/go-sdk/internal/apitest"
+)
+
+var fastWait = WaitOptions{PollInterval: time.Millisecond, MaxInterval: 2 * time.Millisecond}
+
+func TestExplorerURL(t *testing.T) {
+	if got := ExplorerURL(ChainTypeEVM, int(EvmChainMoonbase), "0xabc"); got != "https://moonbase.moonscan.io/tx/0xabc" {
+		t.Errorf("unexpected Moonbase URL: %s", got)
+	}
+	if got := ExplorerURL(ChainTypeSubstrate, int(SubstrateChainAstar), "0xabc"); got != "https://astar.subscan.io/extrinsic/0xabc" {
+		t.Errorf("unexpected Astar URL: %s", got)
+	}
+	if got := ExplorerURL(ChainTypeEVM, 999999, "0xabc"); got != "" {
+		t.Errorf("expected no URL for unknown chain, got %s", got)
+	}
+	if got := ExplorerURL(ChainTypeEVM, int(EvmChainEthereum), ""); got != "" {
+		t.Errorf("expected no URL for empty hash, got %s", got)
+	}
+}
+
+func TestWaitForTransaction(t *testing.T) {
+	collection := Collection{CollectionUUID: "c1", ChainType: ChainTypeEVM, Chain: int(EvmChainSepolia)}
+	statuses := []TransactionStatus{TransactionPending, TransactionPending, TransactionConfirmed}
+	calls := 0
+	list := func(context.Context) ([]Transaction, error) {
+		status := statuses[min(calls, len(statuses)-1)]
+		calls++
+		return []Transaction{
+			{TransactionHash: "0xother", TransactionStatus: TransactionFailed},
+			{TransactionHash: "0xabc", TransactionStatus: status},
+		}, nil
+	}
+
+	var events []TransactionEvent
+	opts := fastWait
+	opts.OnTransaction = func(e TransactionEvent) { events = append(events, e) }
+
+	tx, err := waitForTransaction(context.Background(), collection, "0xabc", opts, list)
+	if err != nil {
+		t.Fatalf("waitForTransaction failed: %v", err)
+	}
+	if tx.TransactionStatus != TransactionConfirmed || calls != 3 {
+		t.Errorf("expected confirmation after 3 polls, got %v after %d", tx.TransactionStatus, calls)
+	}
+	if len(events) != 2 || events[0].Transaction.TransactionStatus != TransactionPending || events[1].Transaction.TransactionStatus != TransactionConfirmed {
+		t.Errorf("expected pending and confirmed events, got %+v", events)
+	}
+	if events[0].ExplorerURL != "https://sepolia.etherscan.io/tx/0xabc" {
+		t.Errorf("unexpected explorer URL: %s", events[0].ExplorerURL)
+	}
+}
+
+func TestWaitForTransactionPages(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.Reply("GET /nfts/collections/{uuid}", Collection{CollectionUUID: "c1"})
+	// 250 transactions, the one waited for on the last page, as if search and ordering were ignored
+	server.Mux.HandleFunc("GET /nfts/collections/{uuid}/transactions", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var items []Transaction
+		for i := (page - 1) * limit; i < min(page*limit, 250); i++ {
+			items = append(items, Transaction{TransactionHash: fmt.Sprintf("0x%d", i), TransactionStatus: TransactionConfirmed})
+		}
+		apitest.WriteJSON(w, map[string]any{"items": items, "total": 250})
+	})
+
+	tx, err := WaitForTransaction(context.Background(), "c1", "0x249", fastWait)
+	if err != nil || tx.TransactionHash != "0x249" {
+		t.Fatalf("WaitForTransaction = %+v, %v", tx, err)
+	}
+	got := server.Received()
+	if len(got) != 4 || got[1].Query != "desc=true&limit=100&orderBy=createTime&page=1&search=0x249" {
+		t.Errorf("unexpected requests %+v", got)
+	}
+}
+
+func TestWaitForTransactionFailed(t *testing.T) {
+	collection := Collection{CollectionUUID: "c1", ChainType: ChainTypeEVM, Chain: int(EvmChainSepolia)}
+	list := func(context.Context) ([]Transaction, error) {
+		return []Transaction{{TransactionHash: "0xabc", TransactionStatus: TransactionFailed}}, nil
+	}
+
+	_, err := waitForTransaction(context.Background(), collection, "0xabc", fastWait, list)
+	var txErr *FailedTransactionError
+	if !errors.As(err, &txErr) {
+		t.Fatalf("expected FailedTransactionError, got %v", err)
+	}
+	if txErr.TransactionHash != "0xabc" || txErr.Status != TransactionFailed || txErr.ExplorerURL == "" {
+		t.Errorf("unexpected error fields: %+v", txErr)
+	}
+}
+
+func TestWaitForTransactionContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	list := func(context.Context) ([]Transaction, error) { return nil, nil }
+	if _, err := waitForTransaction(ctx, Collection{}, "0xabc", fastWait, list); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestWaitForCollectionDeployed(t *testing.T) {
+	statuses := []CollectionStatus{CollectionDeployInitiated, CollectionDeploying, CollectionDeployed}
+	calls := 0
+	get := func(context.Context) (Collection, error) {
+		status := statuses[min(calls, len(statuses)-1)]
+		calls++
+		return Collection{CollectionUUID: "c1", CollectionStatus: status, TransactionHash: "0xdeploy"}, nil
+	}
+
+	var events []CollectionStatus
+	opts := fastWait
+	opts.OnCollection = func(e CollectionEvent) { events = append(events, e.Collection.CollectionStatus) }
+
+	collection, err := waitForCollectionDeployed(context.Background(), opts, get)
+	if err != nil {
+		t.Fatalf("waitForCollectionDeployed failed: %v", err)
+	}
+	if collection.CollectionStatus != CollectionDeployed || len(events) != 3 {
+		t.Errorf("unexpected result %v with events %v", collection.CollectionStatus, events)
+	}
+
+	failed := func(context.Context) (Collection, error) {
+		return Collection{CollectionUUID: "c1", CollectionStatus: CollectionFailed, TransactionHash: "0xdeploy"}, nil
+	}
+	var txErr *FailedTransactionError
+	if _, err := waitForCollectionDeployed(context.Background(), fastWait, failed); !errors.As(err, &txErr) || txErr.TransactionHash != "0xdeploy" {
+		t.Errorf("expected FailedTransactionError for failed deployment, got %v", err)
+	}
+}
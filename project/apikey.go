@@ -0,0 +1,78 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Apillon/go-sdk/requests"
+)
+
+// ValidateAPIKey checks the API key the requests package resolves through its credential chain and returns its roles.
+// Call it at startup to fail fast on a wrong key instead of on the first request.
+// Returns an APIKeyInfoResponse, or a ProjectError with code 401 if the key is missing or rejected.
+// Other API errors keep their HTTP status as the code (e.g. 429 when rate limited); network failures use 500.
+func ValidateAPIKey(ctx context.Context) (APIKeyInfoResponse, error) {
+	res, err := requests.GetReq(ctx, "/project/api-key/info", nil)
+	if err != nil {
+		code := 500
+		var apiErr *requests.APIError
+		if errors.As(err, &apiErr) {
+			if apiErr.Status == http.StatusUnauthorized || apiErr.Status == http.StatusForbidden {
+				return APIKeyInfoResponse{}, &ProjectError{
+					Code:    http.StatusUnauthorized,
+					Message: "API key is missing or invalid",
+					Err:     err,
+				}
+			}
+			code = apiErr.Status
+		}
+		return APIKeyInfoResponse{}, &ProjectError{
+			Code:    code,
+			Message: "failed to validate API key",
+			Err:     err,
+		}
+	}
+
+	var info APIKeyInfoResponse
+	if err := json.Unmarshal([]byte(res), &info); err != nil {
+		return APIKeyInfoResponse{}, &ProjectError{
+			Code:    500,
+			Message: "failed to unmarshal API key info response",
+			Err:     err,
+		}
+	}
+
+	return info, nil
+}
+
+// RequireRoles validates the API key like ValidateAPIKey and checks that it holds every required permission.
+// Returns the key info, or a ProjectError with code 403 listing the missing permissions.
+func RequireRoles(ctx context.Context, required ...Permission) (APIKeyInfo, error) {
+	info, err := ValidateAPIKey(ctx)
+	if err != nil {
+		return APIKeyInfo{}, err
+	}
+	if err := checkRoles(info.Data, required); err != nil {
+		return info.Data, err
+	}
+	return info.Data, nil
+}
+
+func checkRoles(info APIKeyInfo, required []Permission) error {
+	missing := info.Missing(required...)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(missing))
+	for i, p := range missing {
+		names[i] = p.String()
+	}
+	return &ProjectError{
+		Code:    http.StatusForbidden,
+		Message: "API key is missing permissions: " + strings.Join(names, ", "),
+	}
+}
@@ -0,0 +1,122 @@
+// Package project provides functions to read project-level information from the Apillon API:
+// project details, credit balance and transactions, storage and hosting quota usage, and the
+// roles of the API key in use.
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/Apillon/go-sdk/requests"
+)
+
+// ProjectError represents an error that occurred during project operations
+type ProjectError struct {
+	Code    int
+	Message string
+	Err     error
+}
+
+func (e *ProjectError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("project error (code %d): %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("project error (code %d): %s", e.Code, e.Message)
+}
+
+func (e *ProjectError) Unwrap() error {
+	return e.Err
+}
+
+// Error codes
+const (
+	ErrCodeInvalidInput = 40000001
+)
+
+// GetProject retrieves information about the project the API key belongs to.
+// Returns a ProjectResponse struct or an error if the request or unmarshalling fails.
+func GetProject(ctx context.Context) (ProjectResponse, error) {
+	var project ProjectResponse
+	if err := get(ctx, "/project/info", nil, &project, "get project info"); err != nil {
+		return ProjectResponse{}, err
+	}
+	return project, nil
+}
+
+// GetCredit retrieves the credit balance of the project.
+// Returns a CreditResponse struct or an error if the request or unmarshalling fails.
+func GetCredit(ctx context.Context) (CreditResponse, error) {
+	var credit CreditResponse
+	if err := get(ctx, "/project/credit", nil, &credit, "get credit balance"); err != nil {
+		return CreditResponse{}, err
+	}
+	return credit, nil
+}
+
+// ListCreditTransactions lists the credit transactions of the project, newest first.
+// Returns a ListCreditTransactionsResponse struct or an error if the request or unmarshalling fails.
+func ListCreditTransactions(ctx context.Context, opts CreditTransactionsOptions) (ListCreditTransactionsResponse, error) {
+	if opts.Page < 0 || opts.Limit < 0 {
+		return ListCreditTransactionsResponse{}, &ProjectError{
+			Code:    ErrCodeInvalidInput,
+			Message: "page and limit cannot be negative",
+		}
+	}
+
+	params := map[string]string{}
+	if opts.Page > 0 {
+		params["page"] = strconv.Itoa(opts.Page)
+	}
+	if opts.Limit > 0 {
+		params["limit"] = strconv.Itoa(opts.Limit)
+	}
+
+	var transactions ListCreditTransactionsResponse
+	if err := get(ctx, "/project/credit/transactions", params, &transactions, "list credit transactions"); err != nil {
+		return ListCreditTransactionsResponse{}, err
+	}
+	return transactions, nil
+}
+
+// GetStorageUsage retrieves the storage and bandwidth usage of the project's buckets.
+// Returns a StorageUsageResponse struct or an error if the request or unmarshalling fails.
+func GetStorageUsage(ctx context.Context) (StorageUsageResponse, error) {
+	var usage StorageUsageResponse
+	if err := get(ctx, "/storage/info", nil, &usage, "get storage usage"); err != nil {
+		return StorageUsageResponse{}, err
+	}
+	return usage, nil
+}
+
+// GetHostingUsage retrieves the website count and the storage and bandwidth usage of the project's websites.
+// Returns a HostingUsageResponse struct or an error if the request or unmarshalling fails.
+func GetHostingUsage(ctx context.Context) (HostingUsageResponse, error) {
+	var usage HostingUsageResponse
+	if err := get(ctx, "/hosting/info", nil, &usage, "get hosting usage"); err != nil {
+		return HostingUsageResponse{}, err
+	}
+	return usage, nil
+}
+
+// get sends a GET request and decodes the response into out; operation names the call for error messages.
+func get(ctx context.Context, path string, params map[string]string, out any, operation string) error {
+	res, err := requests.GetReq(ctx, path, params)
+	if err != nil {
+		return &ProjectError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to %s", operation),
+			Err:     err,
+		}
+	}
+
+	if err := json.Unmarshal([]byte(res), out); err != nil {
+		return &ProjectError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to unmarshal %s response", operation),
+			Err:     err,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Apillon/go-sdk/internal/apitest"
+)
+
+func TestProjectRequests(t *testing.T) {
+	ctx := context.Background()
+	server := apitest.NewServer(t)
+	server.Reply("GET /project/info", Project{ProjectUUID: "p-1", Name: "Demo"})
+	server.Reply("GET /project/credit", Credit{Balance: 40, Threshold: 50})
+	server.Reply("GET /storage/info", StorageUsage{UsedStorage: 750, AvailableStorage: 1000})
+	server.Reply("GET /hosting/info", HostingUsage{Websites: 3, MaxWebsites: 3})
+
+	project, err := GetProject(ctx)
+	if err != nil || project.Data.Name != "Demo" {
+		t.Fatalf("GetProject = %+v, %v", project.Data, err)
+	}
+	credit, err := GetCredit(ctx)
+	if err != nil || !credit.Data.Low() {
+		t.Fatalf("GetCredit = %+v, %v; expected a low balance", credit.Data, err)
+	}
+	storageUsage, err := GetStorageUsage(ctx)
+	if err != nil || storageUsage.Data.Storage().Fraction() != 0.75 {
+		t.Fatalf("GetStorageUsage = %+v, %v", storageUsage.Data, err)
+	}
+	hosting, err := GetHostingUsage(ctx)
+	if err != nil || hosting.Data.Websites != hosting.Data.MaxWebsites {
+		t.Fatalf("GetHostingUsage = %+v, %v", hosting.Data, err)
+	}
+
+	want := []string{"GET /project/info", "GET /project/credit", "GET /storage/info", "GET /hosting/info"}
+	if got := server.Requests(); !slices.Equal(got, want) {
+		t.Errorf("requests %q, want %q", got, want)
+	}
+}
+
+func TestListCreditTransactions(t *testing.T) {
+	ctx := context.Background()
+	server := apitest.NewServer(t)
+	server.Reply("GET /project/credit/transactions", map[string]any{"items": []CreditTransaction{{ID: 9, Amount: 5}}, "total": 1})
+
+	res, err := ListCreditTransactions(ctx, CreditTransactionsOptions{Page: 2, Limit: 20})
+	if err != nil || res.Data.Items[0].ID != 9 {
+		t.Fatalf("ListCreditTransactions = %+v, %v", res.Data, err)
+	}
+	if _, err := ListCreditTransactions(ctx, CreditTransactionsOptions{}); err != nil {
+		t.Fatalf("ListCreditTransactions with defaults failed: %v", err)
+	}
+	_, err = ListCreditTransactions(ctx, CreditTransactionsOptions{Limit: -1})
+	var projectErr *ProjectError
+	if !errors.As(err, &projectErr) || projectErr.Code != ErrCodeInvalidInput {
+		t.Errorf("expected ErrCodeInvalidInput, got %v", err)
+	}
+
+	got := server.Received()
+	if len(got) != 2 || got[0].Query != "limit=20&page=2" || got[1].Query != "" {
+		t.Errorf("unexpected requests %+v", got)
+	}
+}
+
+func TestValidateAPIKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int // Status the fake API answers with
+		wantCode int // Expected ProjectError code, 0 on success
+	}{
+		{"valid key", http.StatusOK, 0},
+		{"rejected key", http.StatusUnauthorized, http.StatusUnauthorized},
+		{"forbidden key", http.StatusForbidden, http.StatusUnauthorized},
+		{"rate limited", http.StatusTooManyRequests, http.StatusTooManyRequests},
+		{"failing API", http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := apitest.NewServer(t)
+			if tt.status == http.StatusOK {
+				server.Reply("GET /project/api-key/info", APIKeyInfo{APIKey: "key", Roles: []Permission{{ServiceStorage, RoleRead}}})
+			} else {
+				server.ReplyError("GET /project/api-key/info", tt.status, 40100000, http.StatusText(tt.status))
+			}
+
+			res, err := ValidateAPIKey(context.Background())
+			var projectErr *ProjectError
+			switch {
+			case tt.wantCode == 0 && (err != nil || res.Data.APIKey != "key"):
+				t.Errorf("ValidateAPIKey = %+v, %v", res.Data, err)
+			case tt.wantCode != 0 && (!errors.As(err, &projectErr) || projectErr.Code != tt.wantCode):
+				t.Errorf("expected error code %d, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestRequireRoles(t *testing.T) {
+	ctx := context.Background()
+	server := apitest.NewServer(t)
+	server.Reply("GET /project/api-key/info", APIKeyInfo{APIKey: "key", Roles: []Permission{
+		{ServiceStorage, RoleRead}, {ServiceStorage, RoleWrite},
+	}})
+
+	info, err := RequireRoles(ctx, Permission{ServiceStorage, RoleWrite})
+	if err != nil || len(info.Roles) != 2 {
+		t.Fatalf("RequireRoles = %+v, %v", info, err)
+	}
+
+	_, err = RequireRoles(ctx, Permission{ServiceStorage, RoleRead}, Permission{ServiceHosting, RoleWrite})
+	var projectErr *ProjectError
+	if !errors.As(err, &projectErr) || projectErr.Code != http.StatusForbidden || !strings.HasSuffix(projectErr.Message, ": hosting:write") {
+		t.Errorf("expected the missing hosting permission, got %v", err)
+	}
+}
+
+func TestUnmarshalUsage(t *testing.T) {
+	body := `{"id":"1","status":200,"data":{"usedStorage":750,"availableStorage":1000,"usedBandwidth":10,"availableBandwidth":0}}`
+
+	var res StorageUsageResponse
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if got := res.Data.Storage().Fraction(); got != 0.75 {
+		t.Errorf("expected storage fraction 0.75, got %v", got)
+	}
+	if res.Data.Storage().Exceeded() || res.Data.Bandwidth().Exceeded() {
+		t.Error("expected quotas not to be exceeded")
+	}
+}
+
+func TestCreditLow(t *testing.T) {
+	if (Credit{Balance: 100, Threshold: 50}).Low() {
+		t.Error("expected balance above threshold not to be low")
+	}
+	if !(Credit{Balance: 50, Threshold: 50}).Low() {
+		t.Error("expected balance at threshold to be low")
+	}
+}
+
+func TestCheckRoles(t *testing.T) {
+	body := `{"apiKey":"key","roles":[{"serviceType":"storage","role":"read"},{"serviceType":"STORAGE","role":"WRITE"},{"serviceType":"hosting","role":"execute"}]}`
+
+	var info APIKeyInfo
+	if err := json.Unmarshal([]byte(body), &info); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	if err := checkRoles(info, []Permission{{ServiceStorage, RoleRead}, {ServiceStorage, RoleWrite}}); err != nil {
+		t.Errorf("expected permissions to be held, got %v", err)
+	}
+
+	err := checkRoles(info, []Permission{{ServiceHosting, RoleExecute}, {ServiceNFT, RoleWrite}, {ServiceRPC, RoleRead}})
+	var projectErr *ProjectError
+	if !errors.As(err, &projectErr) || projectErr.Code != http.StatusForbidden {
+		t.Fatalf("expected forbidden error, got %v", err)
+	}
+	if !strings.Contains(projectErr.Message, "nft:write, rpc:read") || strings.Contains(projectErr.Message, "hosting") {
+		t.Errorf("unexpected missing permissions %q", projectErr.Message)
+	}
+}
@@ -0,0 +1,202 @@
+package project
+
+import (
+	"strings"
+
+	"github.com/Apillon/go-sdk/storage"
+)
+
+// CreditDirection tells whether a credit transaction added or spent credits.
+type CreditDirection int
+
+const (
+	CreditReceived CreditDirection = 1 // Credits were added, e.g. by a purchase or subscription
+	CreditSpent    CreditDirection = 2 // Credits were spent on a service
+)
+
+func (d CreditDirection) String() string {
+	switch d {
+	case CreditReceived:
+		return "received"
+	case CreditSpent:
+		return "spent"
+	default:
+		return "unknown"
+	}
+}
+
+// Service is an Apillon service an API key can be granted access to.
+type Service string
+
+const (
+	ServiceAuthentication Service = "authentication"
+	ServiceStorage        Service = "storage"
+	ServiceHosting        Service = "hosting"
+	ServiceNFT            Service = "nft"
+	ServiceComputing      Service = "computing"
+	ServiceCloudFunctions Service = "cloud-functions"
+	ServiceSocial         Service = "social"
+	ServiceRPC            Service = "rpc"
+	ServiceIndexing       Service = "indexing"
+)
+
+// Role is a permission an API key holds on a service.
+type Role string
+
+const (
+	RoleRead    Role = "read"
+	RoleWrite   Role = "write"
+	RoleExecute Role = "execute"
+)
+
+// Project contains information about the project the API key belongs to.
+type Project struct {
+	storage.Timestamps
+	ProjectUUID string `json:"projectUuid"` // Unique identifier for the project
+	Name        string `json:"name"`        // Name of the project
+	Description string `json:"description"` // Description of the project
+}
+
+// Credit contains the credit balance of a project.
+type Credit struct {
+	Balance   int `json:"balance"`   // Available credits
+	Threshold int `json:"threshold"` // Balance below which the project owner is notified
+}
+
+// Low reports whether the balance dropped to or below the notification threshold.
+func (c Credit) Low() bool {
+	return c.Balance <= c.Threshold
+}
+
+// CreditTransaction is a single change of the project's credit balance.
+type CreditTransaction struct {
+	storage.Timestamps
+	ID             int             `json:"id"`             // ID of the transaction
+	Direction      CreditDirection `json:"direction"`      // Whether credits were received or spent
+	Amount         int             `json:"amount"`         // Number of credits
+	Category       string          `json:"category"`       // Product or action the credits were spent on
+	Service        string          `json:"service"`        // Service the credits were spent on
+	ReferenceTable string          `json:"referenceTable"` // Kind of object the transaction refers to
+	ReferenceID    string          `json:"referenceId"`    // ID of the object the transaction refers to
+}
+
+// CreditTransactionsOptions pages the transactions returned by ListCreditTransactions. Zero values are not sent.
+type CreditTransactionsOptions struct {
+	Page  int // Page number, starting at 1
+	Limit int // Transactions per page
+}
+
+// Quota is the used and available amount of a metered resource.
+type Quota struct {
+	Used      storage.ByteSize
+	Available storage.ByteSize
+}
+
+// Fraction returns the used fraction of the quota, 0 if nothing is available.
+func (q Quota) Fraction() float64 {
+	if q.Available <= 0 {
+		return 0
+	}
+	return float64(q.Used) / float64(q.Available)
+}
+
+// Exceeded reports whether the used amount reached the available amount.
+func (q Quota) Exceeded() bool {
+	return q.Available > 0 && q.Used >= q.Available
+}
+
+// StorageUsage contains the storage and bandwidth usage of the project's buckets.
+type StorageUsage struct {
+	UsedStorage        storage.ByteSize `json:"usedStorage"`        // Bytes stored
+	AvailableStorage   storage.ByteSize `json:"availableStorage"`   // Storage quota in bytes
+	UsedBandwidth      storage.ByteSize `json:"usedBandwidth"`      // Bytes served this month
+	AvailableBandwidth storage.ByteSize `json:"availableBandwidth"` // Monthly bandwidth quota in bytes
+}
+
+// Storage returns the storage quota.
+func (u StorageUsage) Storage() Quota {
+	return Quota{Used: u.UsedStorage, Available: u.AvailableStorage}
+}
+
+// Bandwidth returns the bandwidth quota.
+func (u StorageUsage) Bandwidth() Quota {
+	return Quota{Used: u.UsedBandwidth, Available: u.AvailableBandwidth}
+}
+
+// HostingUsage contains the website count and the storage and bandwidth usage of the project's websites.
+type HostingUsage struct {
+	Websites           int              `json:"websites"`           // Number of websites
+	MaxWebsites        int              `json:"maxWebsites"`        // Maximum number of websites
+	UsedStorage        storage.ByteSize `json:"usedStorage"`        // Bytes stored
+	AvailableStorage   storage.ByteSize `json:"availableStorage"`   // Storage quota in bytes
+	UsedBandwidth      storage.ByteSize `json:"usedBandwidth"`      // Bytes served this month
+	AvailableBandwidth storage.ByteSize `json:"availableBandwidth"` // Monthly bandwidth quota in bytes
+}
+
+// Storage returns the storage quota.
+func (u HostingUsage) Storage() Quota {
+	return Quota{Used: u.UsedStorage, Available: u.AvailableStorage}
+}
+
+// Bandwidth returns the bandwidth quota.
+func (u HostingUsage) Bandwidth() Quota {
+	return Quota{Used: u.UsedBandwidth, Available: u.AvailableBandwidth}
+}
+
+// Permission is a role held on a service.
+type Permission struct {
+	Service Service `json:"serviceType"` // Service the role applies to
+	Role    Role    `json:"role"`        // Role held on the service
+}
+
+func (p Permission) String() string {
+	return string(p.Service) + ":" + string(p.Role)
+}
+
+// APIKeyInfo describes the API key used for requests.
+type APIKeyInfo struct {
+	APIKey      string       `json:"apiKey"`      // Public part of the API key
+	Name        string       `json:"name"`        // Name of the API key
+	ProjectUUID string       `json:"projectUuid"` // Project the key belongs to
+	TestNetwork bool         `json:"testNetwork"` // Whether the key is restricted to test networks
+	Roles       []Permission `json:"roles"`       // Roles granted to the key
+}
+
+// Has reports whether the key holds role on service.
+func (k APIKeyInfo) Has(service Service, role Role) bool {
+	for _, p := range k.Roles {
+		if strings.EqualFold(string(p.Service), string(service)) && strings.EqualFold(string(p.Role), string(role)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Missing returns the permissions of required the key does not hold.
+func (k APIKeyInfo) Missing(required ...Permission) []Permission {
+	var missing []Permission
+	for _, p := range required {
+		if !k.Has(p.Service, p.Role) {
+			missing = append(missing, p)
+		}
+	}
+	return missing
+}
+
+// ProjectResponse represents a response containing project information.
+type ProjectResponse = storage.APIResponse[Project]
+
+// CreditResponse represents a response containing the credit balance.
+type CreditResponse = storage.APIResponse[Credit]
+
+// ListCreditTransactionsResponse represents a response containing a list of credit transactions.
+type ListCreditTransactionsResponse = storage.APIResponse[storage.ListData[CreditTransaction]]
+
+// StorageUsageResponse represents a response containing storage usage.
+type StorageUsageResponse = storage.APIResponse[StorageUsage]
+
+// HostingUsageResponse represents a response containing hosting usage.
+type HostingUsageResponse = storage.APIResponse[HostingUsage]
+
+// APIKeyInfoResponse represents a response describing the current API key.
+type APIKeyInfoResponse = storage.APIResponse[APIKeyInfo]
@@ -0,0 +1,81 @@
+package requests
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// Operations reported to an Instrumenter.
+const (
+	OpRequest            = "apillon.request"              // An Apillon API request, including its retries
+	OpUpload             = "apillon.upload"               // A whole upload session, e.g. storage.UploadFileProcess
+	OpUploadStartSession = "apillon.upload.start_session" // Starting an upload session and getting signed URLs
+	OpUploadFile         = "apillon.upload.put_file"      // Uploading one file to its signed URL
+	OpUploadEndSession   = "apillon.upload.end_session"   // Ending an upload session
+)
+
+// Operation describes an instrumented operation.
+type Operation struct {
+	Name   string // One of the Op* constants
+	Method string // HTTP method, for requests and file uploads
+	Path   string // API path, or the signed URL without its query for file uploads
+	Files  int    // Number of files, for upload sessions
+	Bytes  int64  // Bytes sent, for file uploads
+}
+
+// Outcome describes how an instrumented operation ended.
+type Outcome struct {
+	Status   int   // HTTP status code, 0 if no response was received
+	Attempts int   // Attempts made, for requests; more than one means the request was retried
+	Err      error // The error the operation failed with, if any
+}
+
+// Instrumenter observes API requests and upload phases, e.g. to record traces and metrics.
+// See the telemetry package for an OpenTelemetry implementation.
+type Instrumenter interface {
+	// Start is called when an operation starts. The returned context is used for the operation,
+	// including nested operations, and passed to End.
+	Start(ctx context.Context, op Operation) context.Context
+	// End is called once when the operation ends.
+	End(ctx context.Context, op Operation, out Outcome)
+	// Inject adds the trace context of ctx to the headers of an outgoing API request.
+	Inject(ctx context.Context, header http.Header)
+}
+
+var (
+	instrumentMu sync.RWMutex
+	instrumenter Instrumenter
+)
+
+// SetInstrumenter sets the instrumenter notified of every operation. nil, the default, disables instrumentation.
+func SetInstrumenter(i Instrumenter) {
+	instrumentMu.Lock()
+	defer instrumentMu.Unlock()
+	instrumenter = i
+}
+
+// StartOperation starts an operation with the instrumenter set with SetInstrumenter.
+// It returns the context to run the operation with and a function to call with its outcome;
+// without an instrumenter these are ctx and a no-op.
+func StartOperation(ctx context.Context, op Operation) (context.Context, func(Outcome)) {
+	instrumentMu.RLock()
+	i := instrumenter
+	instrumentMu.RUnlock()
+	if i == nil {
+		return ctx, func(Outcome) {}
+	}
+
+	ctx = i.Start(ctx, op)
+	return ctx, func(out Outcome) { i.End(ctx, op, out) }
+}
+
+// injectTraceContext adds the trace context of ctx to header if an instrumenter is set.
+func injectTraceContext(ctx context.Context, header http.Header) {
+	instrumentMu.RLock()
+	i := instrumenter
+	instrumentMu.RUnlock()
+	if i != nil {
+		i.Inject(ctx, header)
+	}
+}
@@ -0,0 +1,125 @@
+package requests
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/Apillon/go-sdk/credentials"
+)
+
+// maxLoggedBody is the number of body bytes dumped in debug mode.
+const maxLoggedBody = 4096
+
+var (
+	loggingMu sync.RWMutex
+	logger    *slog.Logger
+	debug     bool
+)
+
+// sensitiveKeys are JSON keys whose values are redacted from dumped bodies. Keys are matched
+// case-insensitively, ignoring "_" and "-", so "apikey" also matches "API_KEY".
+var sensitiveKeys = []string{"token", "secret", "password", "apikey", "privatekey", "mnemonic", "authorization"}
+
+// SetLogger sets the logger that receives an event for every request attempt (method, path, status,
+// duration, attempt and request ID) and for retries. A nil logger, the default, disables logging.
+//
+// Other SDK packages log through the same logger, e.g. the signed URL uploads and session
+// timing of storage.UploadFileProcess.
+func SetLogger(l *slog.Logger) {
+	loggingMu.Lock()
+	defer loggingMu.Unlock()
+	logger = l
+}
+
+// SetDebug enables dumping request and response bodies at debug level. Credentials, values of
+// sensitive JSON keys such as tokens and passwords, and values of key/value pairs with such a key
+// (e.g. environment variables) are redacted, and bodies are truncated.
+// It has no effect without a logger, or if the logger does not enable the debug level.
+func SetDebug(enabled bool) {
+	loggingMu.Lock()
+	defer loggingMu.Unlock()
+	debug = enabled
+}
+
+// Logger returns the logger set with SetLogger, or nil if logging is disabled.
+func Logger() *slog.Logger {
+	loggingMu.RLock()
+	defer loggingMu.RUnlock()
+	return logger
+}
+
+// debugLogger returns the logger if debug mode is on and the logger enables the debug level.
+func debugLogger(ctx context.Context) *slog.Logger {
+	loggingMu.RLock()
+	defer loggingMu.RUnlock()
+	if !debug || logger == nil || !logger.Enabled(ctx, slog.LevelDebug) {
+		return nil
+	}
+	return logger
+}
+
+// newRequestID returns a random ID that correlates the attempts of a request in logs.
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// RedactBody returns body for logging, with credentials and sensitive JSON values redacted and
+// truncated to a few KiB.
+func RedactBody(creds credentials.Credentials, body []byte) string {
+	var v any
+	if err := json.Unmarshal(body, &v); err == nil {
+		if redacted, err := json.Marshal(redactJSON(v)); err == nil {
+			body = redacted
+		}
+	}
+
+	text := creds.Redact(string(body))
+	if len(text) > maxLoggedBody {
+		text = text[:maxLoggedBody] + "...(truncated)"
+	}
+	return text
+}
+
+// redactJSON replaces the values of sensitive keys in a decoded JSON value, including the value of
+// key/value pairs such as {"key":"DB_PASSWORD","value":"..."} whose key names a sensitive variable.
+func redactJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		if name, ok := v["key"].(string); ok && sensitive(name) {
+			if _, ok := v["value"]; ok {
+				v["value"] = credentials.Redacted
+			}
+		}
+		for key, value := range v {
+			if sensitive(key) {
+				v[key] = credentials.Redacted
+			} else {
+				v[key] = redactJSON(value)
+			}
+		}
+	case []any:
+		for i := range v {
+			v[i] = redactJSON(v[i])
+		}
+	}
+	return v
+}
+
+func sensitive(key string) bool {
+	key = strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
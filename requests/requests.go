@@ -1,31 +1,50 @@
 // Package requests provides helper functions for making authenticated HTTP requests
-// to the Apillon API. It supports GET, POST, and DELETE methods, and manages API key authentication.
+// to the Apillon API. It supports GET, POST, PATCH, and DELETE methods, and manages API key authentication.
 package requests
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
-	"os"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/Apillon/go-sdk/credentials"
 )
 
 const (
-	baseURL     = "https://api.apillon.io"
-	maxRetries  = 3
-	retryDelay  = time.Second
-	timeoutGet  = 30 * time.Second
-	timeoutPost = 60 * time.Second
+	defaultBaseURL = "https://api.apillon.io"
+	maxRetries     = 3
+	retryDelay     = time.Second
+	timeoutGet     = 30 * time.Second
+	timeoutPost    = 60 * time.Second
 )
 
-var apiKey string
+var (
+	credentialsMu sync.RWMutex
+	explicit      credentials.Credentials
+	profile       string
+	provider      credentials.Provider
+
+	// resolved caches the credentials found by ResolveCredentials; generation counts the
+	// changes made by the Set functions so a resolve racing with one is not cached.
+	resolved   *credentials.Credentials
+	generation int
+)
 
-// APIError represents an error response from the Apillon API
+// APIError represents an error response from the Apillon API. Responses with an error status
+// and a body that is not an API error, e.g. from a gateway, are returned as an APIError too,
+// with the HTTP status and the body as the message.
 type APIError struct {
 	Status  int    `json:"status"`
+	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
 
@@ -34,24 +53,107 @@ func (e *APIError) Error() string {
 }
 
 // SetAPIKey sets the API key to be used for authentication in all requests.
+// The key is either combined with the secret set by SetAPISecret, given as "key:secret", or a
+// pre-encoded Basic auth token (base64 of "key:secret"), which is sent as is.
 //
-// If not set, the package will attempt to read the API key from the APILLON_API_KEY environment variable.
+// If not set, the package resolves credentials through the provider chain described in the
+// credentials package: the APILLON_API_KEY environment variable, then the config file profile,
+// then the provider set with SetCredentialProvider.
 func SetAPIKey(key string) {
-	apiKey = key
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+	explicit.APIKey = key
+	resetCredentials()
+}
+
+// SetAPISecret sets the API secret paired with the API key set by SetAPIKey.
+// The Authorization header is then built from the base64 encoding of "key:secret".
+func SetAPISecret(secret string) {
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+	explicit.APISecret = secret
+	resetCredentials()
+}
+
+// SetCredentials sets explicit credentials, including the optional secret, base URL and default bucket.
+// They take precedence over every other source while they contain an API key.
+func SetCredentials(creds credentials.Credentials) {
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+	explicit = creds
+	resetCredentials()
 }
 
-// getAPIKey retrieves the API key for authentication.
-// It returns the key set by SetAPIKey, or falls back to the APILLON_API_KEY environment variable.
-func getAPIKey() string {
-	if apiKey != "" {
-		return apiKey
+// SetProfile selects the config file profile read when no explicit credentials or environment variables are set.
+// An empty name restores the default (APILLON_PROFILE, then "default").
+func SetProfile(name string) {
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+	profile = name
+	resetCredentials()
+}
+
+// SetCredentialProvider sets a custom provider consulted after every other source.
+// Its credentials are cached like those of any other source; call SetCredentialProvider
+// again to make the next request fetch them anew, e.g. after rotating a key.
+func SetCredentialProvider(p credentials.Provider) {
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+	provider = p
+	resetCredentials()
+}
+
+// ResolveCredentials resolves the credentials requests are sent with and reports their source.
+// The first successful resolve is cached, so the config file and custom provider are not read
+// for every request; SetAPIKey, SetAPISecret, SetCredentials, SetProfile and SetCredentialProvider
+// clear the cache. Failed resolves are not cached.
+// Returns credentials.ErrNoCredentials if no source provides an API key.
+func ResolveCredentials() (credentials.Credentials, error) {
+	credentialsMu.RLock()
+	if resolved != nil {
+		creds := *resolved
+		credentialsMu.RUnlock()
+		return creds, nil
+	}
+	chain := credentials.Chain{Explicit: explicit, Profile: profile, Custom: provider}
+	gen := generation
+	credentialsMu.RUnlock()
+
+	creds, err := chain.Resolve()
+	if err != nil {
+		return creds, err
 	}
-	return os.Getenv("APILLON_API_KEY")
+
+	credentialsMu.Lock()
+	if gen == generation {
+		resolved = &creds
+	}
+	credentialsMu.Unlock()
+	return creds, nil
+}
+
+// resetCredentials clears the cached credentials. The caller must hold credentialsMu.
+func resetCredentials() {
+	resolved = nil
+	generation++
+}
+
+// getAPIKey retrieves the API key for authentication through ResolveCredentials.
+// Returns an error if no source provides an API key or a source fails.
+func getAPIKey() (string, error) {
+	creds, err := ResolveCredentials()
+	if err != nil {
+		return "", err
+	}
+	return creds.APIKey, nil
 }
 
 // buildURL constructs a URL with query parameters
-func buildURL(path string, params map[string]string) (string, error) {
-	base, err := url.Parse(baseURL + path)
+func buildURL(baseURL string, path string, params map[string]string) (string, error) {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	base, err := url.Parse(strings.TrimSuffix(baseURL, "/") + path)
 	if err != nil {
 		return "", fmt.Errorf("invalid base URL: %w", err)
 	}
@@ -68,47 +170,119 @@ func buildURL(path string, params map[string]string) (string, error) {
 }
 
 // doRequest performs an HTTP request with retries and proper error handling
-func doRequest(ctx context.Context, method, path string, body io.Reader, params map[string]string, timeout time.Duration) (string, error) {
-	url, err := buildURL(path, params)
+func doRequest(ctx context.Context, method, path string, body io.Reader, params map[string]string, timeout time.Duration) (_ string, err error) {
+	// Without any credentials the request is still sent, and the API answers with 401.
+	creds, err := ResolveCredentials()
+	if err != nil && !errors.Is(err, credentials.ErrNoCredentials) {
+		return "", err
+	}
+
+	url, err := buildURL(creds.BaseURL, path, params)
 	if err != nil {
 		return "", err
 	}
 
+	// Buffer the body so every attempt sends it in full
+	var payload []byte
+	if body != nil {
+		if payload, err = io.ReadAll(body); err != nil {
+			return "", fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	var status, attempts int
+	ctx, end := StartOperation(ctx, Operation{Name: OpRequest, Method: method, Path: path})
+	defer func() { end(Outcome{Status: status, Attempts: attempts, Err: err}) }()
+
+	log := Logger()
+	debugLog := debugLogger(ctx)
+	var requestID string
+	if log != nil {
+		requestID = newRequestID()
+	}
+	if debugLog != nil && payload != nil {
+		debugLog.LogAttrs(ctx, slog.LevelDebug, "apillon request body",
+			slog.String("method", method), slog.String("path", path), slog.String("requestId", requestID),
+			slog.String("body", RedactBody(creds, payload)))
+	}
+
 	var lastErr error
 	for attempt := 0; attempt < maxRetries; attempt++ {
-		req, err := http.NewRequestWithContext(ctx, method, url, body)
+		attempts = attempt + 1
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 		if err != nil {
 			return "", fmt.Errorf("failed to create request: %w", err)
 		}
 
-		req.Header.Set("Authorization", "Basic "+getAPIKey())
-		if method == "POST" {
+		if auth := creds.Authorization(); auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		if method == "POST" || method == "PATCH" {
 			req.Header.Set("Content-Type", "application/json")
 		}
+		if requestID != "" {
+			req.Header.Set("X-Request-Id", requestID)
+		}
+		injectTraceContext(ctx, req.Header)
 
 		client := &http.Client{
 			Timeout: timeout,
 		}
 
+		start := time.Now()
 		resp, err := client.Do(req)
 		if err != nil {
 			lastErr = err
+			if log != nil {
+				log.LogAttrs(ctx, slog.LevelWarn, "apillon request failed",
+					slog.String("method", method), slog.String("path", path), slog.Duration("duration", time.Since(start)),
+					slog.Int("attempt", attempt+1), slog.String("requestId", requestID), slog.String("error", err.Error()),
+					slog.Bool("retry", attempt+1 < maxRetries))
+			}
 			time.Sleep(retryDelay * time.Duration(attempt+1))
 			continue
 		}
 
 		defer resp.Body.Close()
+		status = resp.StatusCode
 
 		responseBody, err := io.ReadAll(resp.Body)
 		if err != nil {
 			return "", fmt.Errorf("failed to read response body: %w", err)
 		}
 
+		if log != nil {
+			level := slog.LevelInfo
+			if resp.StatusCode >= 400 {
+				level = slog.LevelWarn
+			}
+			log.LogAttrs(ctx, level, "apillon request",
+				slog.String("method", method), slog.String("path", path), slog.Int("status", resp.StatusCode),
+				slog.Duration("duration", time.Since(start)), slog.Int("attempt", attempt+1), slog.String("requestId", requestID))
+		}
+		if debugLog != nil {
+			debugLog.LogAttrs(ctx, slog.LevelDebug, "apillon response body",
+				slog.String("method", method), slog.String("path", path), slog.String("requestId", requestID),
+				slog.String("body", RedactBody(creds, responseBody)))
+		}
+
 		if resp.StatusCode >= 400 {
+			// Gateways and proxies may answer with plain text or HTML, which is kept as the message
 			var apiErr APIError
 			if err := json.Unmarshal(responseBody, &apiErr); err != nil {
-				return "", fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(responseBody))
+				apiErr = APIError{Message: strings.TrimSpace(string(responseBody))}
+			}
+			if apiErr.Status == 0 {
+				apiErr.Status = resp.StatusCode
 			}
+			if apiErr.Message == "" {
+				apiErr.Message = http.StatusText(resp.StatusCode)
+			}
+			apiErr.Message = creds.Redact(apiErr.Message)
 			return "", &apiErr
 		}
 
@@ -146,6 +320,20 @@ func PostReq(ctx context.Context, path string, body io.Reader) (string, error) {
 	return doRequest(ctx, "POST", path, body, nil, timeoutPost)
 }
 
+// PatchReq sends an authenticated HTTP PATCH request to the Apillon API.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout
+//   - path: The API endpoint path (e.g., "/hosting/websites/{uuid}").
+//   - body: The request body as an io.Reader (should be JSON).
+//
+// Returns:
+//   - string: The response body as a string.
+//   - error: An error if the request fails or the response cannot be read.
+func PatchReq(ctx context.Context, path string, body io.Reader) (string, error) {
+	return doRequest(ctx, "PATCH", path, body, nil, timeoutPost)
+}
+
 // DeleteReq sends an authenticated HTTP DELETE request to the Apillon API.
 //
 // Parameters:
@@ -1,9 +1,26 @@
 package requests
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Apillon/go-sdk/credentials"
+)
 
 func TestCheckAPIKey(t *testing.T) {
-	apiKey := getAPIKey()
+	apiKey, err := getAPIKey()
+	if err != nil {
+		t.Fatalf("resolving API key failed: %v", err)
+	}
 
 	t.Logf("API key: %s", apiKey)
 
@@ -11,3 +28,136 @@ func TestCheckAPIKey(t *testing.T) {
 		t.Errorf("API key not set correctly")
 	}
 }
+
+func TestBuildURL(t *testing.T) {
+	got, err := buildURL("", "/storage/buckets", map[string]string{"search": "a b"})
+	if err != nil || got != "https://api.apillon.io/storage/buckets?search=a+b" {
+		t.Errorf("buildURL = %q, %v", got, err)
+	}
+	got, err = buildURL("https://api.example.com/", "/project/info", nil)
+	if err != nil || got != "https://api.example.com/project/info" {
+		t.Errorf("buildURL = %q, %v", got, err)
+	}
+}
+
+func TestResolveCredentialsCache(t *testing.T) {
+	t.Setenv(credentials.EnvAPIKey, "")
+	t.Setenv(credentials.EnvProfile, "")
+	t.Setenv(credentials.EnvConfig, filepath.Join(t.TempDir(), "missing.toml"))
+	defer SetCredentialProvider(nil)
+
+	calls := 0
+	key := "key-1"
+	SetCredentialProvider(func() (credentials.Credentials, error) {
+		calls++
+		return credentials.Credentials{APIKey: key}, nil
+	})
+	for range 3 {
+		if got, err := getAPIKey(); err != nil || got != "key-1" {
+			t.Fatalf("getAPIKey = %q, %v", got, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("provider called %d times, want 1", calls)
+	}
+
+	key = "key-2"
+	SetCredentials(credentials.Credentials{})
+	if got, _ := getAPIKey(); got != "key-2" || calls != 2 {
+		t.Errorf("after SetCredentials got %q with %d provider calls, want key-2 and 2", got, calls)
+	}
+
+	SetCredentialProvider(func() (credentials.Credentials, error) {
+		return credentials.Credentials{}, errors.New("vault sealed")
+	})
+	if _, err := getAPIKey(); err == nil || !strings.Contains(err.Error(), "vault sealed") {
+		t.Errorf("expected the provider error, got %v", err)
+	}
+}
+
+func TestAuthorizationHeader(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintf(w, `{"status":401,"message":"invalid key %s"}`, strings.TrimPrefix(got, "Basic "))
+	}))
+	defer server.Close()
+
+	SetCredentials(credentials.Credentials{APIKey: "ak-123", APISecret: "sk-456", BaseURL: server.URL})
+	defer SetCredentials(credentials.Credentials{})
+
+	_, err := GetReq(context.Background(), "/project/info", nil)
+	if got != "Basic YWstMTIzOnNrLTQ1Ng==" {
+		t.Errorf("Authorization = %q", got)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "invalid key [REDACTED]" {
+		t.Errorf("expected redacted API error, got %v", err)
+	}
+}
+
+func TestLogging(t *testing.T) {
+	var gotID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID = r.Header.Get("X-Request-Id")
+		fmt.Fprint(w, `{"data":{"sessionToken":"tok-789","name":"site"}}`)
+	}))
+	defer server.Close()
+
+	SetCredentials(credentials.Credentials{APIKey: "ak-123", BaseURL: server.URL})
+	defer SetCredentials(credentials.Credentials{})
+	var logs bytes.Buffer
+	SetLogger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer SetLogger(nil)
+
+	if _, err := PostReq(context.Background(), "/auth/session-token", strings.NewReader(`{"password":"pw-000"}`)); err != nil {
+		t.Fatal(err)
+	}
+	var event struct {
+		Msg       string `json:"msg"`
+		Method    string `json:"method"`
+		Path      string `json:"path"`
+		Status    int    `json:"status"`
+		Attempt   int    `json:"attempt"`
+		RequestID string `json:"requestId"`
+	}
+	if err := json.Unmarshal(logs.Bytes(), &event); err != nil {
+		t.Fatalf("expected a single event, got %q: %v", logs.String(), err)
+	}
+	if event.Msg != "apillon request" || event.Method != "POST" || event.Path != "/auth/session-token" ||
+		event.Status != 200 || event.Attempt != 1 || event.RequestID == "" || event.RequestID != gotID {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	logs.Reset()
+	SetDebug(true)
+	defer SetDebug(false)
+	if _, err := PostReq(context.Background(), "/auth/session-token", strings.NewReader(`{"password":"pw-000"}`)); err != nil {
+		t.Fatal(err)
+	}
+	out := logs.String()
+	if strings.Count(out, "\n") != 3 || !strings.Contains(out, `\"name\":\"site\"`) {
+		t.Errorf("expected request, response and body events, got %q", out)
+	}
+	for _, secret := range []string{"pw-000", "tok-789", "ak-123"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("debug log leaks %s: %q", secret, out)
+		}
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	creds := credentials.Credentials{APIKey: "ak-123"}
+	got := RedactBody(creds, []byte(`{"items":[{"apiKey":"x","name":"ak-123"}],"total":1}`))
+	if got != `{"items":[{"apiKey":"[REDACTED]","name":"[REDACTED]"}],"total":1}` {
+		t.Errorf("RedactBody = %s", got)
+	}
+	got = RedactBody(creds, []byte(`{"variables":[{"key":"DB_PASSWORD","value":"hunter2"},{"key":"private-key","value":"0xabc"},{"key":"REGION","value":"eu"}]}`))
+	if got != `{"variables":[{"key":"DB_PASSWORD","value":"[REDACTED]"},{"key":"private-key","value":"[REDACTED]"},{"key":"REGION","value":"eu"}]}` {
+		t.Errorf("RedactBody did not redact sensitive variables: %s", got)
+	}
+	if got := RedactBody(creds, []byte(strings.Repeat("a", 5000))); len(got) != maxLoggedBody+len("...(truncated)") {
+		t.Errorf("expected truncated body, got %d bytes", len(got))
+	}
+}
@@ -0,0 +1,105 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Apillon/go-sdk/requests"
+)
+
+// ListEndpoints lists the supported networks and their endpoint URLs.
+// Returns a ListEndpointsResponse struct or an error if the request or unmarshalling fails.
+func ListEndpoints(ctx context.Context) (ListEndpointsResponse, error) {
+	res, err := requests.GetReq(ctx, "/rpc/endpoints", nil)
+	if err != nil {
+		return ListEndpointsResponse{}, &RPCError{
+			Code:    500,
+			Message: "failed to list RPC endpoints",
+			Err:     err,
+		}
+	}
+
+	var endpoints ListEndpointsResponse
+	if err := json.Unmarshal([]byte(res), &endpoints); err != nil {
+		return ListEndpointsResponse{}, &RPCError{
+			Code:    500,
+			Message: "failed to unmarshal list RPC endpoints response",
+			Err:     err,
+		}
+	}
+
+	return endpoints, nil
+}
+
+// FindEndpoint returns the endpoint of chain, matched case-insensitively against the network name,
+// the display name or, for numeric values, the chain ID.
+// Returns false if no endpoint matches.
+func FindEndpoint(endpoints []Endpoint, chain string) (Endpoint, bool) {
+	chain = strings.TrimSpace(chain)
+	chainID, idErr := strconv.Atoi(chain)
+	for _, e := range endpoints {
+		if strings.EqualFold(e.NetworkName, chain) || strings.EqualFold(e.Name, chain) || (idErr == nil && e.NetworkID == chainID) {
+			return e, true
+		}
+	}
+	return Endpoint{}, false
+}
+
+// URL returns the HTTPS (or, if websocket is set, WSS) URL of the endpoint authenticated with key.
+// Returns an empty string if the endpoint has no URL of that kind.
+func (e Endpoint) URL(key string, websocket bool) string {
+	base := e.HTTPSURL
+	if websocket {
+		base = e.WSSURL
+	}
+	if base == "" {
+		return ""
+	}
+	return strings.TrimSuffix(base, "/") + "/" + key
+}
+
+// EndpointURL builds a ready-to-use endpoint URL for chain with the RPC API key identified by apiKeyId.
+// chain is matched as described for FindEndpoint, e.g. "moonbeam" or "1284".
+// Returns the URL or an error if the key or chain cannot be found.
+func EndpointURL(ctx context.Context, apiKeyId int, chain string, websocket bool) (string, error) {
+	if strings.TrimSpace(chain) == "" {
+		return "", &RPCError{
+			Code:    ErrCodeInvalidInput,
+			Message: "chain cannot be empty",
+		}
+	}
+
+	key, err := GetAPIKey(ctx, apiKeyId)
+	if err != nil {
+		return "", err
+	}
+
+	endpoints, err := ListEndpoints(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint, ok := FindEndpoint(endpoints.Data, chain)
+	if !ok {
+		return "", &RPCError{
+			Code:    ErrCodeInvalidInput,
+			Message: fmt.Sprintf("no RPC endpoint for chain %q", chain),
+		}
+	}
+
+	url := endpoint.URL(key.Data.UUID, websocket)
+	if url == "" {
+		kind := "HTTPS"
+		if websocket {
+			kind = "WebSocket"
+		}
+		return "", &RPCError{
+			Code:    ErrCodeInvalidInput,
+			Message: fmt.Sprintf("chain %q has no %s endpoint", chain, kind),
+		}
+	}
+	return url, nil
+}
@@ -0,0 +1,148 @@
+// Package rpc provides functions to manage Apillon Web3 RPC API keys, discover supported
+// networks and their endpoint URLs, and an http.RoundTripper for JSON-RPC clients.
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Apillon/go-sdk/requests"
+)
+
+// RPCError represents an error that occurred during RPC operations
+type RPCError struct {
+	Code    int
+	Message string
+	Err     error
+}
+
+func (e *RPCError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("rpc error (code %d): %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("rpc error (code %d): %s", e.Code, e.Message)
+}
+
+func (e *RPCError) Unwrap() error {
+	return e.Err
+}
+
+// Error codes
+const (
+	ErrCodeInvalidInput = 40000001
+)
+
+// ListAPIKeys lists all RPC API keys in the project.
+// Returns a ListAPIKeysResponse struct or an error if the request or unmarshalling fails.
+func ListAPIKeys(ctx context.Context) (ListAPIKeysResponse, error) {
+	res, err := requests.GetReq(ctx, "/rpc/api-key", nil)
+	if err != nil {
+		return ListAPIKeysResponse{}, &RPCError{
+			Code:    500,
+			Message: "failed to list RPC API keys",
+			Err:     err,
+		}
+	}
+
+	var keys ListAPIKeysResponse
+	if err := json.Unmarshal([]byte(res), &keys); err != nil {
+		return ListAPIKeysResponse{}, &RPCError{
+			Code:    500,
+			Message: "failed to unmarshal list RPC API keys response",
+			Err:     err,
+		}
+	}
+
+	return keys, nil
+}
+
+// CreateAPIKey creates a new RPC API key.
+// Returns an APIKeyResponse containing the created key, or an error if the request or unmarshalling fails.
+func CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (APIKeyResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return APIKeyResponse{}, &RPCError{
+			Code:    ErrCodeInvalidInput,
+			Message: "API key name cannot be empty",
+		}
+	}
+
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return APIKeyResponse{}, &RPCError{
+			Code:    500,
+			Message: "failed to marshal create RPC API key request",
+			Err:     err,
+		}
+	}
+
+	res, err := requests.PostReq(ctx, "/rpc/api-key", strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return APIKeyResponse{}, &RPCError{
+			Code:    500,
+			Message: "failed to create RPC API key",
+			Err:     err,
+		}
+	}
+
+	return unmarshalAPIKey(res, "create RPC API key")
+}
+
+// GetAPIKey retrieves an RPC API key by its ID.
+// Returns an APIKeyResponse struct or an error if the request or unmarshalling fails.
+func GetAPIKey(ctx context.Context, id int) (APIKeyResponse, error) {
+	if id <= 0 {
+		return APIKeyResponse{}, &RPCError{
+			Code:    ErrCodeInvalidInput,
+			Message: "API key ID must be positive",
+		}
+	}
+
+	res, err := requests.GetReq(ctx, "/rpc/api-key/"+strconv.Itoa(id), nil)
+	if err != nil {
+		return APIKeyResponse{}, &RPCError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to get RPC API key %d", id),
+			Err:     err,
+		}
+	}
+
+	return unmarshalAPIKey(res, "get RPC API key")
+}
+
+// RevokeAPIKey revokes an RPC API key. Endpoint URLs built with the key stop working.
+// Returns the API response or an error if the request fails.
+func RevokeAPIKey(ctx context.Context, id int) (string, error) {
+	if id <= 0 {
+		return "", &RPCError{
+			Code:    ErrCodeInvalidInput,
+			Message: "API key ID must be positive",
+		}
+	}
+
+	res, err := requests.DeleteReq(ctx, "/rpc/api-key/"+strconv.Itoa(id))
+	if err != nil {
+		return "", &RPCError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to revoke RPC API key %d", id),
+			Err:     err,
+		}
+	}
+
+	return res, nil
+}
+
+// unmarshalAPIKey decodes a single API key response; operation names the call for error messages.
+func unmarshalAPIKey(res string, operation string) (APIKeyResponse, error) {
+	var key APIKeyResponse
+	if err := json.Unmarshal([]byte(res), &key); err != nil {
+		return APIKeyResponse{}, &RPCError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to unmarshal %s response", operation),
+			Err:     err,
+		}
+	}
+	return key, nil
+}
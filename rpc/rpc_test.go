@@ -0,0 +1,209 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Apillon/go-sdk/internal/apitest"
+	"github.com/Apillon/go-sdk/requests"
+)
+
+var testEndpoints = []Endpoint{
+	{Name: "Moonbeam", NetworkName: "moonbeam", NetworkID: 1284, HTTPSURL: "https://api-moonbeam.example/", WSSURL: "wss://api-moonbeam.example"},
+	{Name: "Polkadot", NetworkName: "polkadot", HTTPSURL: "https://api-polkadot.example"},
+}
+
+func TestFindEndpoint(t *testing.T) {
+	for _, chain := range []string{"moonbeam", "Moonbeam", "1284"} {
+		if e, ok := FindEndpoint(testEndpoints, chain); !ok || e.NetworkName != "moonbeam" {
+			t.Errorf("FindEndpoint(%q) = %+v, %v", chain, e, ok)
+		}
+	}
+	if _, ok := FindEndpoint(testEndpoints, "ethereum"); ok {
+		t.Error("expected no endpoint for unsupported chain")
+	}
+}
+
+func TestEndpointURL(t *testing.T) {
+	if got := testEndpoints[0].URL("key", false); got != "https://api-moonbeam.example/key" {
+		t.Errorf("unexpected HTTPS URL %s", got)
+	}
+	if got := testEndpoints[0].URL("key", true); got != "wss://api-moonbeam.example/key" {
+		t.Errorf("unexpected WSS URL %s", got)
+	}
+	if got := testEndpoints[1].URL("key", true); got != "" {
+		t.Errorf("expected no WSS URL, got %s", got)
+	}
+}
+
+func TestInputValidation(t *testing.T) {
+	server := apitest.NewServer(t)
+	ctx := context.Background()
+
+	checks := map[string]error{}
+	_, checks["CreateAPIKey"] = CreateAPIKey(ctx, CreateAPIKeyRequest{})
+	_, checks["GetAPIKey"] = GetAPIKey(ctx, 0)
+	_, checks["RevokeAPIKey"] = RevokeAPIKey(ctx, -1)
+	_, checks["EndpointURL"] = EndpointURL(ctx, 1, " ", false)
+
+	for name, err := range checks {
+		var rpcErr *RPCError
+		if !errors.As(err, &rpcErr) || rpcErr.Code != ErrCodeInvalidInput {
+			t.Errorf("%s: expected ErrCodeInvalidInput, got %v", name, err)
+		}
+	}
+	if got := server.Received(); len(got) != 0 {
+		t.Errorf("expected no requests for invalid input, got %+v", got)
+	}
+}
+
+func TestEndpointURLFromAPI(t *testing.T) {
+	tests := []struct {
+		name      string
+		chain     string
+		websocket bool
+		want      string
+		wantErr   bool
+	}{
+		{"by network name", "moonbeam", false, "https://api-moonbeam.example/key-uuid", false},
+		{"websocket by chain ID", "1284", true, "wss://api-moonbeam.example/key-uuid", false},
+		{"no websocket endpoint", "Polkadot", true, "", true},
+		{"unsupported chain", "ethereum", false, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := apitest.NewServer(t)
+			server.Reply("GET /rpc/api-key/7", APIKey{ID: 7, Name: "backend", UUID: "key-uuid"})
+			server.Reply("GET /rpc/endpoints", testEndpoints)
+
+			got, err := EndpointURL(context.Background(), 7, tt.chain, tt.websocket)
+			var rpcErr *RPCError
+			switch {
+			case tt.wantErr && (!errors.As(err, &rpcErr) || rpcErr.Code != ErrCodeInvalidInput):
+				t.Errorf("expected ErrCodeInvalidInput, got %v", err)
+			case !tt.wantErr && (err != nil || got != tt.want):
+				t.Errorf("EndpointURL = %q, %v; want %q", got, err, tt.want)
+			}
+			if got := server.Requests(); !slices.Equal(got, []string{"GET /rpc/api-key/7", "GET /rpc/endpoints"}) {
+				t.Errorf("unexpected requests %q", got)
+			}
+		})
+	}
+}
+
+func TestEndpointURLUnknownKey(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.ReplyError("GET /rpc/api-key/{id}", http.StatusNotFound, 40400000, "RPC_API_KEY_NOT_FOUND")
+
+	_, err := EndpointURL(context.Background(), 9, "moonbeam", false)
+	var apiErr *requests.APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusNotFound {
+		t.Fatalf("expected the key lookup error, got %v", err)
+	}
+	if got := server.Requests(); !slices.Equal(got, []string{"GET /rpc/api-key/9"}) {
+		t.Errorf("expected endpoints not to be listed, got %q", got)
+	}
+}
+
+func TestAPIKeyRequests(t *testing.T) {
+	ctx := context.Background()
+	server := apitest.NewServer(t)
+	server.Reply("POST /rpc/api-key", APIKey{ID: 8, Name: "new", UUID: "new-uuid"})
+	server.Reply("DELETE /rpc/api-key/{id}", true)
+
+	created, err := CreateAPIKey(ctx, CreateAPIKeyRequest{Name: "new"})
+	if err != nil || created.Data.ID != 8 || created.Data.UUID != "new-uuid" {
+		t.Fatalf("CreateAPIKey = %+v, %v", created.Data, err)
+	}
+	if _, err := RevokeAPIKey(ctx, 8); err != nil {
+		t.Fatalf("RevokeAPIKey failed: %v", err)
+	}
+
+	want := []apitest.Request{
+		{Method: "POST", Path: "/rpc/api-key", Body: `{"name":"new"}`},
+		{Method: "DELETE", Path: "/rpc/api-key/8"},
+	}
+	if got := server.Received(); !slices.Equal(got, want) {
+		t.Errorf("requests %+v, want %+v", got, want)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "eth_fail") {
+			http.Error(w, "rate limited", http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+	defer server.Close()
+
+	var calls []CallMetrics
+	transport := &Transport{APIKey: "secret", OnCall: func(m CallMetrics) { calls = append(calls, m) }}
+	client := &http.Client{Transport: transport}
+
+	requests := []struct {
+		url  string
+		body string
+	}{
+		{server.URL + "/", `{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}`},
+		{server.URL + "/secret", `[{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},{"jsonrpc":"2.0","id":2,"method":"eth_blockNumber"}]`},
+		{server.URL, `{"jsonrpc":"2.0","id":1,"method":"eth_fail"}`},
+	}
+	for _, r := range requests {
+		resp, err := client.Post(r.url, "application/json", strings.NewReader(r.body))
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	for _, p := range paths {
+		if p != "/secret" {
+			t.Errorf("expected API key path, got %s", p)
+		}
+	}
+	if len(calls) != 3 || len(calls[1].Methods) != 2 || calls[2].StatusCode != http.StatusTooManyRequests {
+		t.Errorf("unexpected call metrics %+v", calls)
+	}
+
+	usage := transport.Usage()
+	if usage.Requests != 3 || usage.Calls != 4 || usage.Errors != 1 || usage.Methods["eth_blockNumber"] != 2 {
+		t.Errorf("unexpected usage %+v", usage)
+	}
+}
+
+func TestTransportWithoutBody(t *testing.T) {
+	var sent *http.Request
+	base := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		sent = r
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: r}, nil
+	})
+	client := &http.Client{Transport: &Transport{APIKey: "secret", Base: base}}
+
+	resp, err := client.Get("https://rpc.example/health")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	// A non-nil body of length 0 would be sent with an unknown length
+	if sent.Body != http.NoBody || sent.ContentLength != 0 {
+		t.Errorf("GET sent with body %v and length %d", sent.Body, sent.ContentLength)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
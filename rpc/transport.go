@@ -0,0 +1,161 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CallMetrics describes a single HTTP request made through a Transport.
+type CallMetrics struct {
+	Methods       []string      // JSON-RPC methods in the request; more than one for batch requests
+	StatusCode    int           // HTTP status code, 0 if the request failed
+	Duration      time.Duration // Time until the response headers were received
+	RequestBytes  int           // Size of the request body
+	ResponseBytes int64         // Size of the response body as announced by the server, -1 if unknown
+	Err           error         // Transport error, if any
+}
+
+// Usage is a snapshot of the requests made through a Transport.
+type Usage struct {
+	Requests int64            // HTTP requests sent
+	Calls    int64            // JSON-RPC calls, counting each call of a batch
+	Errors   int64            // Requests that failed or returned an HTTP error status
+	Methods  map[string]int64 // Calls per JSON-RPC method
+}
+
+// Transport is an http.RoundTripper for JSON-RPC clients that appends an RPC API key to
+// endpoint URLs and records usage. Use it with any client that accepts an *http.Client:
+//
+//	client := &http.Client{Transport: &rpc.Transport{APIKey: key.UUID}}
+//
+// A Transport must not be copied after first use.
+type Transport struct {
+	// APIKey is appended as the last path segment of request URLs that do not already end with it.
+	APIKey string
+	// Base performs the requests. Defaults to http.DefaultTransport.
+	Base http.RoundTripper
+	// OnCall, if set, is called after every request with its metrics.
+	OnCall func(CallMetrics)
+
+	mu    sync.Mutex
+	usage Usage
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out := req.Clone(req.Context())
+	// A request without a body, e.g. a GET, is sent without one rather than chunked
+	out.Body = http.NoBody
+	if len(body) > 0 {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	out.ContentLength = int64(len(body))
+	if t.APIKey != "" && !strings.HasSuffix(strings.TrimSuffix(out.URL.Path, "/"), "/"+t.APIKey) {
+		out.URL.Path = strings.TrimSuffix(out.URL.Path, "/") + "/" + t.APIKey
+		out.URL.RawPath = ""
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(out)
+
+	metrics := CallMetrics{
+		Methods:       jsonRPCMethods(body),
+		Duration:      time.Since(start),
+		RequestBytes:  len(body),
+		ResponseBytes: -1,
+		Err:           err,
+	}
+	if resp != nil {
+		metrics.StatusCode = resp.StatusCode
+		metrics.ResponseBytes = resp.ContentLength
+	}
+	t.record(metrics)
+
+	return resp, err
+}
+
+// Usage returns a snapshot of the requests made through the transport.
+func (t *Transport) Usage() Usage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	snapshot := t.usage
+	snapshot.Methods = make(map[string]int64, len(t.usage.Methods))
+	for method, n := range t.usage.Methods {
+		snapshot.Methods[method] = n
+	}
+	return snapshot
+}
+
+func (t *Transport) record(m CallMetrics) {
+	t.mu.Lock()
+	t.usage.Requests++
+	t.usage.Calls += int64(len(m.Methods))
+	if m.Err != nil || m.StatusCode >= 400 {
+		t.usage.Errors++
+	}
+	if t.usage.Methods == nil {
+		t.usage.Methods = map[string]int64{}
+	}
+	for _, method := range m.Methods {
+		t.usage.Methods[method]++
+	}
+	t.mu.Unlock()
+
+	if t.OnCall != nil {
+		t.OnCall(m)
+	}
+}
+
+// jsonRPCMethods extracts the method names of a single or batch JSON-RPC request body.
+func jsonRPCMethods(body []byte) []string {
+	type call struct {
+		Method string `json:"method"`
+	}
+
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil
+	}
+
+	var calls []call
+	if body[0] == '[' {
+		if json.Unmarshal(body, &calls) != nil {
+			return nil
+		}
+	} else {
+		var single call
+		if json.Unmarshal(body, &single) != nil {
+			return nil
+		}
+		calls = []call{single}
+	}
+
+	methods := make([]string, 0, len(calls))
+	for _, c := range calls {
+		if c.Method != "" {
+			methods = append(methods, c.Method)
+		}
+	}
+	return methods
+}
@@ -0,0 +1,40 @@
+package rpc
+
+import "github.com/Apillon/go-sdk/storage"
+
+// APIKey is an RPC API key. Its UUID is the secret appended to endpoint URLs.
+type APIKey struct {
+	storage.Timestamps
+	ID          int    `json:"id"`          // Numeric identifier used by the API key endpoints
+	Name        string `json:"name"`        // Name of the key
+	Description string `json:"description"` // Description of the key
+	UUID        string `json:"uuid"`        // Key value appended to endpoint URLs
+}
+
+// Endpoint is an RPC endpoint of a supported network.
+type Endpoint struct {
+	ID          int    `json:"id"`          // Identifier of the endpoint
+	Name        string `json:"name"`        // Display name of the network, e.g. "Moonbeam"
+	ImageURL    string `json:"imageUrl"`    // Network logo
+	Type        string `json:"type"`        // "mainnet" or "testnet"
+	Version     string `json:"version"`     // Node version
+	NetworkName string `json:"networkName"` // Short network name, e.g. "moonbeam"
+	NetworkID   int    `json:"networkId"`   // Chain ID for EVM networks
+	HTTPSURL    string `json:"httpsUrl"`    // HTTPS endpoint without the API key
+	WSSURL      string `json:"wssUrl"`      // WebSocket endpoint without the API key
+}
+
+// APIKeyResponse represents a response containing a single RPC API key.
+type APIKeyResponse = storage.APIResponse[APIKey]
+
+// ListAPIKeysResponse represents a response containing a list of RPC API keys.
+type ListAPIKeysResponse = storage.APIResponse[storage.ListData[APIKey]]
+
+// ListEndpointsResponse represents a response containing the supported endpoints.
+type ListEndpointsResponse = storage.APIResponse[[]Endpoint]
+
+// CreateAPIKeyRequest represents the request body for creating an RPC API key.
+type CreateAPIKeyRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
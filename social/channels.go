@@ -0,0 +1,143 @@
+package social
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Apillon/go-sdk/requests"
+)
+
+// ListChannels lists the channels in the project. A non-empty hubUuid lists only the channels of that hub.
+// Returns a ListChannelsResponse struct or an error if the request or unmarshalling fails.
+func ListChannels(ctx context.Context, hubUuid string) (ListChannelsResponse, error) {
+	params := map[string]string{}
+	if hubUuid != "" {
+		params["hubUuid"] = hubUuid
+	}
+
+	res, err := requests.GetReq(ctx, "/social/channels", params)
+	if err != nil {
+		return ListChannelsResponse{}, &SocialError{
+			Code:    500,
+			Message: "failed to list channels",
+			Err:     err,
+		}
+	}
+
+	var channels ListChannelsResponse
+	if err := json.Unmarshal([]byte(res), &channels); err != nil {
+		return ListChannelsResponse{}, &SocialError{
+			Code:    500,
+			Message: "failed to unmarshal list channels response",
+			Err:     err,
+		}
+	}
+
+	return channels, nil
+}
+
+// CreateChannel creates a new channel. Use Channel.WidgetConfig on the result, once the channel is active,
+// to get the identifiers for the Grill widget.
+// Returns a ChannelResponse containing the created channel, or an error if the request or unmarshalling fails.
+func CreateChannel(ctx context.Context, req CreateChannelRequest) (ChannelResponse, error) {
+	if strings.TrimSpace(req.Title) == "" || strings.TrimSpace(req.Body) == "" {
+		return ChannelResponse{}, &SocialError{
+			Code:    ErrCodeInvalidInput,
+			Message: "channel title and body cannot be empty",
+		}
+	}
+
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return ChannelResponse{}, &SocialError{
+			Code:    500,
+			Message: "failed to marshal create channel request",
+			Err:     err,
+		}
+	}
+
+	res, err := requests.PostReq(ctx, "/social/channels", strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return ChannelResponse{}, &SocialError{
+			Code:    500,
+			Message: "failed to create channel",
+			Err:     err,
+		}
+	}
+
+	return unmarshalChannel(res, "create channel")
+}
+
+// GetChannel retrieves a channel by its UUID.
+// Returns a ChannelResponse struct or an error if the request or unmarshalling fails.
+func GetChannel(ctx context.Context, channelUuid string) (ChannelResponse, error) {
+	if channelUuid == "" {
+		return ChannelResponse{}, &SocialError{
+			Code:    ErrCodeInvalidInput,
+			Message: "channel UUID cannot be empty",
+		}
+	}
+
+	res, err := requests.GetReq(ctx, "/social/channels/"+channelUuid, nil)
+	if err != nil {
+		return ChannelResponse{}, &SocialError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to get channel %s", channelUuid),
+			Err:     err,
+		}
+	}
+
+	return unmarshalChannel(res, "get channel")
+}
+
+// UpdateChannel updates the title, body and/or tags of a channel. Nil fields in update are left unchanged.
+// Returns a ChannelResponse containing the updated channel, or an error if the request or unmarshalling fails.
+func UpdateChannel(ctx context.Context, channelUuid string, update UpdateChannelRequest) (ChannelResponse, error) {
+	if channelUuid == "" {
+		return ChannelResponse{}, &SocialError{
+			Code:    ErrCodeInvalidInput,
+			Message: "channel UUID cannot be empty",
+		}
+	}
+	if (update.Title != nil && strings.TrimSpace(*update.Title) == "") || (update.Body != nil && strings.TrimSpace(*update.Body) == "") {
+		return ChannelResponse{}, &SocialError{
+			Code:    ErrCodeInvalidInput,
+			Message: "channel title and body cannot be empty",
+		}
+	}
+
+	bodyBytes, err := json.Marshal(update)
+	if err != nil {
+		return ChannelResponse{}, &SocialError{
+			Code:    500,
+			Message: "failed to marshal update channel request",
+			Err:     err,
+		}
+	}
+
+	res, err := requests.PatchReq(ctx, "/social/channels/"+channelUuid, strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return ChannelResponse{}, &SocialError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to update channel %s", channelUuid),
+			Err:     err,
+		}
+	}
+
+	return unmarshalChannel(res, "update channel")
+}
+
+// unmarshalChannel decodes a single channel response; operation names the call for error messages.
+func unmarshalChannel(res string, operation string) (ChannelResponse, error) {
+	var channel ChannelResponse
+	if err := json.Unmarshal([]byte(res), &channel); err != nil {
+		return ChannelResponse{}, &SocialError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to unmarshal %s response", operation),
+			Err:     err,
+		}
+	}
+	return channel, nil
+}
@@ -0,0 +1,162 @@
+// Package social provides functions to manage Apillon Social (Grill.chat) hubs and channels
+// and to get the identifiers needed to embed channels with the Grill widget.
+package social
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Apillon/go-sdk/requests"
+)
+
+// SocialError represents an error that occurred during social operations
+type SocialError struct {
+	Code    int
+	Message string
+	Err     error
+}
+
+func (e *SocialError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("social error (code %d): %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("social error (code %d): %s", e.Code, e.Message)
+}
+
+func (e *SocialError) Unwrap() error {
+	return e.Err
+}
+
+// Error codes
+const (
+	ErrCodeInvalidInput = 40000001
+)
+
+// ListHubs lists all hubs in the project.
+// Returns a ListHubsResponse struct or an error if the request or unmarshalling fails.
+func ListHubs(ctx context.Context) (ListHubsResponse, error) {
+	res, err := requests.GetReq(ctx, "/social/hubs", nil)
+	if err != nil {
+		return ListHubsResponse{}, &SocialError{
+			Code:    500,
+			Message: "failed to list hubs",
+			Err:     err,
+		}
+	}
+
+	var hubs ListHubsResponse
+	if err := json.Unmarshal([]byte(res), &hubs); err != nil {
+		return ListHubsResponse{}, &SocialError{
+			Code:    500,
+			Message: "failed to unmarshal list hubs response",
+			Err:     err,
+		}
+	}
+
+	return hubs, nil
+}
+
+// CreateHub creates a new hub.
+// Returns a HubResponse containing the created hub, or an error if the request or unmarshalling fails.
+func CreateHub(ctx context.Context, req CreateHubRequest) (HubResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return HubResponse{}, &SocialError{
+			Code:    ErrCodeInvalidInput,
+			Message: "hub name cannot be empty",
+		}
+	}
+
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return HubResponse{}, &SocialError{
+			Code:    500,
+			Message: "failed to marshal create hub request",
+			Err:     err,
+		}
+	}
+
+	res, err := requests.PostReq(ctx, "/social/hubs", strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return HubResponse{}, &SocialError{
+			Code:    500,
+			Message: "failed to create hub",
+			Err:     err,
+		}
+	}
+
+	return unmarshalHub(res, "create hub")
+}
+
+// GetHub retrieves a hub by its UUID.
+// Returns a HubResponse struct or an error if the request or unmarshalling fails.
+func GetHub(ctx context.Context, hubUuid string) (HubResponse, error) {
+	if hubUuid == "" {
+		return HubResponse{}, &SocialError{
+			Code:    ErrCodeInvalidInput,
+			Message: "hub UUID cannot be empty",
+		}
+	}
+
+	res, err := requests.GetReq(ctx, "/social/hubs/"+hubUuid, nil)
+	if err != nil {
+		return HubResponse{}, &SocialError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to get hub %s", hubUuid),
+			Err:     err,
+		}
+	}
+
+	return unmarshalHub(res, "get hub")
+}
+
+// UpdateHub updates the name, description and/or tags of a hub. Nil fields in update are left unchanged.
+// Returns a HubResponse containing the updated hub, or an error if the request or unmarshalling fails.
+func UpdateHub(ctx context.Context, hubUuid string, update UpdateHubRequest) (HubResponse, error) {
+	if hubUuid == "" {
+		return HubResponse{}, &SocialError{
+			Code:    ErrCodeInvalidInput,
+			Message: "hub UUID cannot be empty",
+		}
+	}
+	if update.Name != nil && strings.TrimSpace(*update.Name) == "" {
+		return HubResponse{}, &SocialError{
+			Code:    ErrCodeInvalidInput,
+			Message: "hub name cannot be empty",
+		}
+	}
+
+	bodyBytes, err := json.Marshal(update)
+	if err != nil {
+		return HubResponse{}, &SocialError{
+			Code:    500,
+			Message: "failed to marshal update hub request",
+			Err:     err,
+		}
+	}
+
+	res, err := requests.PatchReq(ctx, "/social/hubs/"+hubUuid, strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return HubResponse{}, &SocialError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to update hub %s", hubUuid),
+			Err:     err,
+		}
+	}
+
+	return unmarshalHub(res, "update hub")
+}
+
+// unmarshalHub decodes a single hub response; operation names the call for error messages.
+func unmarshalHub(res string, operation string) (HubResponse, error) {
+	var hub HubResponse
+	if err := json.Unmarshal([]byte(res), &hub); err != nil {
+		return HubResponse{}, &SocialError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to unmarshal %s response", operation),
+			Err:     err,
+		}
+	}
+	return hub, nil
+}
@@ -0,0 +1,142 @@
+package social
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/Apillon/go-sdk/internal/apitest"
+	"github.com/Apillon/go-sdk/requests"
+)
+
+func TestInputValidation(t *testing.T) {
+	server := apitest.NewServer(t)
+	ctx := context.Background()
+	blank := " "
+
+	checks := map[string]error{}
+	_, checks["CreateHub"] = CreateHub(ctx, CreateHubRequest{About: "no name"})
+	_, checks["GetHub"] = GetHub(ctx, "")
+	_, checks["UpdateHub"] = UpdateHub(ctx, "uuid", UpdateHubRequest{Name: &blank})
+	_, checks["CreateChannel"] = CreateChannel(ctx, CreateChannelRequest{Title: "No body"})
+	_, checks["GetChannel"] = GetChannel(ctx, "")
+	_, checks["UpdateChannel"] = UpdateChannel(ctx, "uuid", UpdateChannelRequest{Body: &blank})
+
+	for name, err := range checks {
+		var socialErr *SocialError
+		if !errors.As(err, &socialErr) || socialErr.Code != ErrCodeInvalidInput {
+			t.Errorf("%s: expected ErrCodeInvalidInput, got %v", name, err)
+		}
+	}
+	if got := server.Received(); len(got) != 0 {
+		t.Errorf("expected no requests for invalid input, got %+v", got)
+	}
+}
+
+func TestHubRequests(t *testing.T) {
+	ctx := context.Background()
+	server := apitest.NewServer(t)
+	server.Reply("GET /social/hubs", map[string]any{"items": []Hub{{HubUUID: "h-1"}, {HubUUID: "h-2"}}, "total": 2})
+	server.Reply("POST /social/hubs", Hub{HubUUID: "h-3", Name: "Community", Status: StatusProcessing})
+	server.Reply("PATCH /social/hubs/{uuid}", Hub{HubUUID: "h-1", Name: "Renamed", Status: StatusActive})
+
+	hubs, err := ListHubs(ctx)
+	if err != nil || hubs.Data.Total != 2 || hubs.Data.Items[1].HubUUID != "h-2" {
+		t.Fatalf("ListHubs = %+v, %v", hubs.Data, err)
+	}
+	created, err := CreateHub(ctx, CreateHubRequest{Name: "Community", Tags: "go"})
+	if err != nil || created.Data.HubUUID != "h-3" || created.Data.Status != StatusProcessing {
+		t.Fatalf("CreateHub = %+v, %v", created.Data, err)
+	}
+	name := "Renamed"
+	updated, err := UpdateHub(ctx, "h-1", UpdateHubRequest{Name: &name})
+	if err != nil || updated.Data.Name != "Renamed" {
+		t.Fatalf("UpdateHub = %+v, %v", updated.Data, err)
+	}
+
+	want := []apitest.Request{
+		{Method: "GET", Path: "/social/hubs"},
+		{Method: "POST", Path: "/social/hubs", Body: `{"name":"Community","tags":"go"}`},
+		{Method: "PATCH", Path: "/social/hubs/h-1", Body: `{"name":"Renamed"}`},
+	}
+	if got := server.Received(); !slices.Equal(got, want) {
+		t.Errorf("requests %+v, want %+v", got, want)
+	}
+}
+
+func TestGetMissingHub(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.ReplyError("GET /social/hubs/{uuid}", http.StatusNotFound, 40400000, "HUB_NOT_FOUND")
+
+	_, err := GetHub(context.Background(), "missing")
+	var socialErr *SocialError
+	var apiErr *requests.APIError
+	if !errors.As(err, &socialErr) || socialErr.Code != 500 || !errors.As(err, &apiErr) || apiErr.Status != http.StatusNotFound {
+		t.Fatalf("expected a wrapped not found error, got %v", err)
+	}
+}
+
+func TestChannelRequests(t *testing.T) {
+	ctx := context.Background()
+	server := apitest.NewServer(t)
+	server.Reply("GET /social/channels", map[string]any{"items": []Channel{{ChannelUUID: "c-1"}}, "total": 1})
+	server.Reply("POST /social/channels", Channel{ChannelUUID: "c-2", Status: StatusProcessing})
+	server.Reply("GET /social/channels/{uuid}", Channel{ChannelUUID: "c-1", ChannelID: "1234", HubID: "12", Status: StatusActive})
+
+	if _, err := ListChannels(ctx, "h-1"); err != nil {
+		t.Fatalf("ListChannels failed: %v", err)
+	}
+	if _, err := ListChannels(ctx, ""); err != nil {
+		t.Fatalf("ListChannels without hub failed: %v", err)
+	}
+	created, err := CreateChannel(ctx, CreateChannelRequest{Title: "Product", Body: "Discuss", HubUUID: "h-1"})
+	if err != nil || created.Data.ChannelUUID != "c-2" {
+		t.Fatalf("CreateChannel = %+v, %v", created.Data, err)
+	}
+	channel, err := GetChannel(ctx, "c-1")
+	if err != nil {
+		t.Fatalf("GetChannel failed: %v", err)
+	}
+	if cfg := channel.Data.WidgetConfig(); cfg.HubID != "12" || cfg.ChannelID != "1234" {
+		t.Errorf("unexpected widget config %+v", cfg)
+	}
+
+	want := []apitest.Request{
+		{Method: "GET", Path: "/social/channels", Query: "hubUuid=h-1"},
+		{Method: "GET", Path: "/social/channels"},
+		{Method: "POST", Path: "/social/channels", Body: `{"title":"Product","body":"Discuss","hubUuid":"h-1"}`},
+		{Method: "GET", Path: "/social/channels/c-1"},
+	}
+	if got := server.Received(); !slices.Equal(got, want) {
+		t.Errorf("requests %+v, want %+v", got, want)
+	}
+}
+
+func TestChannelWidgetConfig(t *testing.T) {
+	data := `{"id":"1","status":200,"data":{"channelUuid":"c-1","channelId":"1234","hubId":"12","title":"Product","body":"Discuss","status":5}}`
+
+	var resp ChannelResponse
+	if err := json.Unmarshal([]byte(data), &resp); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if resp.Data.Status != StatusActive {
+		t.Errorf("unexpected status %v", resp.Data.Status)
+	}
+	if cfg := resp.Data.WidgetConfig(); cfg.HubID != "12" || cfg.ChannelID != "1234" {
+		t.Errorf("unexpected widget config %+v", cfg)
+	}
+}
+
+func TestUpdateRequestOmitsUnsetFields(t *testing.T) {
+	about := "New description"
+	data, err := json.Marshal(UpdateHubRequest{About: &about})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"about":"New description"}` {
+		t.Errorf("unexpected update body %s", data)
+	}
+}
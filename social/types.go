@@ -0,0 +1,104 @@
+package social
+
+import "github.com/Apillon/go-sdk/storage"
+
+// Status is the processing status of a hub or channel.
+type Status int
+
+const (
+	StatusProcessing Status = 1 // Being created on-chain
+	StatusActive     Status = 5 // Created and usable
+	StatusFailed     Status = 100
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusProcessing:
+		return "processing"
+	case StatusActive:
+		return "active"
+	case StatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// Hub is a space that groups channels, such as one per product or community.
+type Hub struct {
+	storage.Timestamps
+	HubUUID       string `json:"hubUuid"`       // Unique identifier for the hub
+	HubID         string `json:"hubId"`         // On-chain space ID used by the Grill widget
+	Name          string `json:"name"`          // Name of the hub
+	About         string `json:"about"`         // Description of the hub
+	Tags          string `json:"tags"`          // Comma-separated tags
+	Status        Status `json:"status"`        // Processing status
+	NumOfChannels int    `json:"numOfChannels"` // Number of channels in the hub
+}
+
+// Channel is a discussion thread embedded with the Grill widget.
+type Channel struct {
+	storage.Timestamps
+	ChannelUUID string `json:"channelUuid"` // Unique identifier for the channel
+	ChannelID   string `json:"channelId"`   // On-chain post ID used by the Grill widget
+	HubID       string `json:"hubId"`       // On-chain ID of the hub the channel belongs to
+	Title       string `json:"title"`       // Title of the channel
+	Body        string `json:"body"`        // Description shown at the top of the channel
+	Tags        string `json:"tags"`        // Comma-separated tags
+	Status      Status `json:"status"`      // Processing status
+}
+
+// WidgetConfig holds the identifiers the Grill widget needs to show a channel.
+type WidgetConfig struct {
+	HubID     string `json:"hubId"`
+	ChannelID string `json:"channelId"`
+}
+
+// WidgetConfig returns the identifiers to pass to the Grill widget.
+// The IDs are only assigned once the channel is active.
+func (c Channel) WidgetConfig() WidgetConfig {
+	return WidgetConfig{HubID: c.HubID, ChannelID: c.ChannelID}
+}
+
+// HubResponse represents a response containing a single hub.
+type HubResponse = storage.APIResponse[Hub]
+
+// ListHubsResponse represents a response containing a list of hubs.
+type ListHubsResponse = storage.APIResponse[storage.ListData[Hub]]
+
+// ChannelResponse represents a response containing a single channel.
+type ChannelResponse = storage.APIResponse[Channel]
+
+// ListChannelsResponse represents a response containing a list of channels.
+type ListChannelsResponse = storage.APIResponse[storage.ListData[Channel]]
+
+// CreateHubRequest represents the request body for creating a hub.
+type CreateHubRequest struct {
+	Name  string `json:"name"`
+	About string `json:"about,omitempty"`
+	Tags  string `json:"tags,omitempty"` // Comma-separated tags
+}
+
+// UpdateHubRequest represents the request body for updating a hub.
+// Nil fields are left unchanged.
+type UpdateHubRequest struct {
+	Name  *string `json:"name,omitempty"`
+	About *string `json:"about,omitempty"`
+	Tags  *string `json:"tags,omitempty"`
+}
+
+// CreateChannelRequest represents the request body for creating a channel.
+type CreateChannelRequest struct {
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	Tags    string `json:"tags,omitempty"`    // Comma-separated tags
+	HubUUID string `json:"hubUuid,omitempty"` // Hub to create the channel in; the default hub is used if empty
+}
+
+// UpdateChannelRequest represents the request body for updating a channel.
+// Nil fields are left unchanged.
+type UpdateChannelRequest struct {
+	Title *string `json:"title,omitempty"`
+	Body  *string `json:"body,omitempty"`
+	Tags  *string `json:"tags,omitempty"`
+}
@@ -0,0 +1,535 @@
+package storage
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// BackupManifestName is the name of the manifest file written at the root of every backup.
+// A bucket with a file of this name at its root cannot be exported, because the file would overwrite the manifest.
+const BackupManifestName = "apillon-backup.json"
+
+// uploadBatchSize is the number of files uploaded per session when restoring a backup or copying files.
+const uploadBatchSize = 50
+
+// BackupEntry describes a single file stored in a backup.
+type BackupEntry struct {
+	Path      string   `json:"path"`                // Path of the file inside the backup and the bucket
+	File      FileInfo `json:"file"`                // File metadata as returned by the API
+	BackupCID string   `json:"backupCid,omitempty"` // CID of the content block in CAR backups
+}
+
+// BackupManifest describes the content of a bucket backup.
+type BackupManifest struct {
+	BucketUUID string        `json:"bucketUuid"` // UUID of the exported bucket
+	CreatedAt  string        `json:"createdAt"`  // Time the backup was made (RFC3339 format)
+	Files      []BackupEntry `json:"files"`      // Files contained in the backup
+}
+
+// BackupWriter is a destination for a bucket backup.
+// WriteFile is called once per exported file and Close is called once with the final manifest,
+// which the writer may annotate (CAR backups record BackupCID for each entry).
+type BackupWriter interface {
+	WriteFile(entry BackupEntry, content io.Reader) error
+	Close(manifest *BackupManifest) error
+}
+
+// ExportOptions configures ExportBucket.
+type ExportOptions struct {
+	// Previous is the manifest of an earlier backup written to the same directory. Files whose path
+	// and CID are unchanged, and whose copy is still in the directory with the same size, are not
+	// downloaded again and are carried over into the new manifest, which makes re-runs incremental.
+	// Copies of files that were deleted from the bucket since are removed from the directory.
+	// Only directory backups (see NewDirectoryBackup) support it, because an archive would lack
+	// the content of the carried-over files.
+	Previous *BackupManifest
+	// OnFile, if set, is called after each file is processed. skipped reports whether the file was unchanged.
+	OnFile func(entry BackupEntry, skipped bool)
+}
+
+// ExportResult summarizes a bucket export.
+type ExportResult struct {
+	Manifest  BackupManifest // Manifest written to the backup
+	Written   int            // Number of files downloaded and written
+	Unchanged int            // Number of files skipped because their CID did not change
+	Pending   []FileInfo     // Files without a CID yet (still being processed), not included in the backup
+	Removed   []BackupEntry  // Entries of the previous manifest whose file is no longer in the bucket; their copies were removed
+}
+
+// ExportBucket writes every file of a bucket and a JSON manifest of their metadata to w.
+// Files that have not received a CID yet are reported in ExportResult.Pending and left out.
+// Returns the export summary or an error if listing, downloading or writing fails,
+// or if the bucket holds a file named BackupManifestName at its root.
+func ExportBucket(ctx context.Context, bucketUuid string, w BackupWriter, opts ExportOptions) (_ ExportResult, err error) {
+	if w == nil {
+		return ExportResult{}, &StorageError{
+			Code:    ErrCodeInvalidInput,
+			Message: "backup writer cannot be nil",
+		}
+	}
+	dirBackup, isDir := w.(*directoryBackup)
+	if opts.Previous != nil && !isDir {
+		return ExportResult{}, &StorageError{
+			Code:    ErrCodeInvalidInput,
+			Message: "incremental exports are only supported for directory backups",
+		}
+	}
+	if a, ok := w.(aborter); ok {
+		defer func() {
+			if err != nil {
+				a.abort()
+			}
+		}()
+	}
+
+	files, err := ListAllFilesInBucket(ctx, bucketUuid)
+	if err != nil {
+		return ExportResult{}, err
+	}
+	inBucket := make(map[string]bool, len(files))
+	for _, file := range files {
+		if file.FullPath() == BackupManifestName {
+			return ExportResult{}, &StorageError{
+				Code:    ErrCodeInvalidInput,
+				Message: fmt.Sprintf("bucket file %s would overwrite the backup manifest", BackupManifestName),
+			}
+		}
+		inBucket[file.FullPath()] = true
+	}
+
+	previous := map[string]BackupEntry{}
+	if opts.Previous != nil {
+		for _, entry := range opts.Previous.Files {
+			previous[entry.Path] = entry
+		}
+	}
+
+	result := ExportResult{
+		Manifest: BackupManifest{
+			BucketUUID: bucketUuid,
+			CreatedAt:  time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		if file.CID == "" || file.Link == "" {
+			result.Pending = append(result.Pending, file)
+			continue
+		}
+
+		entry := BackupEntry{Path: file.FullPath(), File: file}
+		if prev, ok := previous[entry.Path]; ok && prev.File.CID == file.CID && dirBackup.holds(entry) {
+			entry.BackupCID = prev.BackupCID
+			result.Manifest.Files = append(result.Manifest.Files, entry)
+			result.Unchanged++
+			if opts.OnFile != nil {
+				opts.OnFile(entry, true)
+			}
+			continue
+		}
+
+		if err := exportFile(ctx, w, entry); err != nil {
+			return result, err
+		}
+		result.Manifest.Files = append(result.Manifest.Files, entry)
+		result.Written++
+		if opts.OnFile != nil {
+			opts.OnFile(entry, false)
+		}
+	}
+
+	if opts.Previous != nil {
+		for _, entry := range opts.Previous.Files {
+			if inBucket[entry.Path] {
+				continue
+			}
+			if err := dirBackup.remove(entry); err != nil {
+				return result, &StorageError{
+					Code:    500,
+					Message: fmt.Sprintf("failed to remove deleted file %s from backup", entry.Path),
+					Err:     err,
+				}
+			}
+			result.Removed = append(result.Removed, entry)
+		}
+	}
+
+	if err := w.Close(&result.Manifest); err != nil {
+		return result, &StorageError{
+			Code:    500,
+			Message: "failed to finalize backup",
+			Err:     err,
+		}
+	}
+
+	return result, nil
+}
+
+// aborter is implemented by backup writers that hold resources to release when an export fails before Close.
+type aborter interface {
+	abort()
+}
+
+// exportFile downloads a single file and passes its content to the backup writer.
+func exportFile(ctx context.Context, w BackupWriter, entry BackupEntry) error {
+	body, err := DownloadFile(ctx, entry.File.Link)
+	if err != nil {
+		return fmt.Errorf("failed to download file %s: %w", entry.Path, err)
+	}
+	defer body.Close()
+
+	if err := w.WriteFile(entry, body); err != nil {
+		return &StorageError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to write file %s to backup", entry.Path),
+			Err:     err,
+		}
+	}
+	return nil
+}
+
+// ReadBackupManifest reads the manifest of a backup stored in fsys: os.DirFS for directory backups,
+// a *zip.Reader for zip backups, or the result of ReadTarBackup or ReadCARBackup.
+func ReadBackupManifest(fsys fs.FS) (BackupManifest, error) {
+	data, err := fs.ReadFile(fsys, BackupManifestName)
+	if err != nil {
+		return BackupManifest{}, &StorageError{
+			Code:    500,
+			Message: "failed to read backup manifest",
+			Err:     err,
+		}
+	}
+
+	var manifest BackupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return BackupManifest{}, &StorageError{
+			Code:    500,
+			Message: "failed to unmarshal backup manifest",
+			Err:     err,
+		}
+	}
+
+	return manifest, nil
+}
+
+// RestoreResult summarizes a backup restore.
+type RestoreResult struct {
+	Uploaded int           // Number of files uploaded
+	Empty    []BackupEntry // Empty files, which cannot be uploaded through a signed URL and were skipped
+}
+
+// RestoreBackup re-uploads every file listed in the manifest of a backup stored in fsys into the given bucket,
+// keeping the original paths and content types. See ReadBackupManifest for the file systems of each backup format.
+// Files are streamed from fsys in batches of 50, one upload session per batch. Empty files are skipped
+// and reported in RestoreResult.Empty.
+// Returns the restore summary or an error if reading or uploading fails.
+func RestoreBackup(ctx context.Context, fsys fs.FS, bucketUuid string) (RestoreResult, error) {
+	if bucketUuid == "" {
+		return RestoreResult{}, &StorageError{
+			Code:    ErrCodeInvalidInput,
+			Message: "bucket UUID cannot be empty",
+		}
+	}
+
+	manifest, err := ReadBackupManifest(fsys)
+	if err != nil {
+		return RestoreResult{}, err
+	}
+
+	var result RestoreResult
+	batch := make([]FileSource, 0, uploadBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if _, err := UploadSessionSources(ctx, "/storage/buckets/"+bucketUuid+"/upload", batch); err != nil {
+			return err
+		}
+		result.Uploaded += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for _, entry := range manifest.Files {
+		info, err := fs.Stat(fsys, entry.Path)
+		if err != nil {
+			return result, &StorageError{
+				Code:    500,
+				Message: fmt.Sprintf("failed to read backup file %s", entry.Path),
+				Err:     err,
+			}
+		}
+		if info.Size() == 0 {
+			result.Empty = append(result.Empty, entry)
+			continue
+		}
+
+		metadata := FileMetadata{
+			FileName:    path.Base(entry.Path),
+			ContentType: entry.File.ContentType,
+		}
+		if dir := path.Dir(entry.Path); dir != "." {
+			metadata.Path = dir + "/"
+		}
+		batch = append(batch, FileSource{
+			Metadata: metadata,
+			Size:     info.Size(),
+			Open: func() (io.ReadCloser, error) {
+				return fsys.Open(entry.Path)
+			},
+		})
+
+		if len(batch) == uploadBatchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// backupFS is an in-memory file system holding the files of an archive backup, keyed by path.
+// It supports opening and reading files but not listing directories.
+type backupFS map[string][]byte
+
+func (f backupFS) Open(name string) (fs.File, error) {
+	data, ok := f[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &backupFile{name: path.Base(name), Reader: bytes.NewReader(data)}, nil
+}
+
+func (f backupFS) ReadFile(name string) ([]byte, error) {
+	data, ok := f[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+	return bytes.Clone(data), nil
+}
+
+// backupFile is an open file of a backupFS. It is its own fs.FileInfo.
+type backupFile struct {
+	name string
+	*bytes.Reader
+}
+
+func (f *backupFile) Stat() (fs.FileInfo, error) { return f, nil }
+func (f *backupFile) Close() error               { return nil }
+func (f *backupFile) Name() string               { return f.name }
+func (f *backupFile) Mode() fs.FileMode          { return 0o444 }
+func (f *backupFile) ModTime() time.Time         { return time.Time{} }
+func (f *backupFile) IsDir() bool                { return false }
+func (f *backupFile) Sys() any                   { return nil }
+
+// ReadTarBackup reads a backup written by NewTarBackup into memory so it can be passed to
+// ReadBackupManifest and RestoreBackup.
+// Returns the files of the backup, or an error if the stream is not a valid tar archive.
+func ReadTarBackup(r io.Reader) (fs.FS, error) {
+	files := backupFS{}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, &StorageError{
+				Code:    500,
+				Message: "failed to read tar backup",
+				Err:     err,
+			}
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, &StorageError{
+				Code:    500,
+				Message: fmt.Sprintf("failed to read tar backup file %s", hdr.Name),
+				Err:     err,
+			}
+		}
+		files[hdr.Name] = data
+	}
+}
+
+// directoryBackup writes a backup to a local directory.
+type directoryBackup struct {
+	dir string
+}
+
+// NewDirectoryBackup returns a BackupWriter that stores files at their paths under dir
+// and writes the manifest to dir/apillon-backup.json. Existing files are overwritten.
+func NewDirectoryBackup(dir string) BackupWriter {
+	return &directoryBackup{dir: dir}
+}
+
+func (b *directoryBackup) WriteFile(entry BackupEntry, content io.Reader) error {
+	target, err := localBackupPath(b.dir, entry.Path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, content); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// holds reports whether the directory still has a copy of entry with the size of its file.
+func (b *directoryBackup) holds(entry BackupEntry) bool {
+	target, err := localBackupPath(b.dir, entry.Path)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(target)
+	return err == nil && info.Mode().IsRegular() && info.Size() == int64(entry.File.Size)
+}
+
+// remove deletes the copy of entry from the directory. A missing copy is not an error.
+func (b *directoryBackup) remove(entry BackupEntry) error {
+	target, err := localBackupPath(b.dir, entry.Path)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (b *directoryBackup) Close(manifest *BackupManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(b.dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(b.dir, BackupManifestName), data, 0o644)
+}
+
+// localBackupPath joins a bucket path onto dir, rejecting paths that would escape it.
+func localBackupPath(dir string, name string) (string, error) {
+	local := filepath.FromSlash(name)
+	if !filepath.IsLocal(local) {
+		return "", fmt.Errorf("unsafe file path %q", name)
+	}
+	return filepath.Join(dir, local), nil
+}
+
+// tarBackup writes a backup as a tar stream.
+type tarBackup struct {
+	tw *tar.Writer
+}
+
+// NewTarBackup returns a BackupWriter that writes files and the manifest as a tar stream to w.
+// The caller is responsible for closing w (and any compression layer) after the export finishes.
+func NewTarBackup(w io.Writer) BackupWriter {
+	return &tarBackup{tw: tar.NewWriter(w)}
+}
+
+func (b *tarBackup) WriteFile(entry BackupEntry, content io.Reader) error {
+	// tar headers need the size up front, so buffer the content
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	return b.writeEntry(entry.Path, data)
+}
+
+func (b *tarBackup) writeEntry(name string, data []byte) error {
+	if !fs.ValidPath(name) {
+		return fmt.Errorf("unsafe file path %q", name)
+	}
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := b.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := b.tw.Write(data)
+	return err
+}
+
+func (b *tarBackup) Close(manifest *BackupManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := b.writeEntry(BackupManifestName, data); err != nil {
+		return err
+	}
+	return b.tw.Close()
+}
+
+// zipBackup writes a backup as a zip archive.
+type zipBackup struct {
+	zw *zip.Writer
+}
+
+// NewZipBackup returns a BackupWriter that writes files and the manifest as a zip archive to w.
+// The resulting archive can be restored with RestoreBackup through zip.NewReader.
+func NewZipBackup(w io.Writer) BackupWriter {
+	return &zipBackup{zw: zip.NewWriter(w)}
+}
+
+func (b *zipBackup) WriteFile(entry BackupEntry, content io.Reader) error {
+	if !fs.ValidPath(entry.Path) {
+		return fmt.Errorf("unsafe file path %q", entry.Path)
+	}
+	f, err := b.zw.Create(entry.Path)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, content)
+	return err
+}
+
+func (b *zipBackup) Close(manifest *BackupManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := b.zw.Create(BackupManifestName)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		return err
+	}
+	return b.zw.Close()
+}
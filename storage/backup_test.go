@@ -0,0 +1,384 @@
+package storage
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Apillon/go-sdk/internal/apitest"
+)
+
+func testBackupManifest() BackupManifest {
+	dir := "images/"
+	return BackupManifest{
+		BucketUUID: "bucket-uuid",
+		Files: []BackupEntry{
+			{Path: "readme.txt", File: FileInfo{Name: "readme.txt", CID: "cid-1", ContentType: "text/plain"}},
+			{Path: "images/logo.svg", File: FileInfo{Name: "logo.svg", Path: &dir, CID: "cid-2", ContentType: "image/svg+xml"}},
+		},
+	}
+}
+
+func writeTestBackup(t *testing.T, w BackupWriter, manifest BackupManifest) {
+	t.Helper()
+	for _, entry := range manifest.Files {
+		if err := w.WriteFile(entry, strings.NewReader("content of "+entry.Path)); err != nil {
+			t.Fatalf("WriteFile(%s) failed: %v", entry.Path, err)
+		}
+	}
+	if err := w.Close(&manifest); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+}
+
+func TestFileInfoFullPath(t *testing.T) {
+	withSlash, withoutSlash, withName := "a/b/", "a/b", "a/b/c.txt"
+	tests := []struct {
+		path *string
+		want string
+	}{
+		{nil, "c.txt"},
+		{&withSlash, "a/b/c.txt"},
+		{&withoutSlash, "a/b/c.txt"},
+		{&withName, "a/b/c.txt"},
+	}
+	for _, tt := range tests {
+		got := FileInfo{Name: "c.txt", Path: tt.path}.FullPath()
+		if got != tt.want {
+			t.Errorf("FullPath() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestDirectoryBackupRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	manifest := testBackupManifest()
+	writeTestBackup(t, NewDirectoryBackup(dir), manifest)
+
+	data, err := os.ReadFile(filepath.Join(dir, "images", "logo.svg"))
+	if err != nil {
+		t.Fatalf("backup file missing: %v", err)
+	}
+	if string(data) != "content of images/logo.svg" {
+		t.Errorf("unexpected file content %q", data)
+	}
+
+	read, err := ReadBackupManifest(os.DirFS(dir))
+	if err != nil {
+		t.Fatalf("ReadBackupManifest failed: %v", err)
+	}
+	if len(read.Files) != 2 || read.Files[1].File.CID != "cid-2" {
+		t.Errorf("unexpected manifest %+v", read)
+	}
+}
+
+func TestDirectoryBackupRejectsEscapingPaths(t *testing.T) {
+	w := NewDirectoryBackup(t.TempDir())
+	if err := w.WriteFile(BackupEntry{Path: "../outside.txt"}, strings.NewReader("x")); err == nil {
+		t.Error("expected an error for a path outside the backup directory")
+	}
+}
+
+func TestTarBackup(t *testing.T) {
+	var buf bytes.Buffer
+	writeTestBackup(t, NewTarBackup(&buf), testBackupManifest())
+
+	var names []string
+	tr := tar.NewReader(&buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("reading tar failed: %v", err)
+		}
+		names = append(names, hdr.Name)
+	}
+
+	want := []string{"readme.txt", "images/logo.svg", BackupManifestName}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("tar entries = %v, want %v", names, want)
+	}
+}
+
+func TestZipBackupManifest(t *testing.T) {
+	var buf bytes.Buffer
+	writeTestBackup(t, NewZipBackup(&buf), testBackupManifest())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("reading zip failed: %v", err)
+	}
+	manifest, err := ReadBackupManifest(zr)
+	if err != nil {
+		t.Fatalf("ReadBackupManifest failed: %v", err)
+	}
+	if manifest.BucketUUID != "bucket-uuid" {
+		t.Errorf("unexpected bucket UUID %q", manifest.BucketUUID)
+	}
+}
+
+func TestCARBackup(t *testing.T) {
+	var buf bytes.Buffer
+	manifest := testBackupManifest()
+	w := NewCARBackup(&buf)
+	for _, entry := range manifest.Files {
+		if err := w.WriteFile(entry, strings.NewReader("content of "+entry.Path)); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+	if err := w.Close(&manifest); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	for _, entry := range manifest.Files {
+		if !strings.HasPrefix(entry.BackupCID, "bafkrei") {
+			t.Errorf("entry %s has unexpected backup CID %q", entry.Path, entry.BackupCID)
+		}
+	}
+
+	r := bytes.NewReader(buf.Bytes())
+	hdrLen, err := binary.ReadUvarint(r)
+	if err != nil {
+		t.Fatalf("reading header length failed: %v", err)
+	}
+	hdr := make([]byte, hdrLen)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		t.Fatalf("reading header failed: %v", err)
+	}
+	if !bytes.Contains(hdr, []byte("roots")) || !bytes.HasSuffix(hdr, []byte("version\x01")) {
+		t.Errorf("unexpected CAR header %x", hdr)
+	}
+
+	sections := 0
+	for r.Len() > 0 {
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			t.Fatalf("reading section length failed: %v", err)
+		}
+		if _, err := r.Seek(int64(n), io.SeekCurrent); err != nil {
+			t.Fatalf("skipping section failed: %v", err)
+		}
+		sections++
+	}
+	// manifest block plus one block per file
+	if sections != 3 {
+		t.Errorf("got %d CAR sections, want 3", sections)
+	}
+}
+
+func TestExportBucketIncremental(t *testing.T) {
+	server := apitest.NewServer(t)
+	readme := server.AddFile("b1", "readme.txt", "v1")
+	server.AddFile("b1", "images/logo.svg", "<svg/>")
+	old := server.AddFile("b1", "old.txt", "old")
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	first, err := ExportBucket(ctx, "b1", NewDirectoryBackup(dir), ExportOptions{})
+	if err != nil {
+		t.Fatalf("ExportBucket failed: %v", err)
+	}
+	if first.Written != 3 || first.Unchanged != 0 {
+		t.Errorf("first export wrote %d and skipped %d files, want 3 and 0", first.Written, first.Unchanged)
+	}
+
+	readme.Content, readme.CID = "v2", apitest.CID("v2")
+	server.AddFile("b1", "images/new.png", "png")
+	if _, err := DeleteFile(ctx, "b1", old.UUID); err != nil {
+		t.Fatal(err)
+	}
+	// A copy that went missing locally is downloaded again even though its CID did not change
+	if err := os.Remove(filepath.Join(dir, "images", "logo.svg")); err != nil {
+		t.Fatal(err)
+	}
+	downloads := server.Count("GET /ipfs/")
+	second, err := ExportBucket(ctx, "b1", NewDirectoryBackup(dir), ExportOptions{Previous: &first.Manifest})
+	if err != nil {
+		t.Fatalf("incremental ExportBucket failed: %v", err)
+	}
+	if second.Written != 3 || second.Unchanged != 0 || server.Count("GET /ipfs/")-downloads != 3 {
+		t.Errorf("incremental export wrote %d and skipped %d files, want 3 and 0", second.Written, second.Unchanged)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "readme.txt")); string(data) != "v2" {
+		t.Errorf("readme.txt was not updated, got %q", data)
+	}
+	if len(second.Removed) != 1 || second.Removed[0].Path != "old.txt" {
+		t.Errorf("removed %+v, want old.txt", second.Removed)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "old.txt")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("copy of deleted file old.txt was kept (%v)", err)
+	}
+	manifest, err := ReadBackupManifest(os.DirFS(dir))
+	if err != nil || len(manifest.Files) != 3 {
+		t.Errorf("unexpected manifest %+v (%v)", manifest, err)
+	}
+
+	third, err := ExportBucket(ctx, "b1", NewDirectoryBackup(dir), ExportOptions{Previous: &manifest})
+	if err != nil || third.Written != 0 || third.Unchanged != 3 {
+		t.Errorf("unchanged export wrote %d and skipped %d files, want 0 and 3 (%v)", third.Written, third.Unchanged, err)
+	}
+
+	var storageErr *StorageError
+	_, err = ExportBucket(ctx, "b1", NewTarBackup(io.Discard), ExportOptions{Previous: &first.Manifest})
+	if !errors.As(err, &storageErr) || storageErr.Code != ErrCodeInvalidInput {
+		t.Errorf("expected invalid input error for an incremental tar export, got %v", err)
+	}
+}
+
+func TestExportBucketRejectsManifestName(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.AddFile("b1", BackupManifestName, "{}")
+	server.AddFile("b1", "docs/"+BackupManifestName, "{}")
+
+	dir := t.TempDir()
+	_, err := ExportBucket(context.Background(), "b1", NewDirectoryBackup(dir), ExportOptions{})
+	var storageErr *StorageError
+	if !errors.As(err, &storageErr) || storageErr.Code != ErrCodeInvalidInput {
+		t.Errorf("expected invalid input error, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, BackupManifestName)); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("export wrote %s (%v)", BackupManifestName, err)
+	}
+}
+
+func TestRestoreBackup(t *testing.T) {
+	skipURLDelay(t)
+	server := apitest.NewServer(t)
+	server.AddFile("b1", "readme.txt", "hello")
+	server.AddFile("b1", "images/logo.svg", "<svg/>")
+	server.AddFile("b1", "empty.txt", "")
+	ctx := context.Background()
+
+	formats := []struct {
+		name   string
+		export func(t *testing.T) fs.FS
+	}{
+		{"directory", func(t *testing.T) fs.FS {
+			dir := t.TempDir()
+			exportTestBucket(t, NewDirectoryBackup(dir))
+			return os.DirFS(dir)
+		}},
+		{"tar", func(t *testing.T) fs.FS {
+			var buf bytes.Buffer
+			exportTestBucket(t, NewTarBackup(&buf))
+			fsys, err := ReadTarBackup(&buf)
+			if err != nil {
+				t.Fatalf("ReadTarBackup failed: %v", err)
+			}
+			return fsys
+		}},
+		{"zip", func(t *testing.T) fs.FS {
+			var buf bytes.Buffer
+			exportTestBucket(t, NewZipBackup(&buf))
+			zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatalf("zip.NewReader failed: %v", err)
+			}
+			return zr
+		}},
+		{"car", func(t *testing.T) fs.FS {
+			var buf bytes.Buffer
+			exportTestBucket(t, NewCARBackup(&buf))
+			fsys, err := ReadCARBackup(&buf)
+			if err != nil {
+				t.Fatalf("ReadCARBackup failed: %v", err)
+			}
+			return fsys
+		}},
+	}
+
+	for _, format := range formats {
+		t.Run(format.name, func(t *testing.T) {
+			bucket := "restored-" + format.name
+			result, err := RestoreBackup(ctx, format.export(t), bucket)
+			if err != nil {
+				t.Fatalf("RestoreBackup failed: %v", err)
+			}
+			if result.Uploaded != 2 || len(result.Empty) != 1 || result.Empty[0].Path != "empty.txt" {
+				t.Errorf("unexpected result %+v", result)
+			}
+
+			var got []string
+			for _, f := range server.Files(bucket) {
+				got = append(got, f.Path()+"="+f.Content)
+			}
+			if want := "images/logo.svg=<svg/>,readme.txt=hello"; strings.Join(got, ",") != want {
+				t.Errorf("restored %v, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestReadCARBackupRejectsCorruptBlock(t *testing.T) {
+	var buf bytes.Buffer
+	writeTestBackup(t, NewCARBackup(&buf), testBackupManifest())
+	data := buf.Bytes()
+	data[len(data)-1] ^= 0xff
+
+	if _, err := ReadCARBackup(bytes.NewReader(data)); err == nil {
+		t.Error("expected an error for a corrupt block")
+	}
+}
+
+func TestReadCARBackupRejectsBadLengths(t *testing.T) {
+	var buf bytes.Buffer
+	writeTestBackup(t, NewCARBackup(&buf), testBackupManifest())
+	valid := buf.Bytes()
+	hdrLen, n := binary.Uvarint(valid)
+	header := valid[:n+int(hdrLen)]
+
+	tests := map[string][]byte{
+		"huge header":       binary.AppendUvarint(nil, 1<<62),
+		"truncated header":  append(binary.AppendUvarint(nil, 1<<20), "roots"...),
+		"huge section":      binary.AppendUvarint(bytes.Clone(header), 1<<40),
+		"truncated section": append(binary.AppendUvarint(bytes.Clone(header), 1<<20), 0x01, 0x55),
+		"truncated length":  append(bytes.Clone(header), 0xff),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ReadCARBackup(bytes.NewReader(data))
+			var storageErr *StorageError
+			if !errors.As(err, &storageErr) {
+				t.Errorf("expected a StorageError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCARBackupRemovesSpoolOnError(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	server := apitest.NewServer(t)
+	server.AddFile("b1", "readme.txt", "hello")
+	server.AddFile("b1", "broken.txt", "broken")
+	server.Mux.HandleFunc("GET /ipfs/"+apitest.CID("broken"), func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gateway error", http.StatusBadGateway)
+	})
+
+	if _, err := ExportBucket(context.Background(), "b1", NewCARBackup(io.Discard), ExportOptions{}); err == nil {
+		t.Fatal("expected the export to fail")
+	}
+	if entries, _ := os.ReadDir(tmp); len(entries) != 0 {
+		t.Errorf("spool file left behind: %v", entries)
+	}
+}
+
+// exportTestBucket exports bucket b1 of the fake API to w.
+func exportTestBucket(t *testing.T, w BackupWriter) {
+	t.Helper()
+	if _, err := ExportBucket(context.Background(), "b1", w, ExportOptions{}); err != nil {
+		t.Fatalf("ExportBucket failed: %v", err)
+	}
+}
@@ -3,9 +3,13 @@ package storage
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/Apillon/go-sdk/internal/apitest"
 )
 
 func TestCreateAndGetBucket(t *testing.T) {
@@ -661,3 +665,34 @@ func TestGetFileDetails(t *testing.T) {
 
 	t.Logf("File details: %+v", fileDetails)
 }
+
+func TestListAllBucketsPages(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.Mux.HandleFunc("GET /storage/buckets/", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var items []BucketItem
+		for i := (page - 1) * limit; i < min(page*limit, 150); i++ {
+			items = append(items, BucketItem{BucketUUID: fmt.Sprintf("b%d", i), Name: fmt.Sprintf("bucket-%d", i)})
+		}
+		apitest.WriteJSON(w, BucketListData{Items: items, Total: 150})
+	})
+
+	buckets, err := ListAllBuckets(context.Background(), "")
+	if err != nil {
+		t.Fatalf("ListAllBuckets failed: %v", err)
+	}
+	if len(buckets) != 150 || buckets[149].Name != "bucket-149" {
+		t.Errorf("got %d buckets, want 150", len(buckets))
+	}
+	if pages := server.Count("GET /storage/buckets/"); pages != 2 {
+		t.Errorf("requested %d pages, want 2", pages)
+	}
+}
+
+func TestDeleteBucketRequiresUUID(t *testing.T) {
+	err := DeleteBucket(context.Background(), "")
+	if storageErr, ok := err.(*StorageError); !ok || storageErr.Code != ErrCodeInvalidInput {
+		t.Errorf("expected ErrCodeInvalidInput, got %v", err)
+	}
+}
@@ -0,0 +1,279 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// Multicodec codes used in CAR backups.
+const (
+	codecRaw    = 0x55
+	codecJSON   = 0x0200
+	hashSHA256  = 0x12
+	cidVersion1 = 1
+)
+
+// maxCARSection is the largest CAR section ReadCARBackup accepts. A section holds one whole file
+// and the backup is read into memory, so a larger length means the backup is corrupt.
+const maxCARSection = 1 << 31
+
+// cidBase32 is the multibase base32 (lowercase, unpadded) encoding used for CIDv1 strings.
+var cidBase32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// carBackup writes a backup as a CARv1 file.
+// Every file is stored as a single raw block and the manifest as a JSON block that is the root of the CAR.
+type carBackup struct {
+	w     io.Writer
+	spool *os.File
+	cids  map[string]string
+}
+
+// NewCARBackup returns a BackupWriter that writes a CARv1 archive to w.
+// Each file becomes one raw block addressed by a CIDv1 (sha2-256), recorded as BackupCID in the manifest.
+// These CIDs differ from the UnixFS CIDs Apillon reports for the files, which are kept in the manifest entries.
+// The manifest itself is stored as a JSON block and used as the CAR root.
+// Blocks are spooled to a temporary file until Close, because the root must be written first.
+func NewCARBackup(w io.Writer) BackupWriter {
+	return &carBackup{w: w, cids: map[string]string{}}
+}
+
+func (b *carBackup) WriteFile(entry BackupEntry, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	if b.spool == nil {
+		if b.spool, err = os.CreateTemp("", "apillon-car-*"); err != nil {
+			return err
+		}
+	}
+
+	cid := newCID(codecRaw, data)
+	if err := writeCARSection(b.spool, cid, data); err != nil {
+		return err
+	}
+	b.cids[entry.Path] = cidString(cid)
+	return nil
+}
+
+func (b *carBackup) Close(manifest *BackupManifest) error {
+	defer b.abort()
+
+	for i := range manifest.Files {
+		if cid, ok := b.cids[manifest.Files[i].Path]; ok {
+			manifest.Files[i].BackupCID = cid
+		}
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	root := newCID(codecJSON, data)
+
+	if err := writeCARHeader(b.w, root); err != nil {
+		return err
+	}
+	if err := writeCARSection(b.w, root, data); err != nil {
+		return err
+	}
+
+	if b.spool == nil {
+		return nil
+	}
+	if _, err := b.spool.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err = io.Copy(b.w, b.spool)
+	return err
+}
+
+// abort removes the spool file.
+func (b *carBackup) abort() {
+	if b.spool == nil {
+		return
+	}
+	b.spool.Close()
+	os.Remove(b.spool.Name())
+	b.spool = nil
+}
+
+// ReadCARBackup reads a backup written by NewCARBackup into memory so it can be passed to
+// ReadBackupManifest and RestoreBackup. Every block is checked against its CID.
+// Returns the files of the backup, or an error if the stream is not a valid CAR backup.
+func ReadCARBackup(r io.Reader) (fs.FS, error) {
+	files, err := readCARBackup(bufio.NewReader(r))
+	if err != nil {
+		return nil, &StorageError{
+			Code:    500,
+			Message: "failed to read CAR backup",
+			Err:     err,
+		}
+	}
+	return files, nil
+}
+
+func readCARBackup(r *bufio.Reader) (backupFS, error) {
+	header, err := readCARSection(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	// NewCARBackup writes the manifest, which is the root, as the first block
+	blocks := map[string][]byte{}
+	var manifestData []byte
+	for {
+		section, err := readCARSection(r)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		cid, data, err := splitCARSection(section)
+		if err != nil {
+			return nil, err
+		}
+		if manifestData == nil {
+			if !bytes.Contains(header, cid) {
+				return nil, errors.New("first block is not the root")
+			}
+			manifestData = data
+			continue
+		}
+		blocks[cidString(cid)] = data
+	}
+	if manifestData == nil {
+		return nil, errors.New("missing manifest block")
+	}
+
+	var manifest BackupManifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, fmt.Errorf("unmarshalling manifest: %w", err)
+	}
+	files := backupFS{BackupManifestName: manifestData}
+	for _, entry := range manifest.Files {
+		if data, ok := blocks[entry.BackupCID]; ok {
+			files[entry.Path] = data
+		}
+	}
+	return files, nil
+}
+
+// readCARSection reads the content of a varint length-prefixed CAR section.
+// Returns io.EOF if r is at the end of the stream, or an error if the length is too large or the section is truncated.
+func readCARSection(r *bufio.Reader) ([]byte, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if n > maxCARSection {
+		return nil, fmt.Errorf("section length %d exceeds the maximum of %d bytes", n, maxCARSection)
+	}
+
+	// Grow the buffer as data arrives instead of trusting n, which may be larger than the stream
+	var section bytes.Buffer
+	if _, err := io.CopyN(&section, r, int64(n)); err != nil {
+		return nil, fmt.Errorf("section of %d bytes ends after %d bytes: %w", n, section.Len(), io.ErrUnexpectedEOF)
+	}
+	return section.Bytes(), nil
+}
+
+// splitCARSection splits a block section into its CIDv1 and data, checking the sha2-256 digest of the data.
+func splitCARSection(section []byte) ([]byte, []byte, error) {
+	r := bytes.NewReader(section)
+	var fields [4]uint64 // version, codec, hash function, digest length
+	for i := range fields {
+		v, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading block CID: %w", err)
+		}
+		fields[i] = v
+	}
+	if fields[0] != cidVersion1 || fields[2] != hashSHA256 || fields[3] != sha256.Size || uint64(r.Len()) < fields[3] {
+		return nil, nil, errors.New("unsupported block CID")
+	}
+
+	cidLen := len(section) - r.Len() + sha256.Size
+	cid, data := section[:cidLen], section[cidLen:]
+	if !bytes.Equal(newCID(fields[1], data), cid) {
+		return nil, nil, fmt.Errorf("block %s does not match its CID", cidString(cid))
+	}
+	return cid, data, nil
+}
+
+// newCID builds a binary CIDv1 with a sha2-256 multihash of data.
+func newCID(codec uint64, data []byte) []byte {
+	sum := sha256.Sum256(data)
+	cid := binary.AppendUvarint(nil, cidVersion1)
+	cid = binary.AppendUvarint(cid, codec)
+	cid = binary.AppendUvarint(cid, hashSHA256)
+	cid = binary.AppendUvarint(cid, uint64(len(sum)))
+	return append(cid, sum[:]...)
+}
+
+// cidString encodes a binary CIDv1 as a base32 multibase string.
+func cidString(cid []byte) string {
+	return "b" + strings.ToLower(cidBase32.EncodeToString(cid))
+}
+
+// writeCARHeader writes a CARv1 header with a single root.
+// The header is the DAG-CBOR map {"roots": [root], "version": 1}.
+func writeCARHeader(w io.Writer, root []byte) error {
+	var hdr bytes.Buffer
+	hdr.WriteByte(0xa2) // map with 2 entries
+	hdr.WriteByte(0x65) // text string, 5 bytes
+	hdr.WriteString("roots")
+	hdr.WriteByte(0x81)         // array with 1 entry
+	hdr.Write([]byte{0xd8, 42}) // tag 42 (CID)
+	// CIDs are byte strings prefixed with the identity multibase (0x00)
+	writeCBORBytesHeader(&hdr, len(root)+1)
+	hdr.WriteByte(0x00)
+	hdr.Write(root)
+	hdr.WriteByte(0x67) // text string, 7 bytes
+	hdr.WriteString("version")
+	hdr.WriteByte(0x01)
+
+	if _, err := w.Write(binary.AppendUvarint(nil, uint64(hdr.Len()))); err != nil {
+		return err
+	}
+	_, err := w.Write(hdr.Bytes())
+	return err
+}
+
+// writeCBORBytesHeader writes the CBOR header of a byte string of length n.
+func writeCBORBytesHeader(buf *bytes.Buffer, n int) {
+	switch {
+	case n < 24:
+		buf.WriteByte(0x40 | byte(n))
+	case n < 256:
+		buf.WriteByte(0x58)
+		buf.WriteByte(byte(n))
+	default:
+		buf.WriteByte(0x59)
+		buf.WriteByte(byte(n >> 8))
+		buf.WriteByte(byte(n))
+	}
+}
+
+// writeCARSection writes a single CAR block section: varint length, CID and data.
+func writeCARSection(w io.Writer, cid []byte, data []byte) error {
+	if _, err := w.Write(binary.AppendUvarint(nil, uint64(len(cid)+len(data)))); err != nil {
+		return err
+	}
+	if _, err := w.Write(cid); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
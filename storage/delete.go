@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Apillon/go-sdk/requests"
+)
+
+// defaultDeleteConcurrency is the number of parallel delete requests used when DeleteOptions.Concurrency is not set.
+const defaultDeleteConcurrency = 4
+
+// ErrDeleteAborted is returned by bulk deletes when DeleteOptions.Confirm declines the delete.
+var ErrDeleteAborted = errors.New("delete aborted")
+
+// Selector reports whether a file should be selected by a bulk operation.
+type Selector func(FileInfo) bool
+
+// MatchGlob selects files whose full path matches a path.Match pattern, e.g. "logs/*.txt".
+// Patterns without a "/" are matched against the file name only.
+func MatchGlob(pattern string) Selector {
+	return func(f FileInfo) bool {
+		target := f.FullPath()
+		if !strings.Contains(pattern, "/") {
+			target = f.Name
+		}
+		ok, err := path.Match(pattern, target)
+		return err == nil && ok
+	}
+}
+
+// MatchPrefix selects files whose full path starts with prefix, e.g. "tmp/".
+func MatchPrefix(prefix string) Selector {
+	return func(f FileInfo) bool {
+		return strings.HasPrefix(f.FullPath(), prefix)
+	}
+}
+
+// OlderThan selects files created more than age ago. Files without a parsable creation time are not selected.
+func OlderThan(age time.Duration) Selector {
+	return func(f FileInfo) bool {
+		created := f.Created()
+		return !created.IsZero() && time.Since(created) > age
+	}
+}
+
+// MatchAll selects files matched by every given selector.
+func MatchAll(selectors ...Selector) Selector {
+	return func(f FileInfo) bool {
+		for _, s := range selectors {
+			if !s(f) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// DeleteStatus describes the outcome of deleting a single file in a bulk delete.
+type DeleteStatus int
+
+const (
+	// DeleteDeleted means the file was deleted.
+	DeleteDeleted DeleteStatus = iota
+	// DeletePlanned means the file matched but was not deleted because of a dry run.
+	DeletePlanned
+	// DeleteAlreadyGone means the API reported the file, or the directory holding it, as already deleted.
+	DeleteAlreadyGone
+	// DeleteFailed means the delete request failed; see DeleteResult.Err.
+	DeleteFailed
+	// DeleteInProgress means the directory holding the file is already marked for deletion.
+	DeleteInProgress
+)
+
+func (s DeleteStatus) String() string {
+	switch s {
+	case DeleteDeleted:
+		return "deleted"
+	case DeletePlanned:
+		return "planned"
+	case DeleteAlreadyGone:
+		return "already gone"
+	case DeleteFailed:
+		return "failed"
+	case DeleteInProgress:
+		return "deleting"
+	default:
+		return fmt.Sprintf("DeleteStatus(%d)", int(s))
+	}
+}
+
+// DeleteResult reports the outcome of deleting a single file.
+type DeleteResult struct {
+	File   FileInfo     // File that matched the selector
+	Status DeleteStatus // Outcome of the delete
+	Err    error        // Error for failed deletes
+}
+
+// DeleteOptions configures DeleteMatching.
+type DeleteOptions struct {
+	// Concurrency is the maximum number of parallel delete requests. Defaults to 4.
+	Concurrency int
+	// DryRun reports the matching files with DeletePlanned without deleting anything.
+	DryRun bool
+	// Confirm, if set, is called with all matching files before anything is deleted.
+	// Returning false aborts the delete: the files are returned as DeletePlanned with ErrDeleteAborted.
+	Confirm func(files []FileInfo) bool
+}
+
+// DeleteMatching deletes every file in a bucket selected by selector, e.g. MatchPrefix("tmp/").
+// Files already deleted, or inside a directory that is deleted or being deleted, are reported as
+// DeleteAlreadyGone or DeleteInProgress rather than failures.
+// Returns one result per matching file, or an error if listing the bucket fails.
+// If opts.Confirm declines, the results are returned with ErrDeleteAborted.
+func DeleteMatching(ctx context.Context, bucketUuid string, selector Selector, opts DeleteOptions) ([]DeleteResult, error) {
+	if selector == nil {
+		return nil, &StorageError{
+			Code:    ErrCodeInvalidInput,
+			Message: "selector cannot be nil",
+		}
+	}
+
+	files, err := ListAllFilesInBucket(ctx, bucketUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []FileInfo
+	for _, file := range files {
+		if selector(file) {
+			matched = append(matched, file)
+		}
+	}
+
+	return deleteFiles(ctx, bucketUuid, matched, opts)
+}
+
+// deleteFiles deletes files with bounded concurrency, honouring the dry run and confirm options.
+// Returns ErrDeleteAborted with the planned results if the delete was not confirmed.
+func deleteFiles(ctx context.Context, bucketUuid string, files []FileInfo, opts DeleteOptions) ([]DeleteResult, error) {
+	results := make([]DeleteResult, len(files))
+	for i, file := range files {
+		results[i] = DeleteResult{File: file, Status: DeletePlanned}
+	}
+	if len(files) == 0 || opts.DryRun {
+		return results, nil
+	}
+	if opts.Confirm != nil && !opts.Confirm(files) {
+		return results, ErrDeleteAborted
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultDeleteConcurrency
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(r *DeleteResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			_, err := DeleteFile(ctx, bucketUuid, r.File.FileUUID)
+			switch {
+			case err == nil:
+				r.Status = DeleteDeleted
+			case errorCode(err) == ErrCodeDirectoryDeleting:
+				r.Status = DeleteInProgress
+			case isAlreadyDeleted(err):
+				r.Status = DeleteAlreadyGone
+			default:
+				r.Status = DeleteFailed
+				r.Err = err
+			}
+		}(&results[i])
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// isAlreadyDeleted reports whether err means the target no longer exists or is already being deleted.
+// Deleting a file inside a directory that is being deleted, or was, returns the directory codes.
+// Only the API's not-found and deleting codes count; other 404 responses, such as an unknown bucket, are failures.
+func isAlreadyDeleted(err error) bool {
+	switch errorCode(err) {
+	case ErrCodeFileNotFound, ErrCodeDirectoryNotFound, ErrCodeDirectoryDeleting:
+		return true
+	}
+	return false
+}
+
+// errorCode returns the Apillon error code of err: the code of a StorageError raised for a known API state,
+// or else the code of the API error it wraps. Returns 0 if err carries no code.
+func errorCode(err error) int {
+	var storageErr *StorageError
+	if errors.As(err, &storageErr) && storageErr.Code != 500 && storageErr.Code != ErrCodeInvalidInput {
+		return storageErr.Code
+	}
+	var apiErr *requests.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return 0
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Apillon/go-sdk/internal/apitest"
+	"github.com/Apillon/go-sdk/requests"
+)
+
+func TestSelectors(t *testing.T) {
+	dir := "logs/"
+	old := Time{Time: time.Now().Add(-48 * time.Hour)}
+	file := FileInfo{Name: "app.txt", Path: &dir, Timestamps: Timestamps{CreateTime: old}}
+
+	tests := []struct {
+		name     string
+		selector Selector
+		want     bool
+	}{
+		{"glob on path", MatchGlob("logs/*.txt"), true},
+		{"glob on name", MatchGlob("*.txt"), true},
+		{"glob mismatch", MatchGlob("tmp/*"), false},
+		{"prefix", MatchPrefix("logs/"), true},
+		{"prefix mismatch", MatchPrefix("tmp/"), false},
+		{"older than", OlderThan(24 * time.Hour), true},
+		{"not older than", OlderThan(72 * time.Hour), false},
+		{"all", MatchAll(MatchPrefix("logs/"), OlderThan(time.Hour)), true},
+		{"all mismatch", MatchAll(MatchPrefix("logs/"), MatchGlob("*.json")), false},
+	}
+	for _, tt := range tests {
+		if got := tt.selector(file); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDeleteFilesDryRunAndConfirm(t *testing.T) {
+	files := []FileInfo{{FileUUID: "a"}, {FileUUID: "b"}}
+
+	results, err := deleteFiles(context.Background(), "bucket", files, DeleteOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	for _, r := range results {
+		if r.Status != DeletePlanned {
+			t.Errorf("dry run: file %s has status %s", r.File.FileUUID, r.Status)
+		}
+	}
+
+	var confirmed []FileInfo
+	results, err = deleteFiles(context.Background(), "bucket", files, DeleteOptions{
+		Confirm: func(files []FileInfo) bool {
+			confirmed = files
+			return false
+		},
+	})
+	if !errors.Is(err, ErrDeleteAborted) {
+		t.Errorf("declined confirm returned %v, want ErrDeleteAborted", err)
+	}
+	if len(confirmed) != 2 {
+		t.Errorf("confirm callback got %d files, want 2", len(confirmed))
+	}
+	for _, r := range results {
+		if r.Status != DeletePlanned {
+			t.Errorf("declined confirm: file %s has status %s", r.File.FileUUID, r.Status)
+		}
+	}
+}
+
+func TestIsAlreadyDeleted(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"file not found", &StorageError{Code: 500, Err: &requests.APIError{Status: 404, Code: ErrCodeFileNotFound}}, true},
+		{"wrapped directory deleting", &StorageError{Code: 500, Err: &requests.APIError{Status: 400, Code: ErrCodeDirectoryDeleting}}, true},
+		{"directory not found", &StorageError{Code: ErrCodeDirectoryNotFound}, true},
+		{"wrapped directory not found", &StorageError{Code: 500, Err: &requests.APIError{Status: 404, Code: ErrCodeDirectoryNotFound}}, true},
+		{"other not found", &StorageError{Code: 500, Err: &requests.APIError{Status: 404, Code: 40406001}}, false},
+		{"not found without code", &StorageError{Code: 500, Err: &requests.APIError{Status: 404}}, false},
+		{"server error", &StorageError{Code: 500, Err: &requests.APIError{Status: 500}}, false},
+	}
+	for _, tt := range tests {
+		if got := isAlreadyDeleted(tt.err); got != tt.want {
+			t.Errorf("%s: isAlreadyDeleted = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDeleteMatchingInsideDeletedDirectory(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.AddFile("b1", "tmp/a.txt", "a")
+	deleting := server.AddFile("b1", "tmp/old/b.txt", "b")
+	gone := server.AddFile("b1", "tmp/gone/c.txt", "c")
+	server.AddFile("b1", "keep.txt", "keep")
+	server.ReplyError("DELETE /storage/buckets/b1/files/"+deleting.UUID, http.StatusBadRequest, ErrCodeDirectoryDeleting, "DIRECTORY_ALREADY_MARKED_FOR_DELETION")
+	server.ReplyError("DELETE /storage/buckets/b1/files/"+gone.UUID, http.StatusNotFound, ErrCodeDirectoryNotFound, "DIRECTORY_NOT_FOUND")
+
+	results, err := DeleteMatching(context.Background(), "b1", MatchPrefix("tmp/"), DeleteOptions{})
+	if err != nil {
+		t.Fatalf("DeleteMatching failed: %v", err)
+	}
+	got := map[string]DeleteStatus{}
+	for _, r := range results {
+		if r.Err != nil {
+			t.Errorf("%s: unexpected error %v", r.File.FullPath(), r.Err)
+		}
+		got[r.File.FullPath()] = r.Status
+	}
+	want := map[string]DeleteStatus{"tmp/a.txt": DeleteDeleted, "tmp/old/b.txt": DeleteInProgress, "tmp/gone/c.txt": DeleteAlreadyGone}
+	if !maps.Equal(got, want) {
+		t.Errorf("statuses %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,184 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// ReadDirectory walks dir and returns every regular file in it as a WholeFile ready for upload.
+// File paths relative to dir are kept in FileMetadata.Path and content types are detected from
+// the file extension, falling back to content sniffing. Empty files are skipped because they
+// cannot be uploaded through a signed URL.
+func ReadDirectory(dir string) ([]WholeFile, error) {
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return nil, &StorageError{
+			Code:    ErrCodeInvalidInput,
+			Message: "path is not a readable directory: " + dir,
+			Err:     err,
+		}
+	}
+
+	var files []WholeFile
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if len(content) == 0 {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		metadata := FileMetadata{
+			FileName:    path.Base(rel),
+			ContentType: DetectContentType(rel, content),
+		}
+		if d := path.Dir(rel); d != "." {
+			metadata.Path = d + "/"
+		}
+		files = append(files, WholeFile{Metadata: metadata, Content: string(content)})
+		return nil
+	})
+	if err != nil {
+		return nil, &StorageError{
+			Code:    500,
+			Message: "failed to read directory " + dir,
+			Err:     err,
+		}
+	}
+
+	return files, nil
+}
+
+// WalkDirectory walks dir and returns every regular file in it as a FileSource that opens the file
+// only when it is uploaded, so large directories do not need to fit in memory. Paths and content types
+// are set as in ReadDirectory. Empty files cannot be uploaded through a signed URL; they are left out
+// and their paths relative to dir are returned in empty.
+func WalkDirectory(dir string) (files []FileSource, empty []string, err error) {
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return nil, nil, &StorageError{
+			Code:    ErrCodeInvalidInput,
+			Message: "path is not a readable directory: " + dir,
+			Err:     err,
+		}
+	}
+
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.Size() == 0 {
+			empty = append(empty, rel)
+			return nil
+		}
+
+		contentType, err := detectFileContentType(p)
+		if err != nil {
+			return err
+		}
+		metadata := FileMetadata{
+			FileName:    path.Base(rel),
+			ContentType: contentType,
+		}
+		if d := path.Dir(rel); d != "." {
+			metadata.Path = d + "/"
+		}
+		files = append(files, FileSource{
+			Metadata: metadata,
+			Size:     info.Size(),
+			Open: func() (io.ReadCloser, error) {
+				return os.Open(p)
+			},
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, &StorageError{
+			Code:    500,
+			Message: "failed to read directory " + dir,
+			Err:     err,
+		}
+	}
+
+	return files, empty, nil
+}
+
+// detectFileContentType is DetectContentType for a local file, reading only the bytes needed for sniffing.
+func detectFileContentType(name string) (string, error) {
+	if byExt := mime.TypeByExtension(path.Ext(name)); byExt != "" {
+		return byExt, nil
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	head := make([]byte, 512) // http.DetectContentType considers at most 512 bytes
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", err
+	}
+	return http.DetectContentType(head[:n]), nil
+}
+
+// UploadDirectory streams every file in dir to a bucket in one upload session, keeping relative paths.
+// Empty files are skipped (see WalkDirectory).
+// Returns the final API response or an error.
+func UploadDirectory(ctx context.Context, bucketUuid string, dir string) (string, error) {
+	if bucketUuid == "" {
+		return "", &StorageError{
+			Code:    ErrCodeInvalidInput,
+			Message: "bucket UUID cannot be empty",
+		}
+	}
+
+	files, _, err := WalkDirectory(dir)
+	if err != nil {
+		return "", err
+	}
+	return UploadSessionSources(ctx, "/storage/buckets/"+bucketUuid+"/upload", files)
+}
+
+// DetectContentType guesses the MIME type of a file from its extension or, failing that, its content.
+func DetectContentType(name string, content []byte) string {
+	if byExt := mime.TypeByExtension(path.Ext(name)); byExt != "" {
+		return byExt
+	}
+	return http.DetectContentType(content)
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadDirectory(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("index.html", "<html></html>")
+	write("assets/css/site.css", "body{}")
+	write("empty.txt", "")
+
+	files, err := ReadDirectory(dir)
+	if err != nil {
+		t.Fatalf("ReadDirectory failed: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("got %d files, want 2 (empty files are skipped)", len(files))
+	}
+
+	byName := map[string]FileMetadata{}
+	for _, f := range files {
+		byName[f.Metadata.FileName] = f.Metadata
+	}
+	if css := byName["site.css"]; css.Path != "assets/css/" || !strings.HasPrefix(css.ContentType, "text/css") {
+		t.Errorf("unexpected metadata for site.css: %+v", css)
+	}
+	if html := byName["index.html"]; html.Path != "" || !strings.HasPrefix(html.ContentType, "text/html") {
+		t.Errorf("unexpected metadata for index.html: %+v", html)
+	}
+
+	if _, err := ReadDirectory(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
+
+func TestWalkDirectory(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("index.html", "<html></html>")
+	write("assets/data", "<!DOCTYPE html><p>sniffed</p>")
+	write(".nojekyll", "")
+
+	files, empty, err := WalkDirectory(dir)
+	if err != nil {
+		t.Fatalf("WalkDirectory failed: %v", err)
+	}
+	if len(empty) != 1 || empty[0] != ".nojekyll" {
+		t.Errorf("empty files %v, want [.nojekyll]", empty)
+	}
+	if len(files) != 2 {
+		t.Fatalf("got %d files, want 2", len(files))
+	}
+
+	byName := map[string]FileSource{}
+	for _, f := range files {
+		byName[f.Metadata.FileName] = f
+	}
+	data := byName["data"]
+	if data.Metadata.Path != "assets/" || !strings.HasPrefix(data.Metadata.ContentType, "text/html") || data.Size != 29 {
+		t.Errorf("unexpected source for data: %+v", data)
+	}
+	body, err := data.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	if content, _ := io.ReadAll(body); string(content) != "<!DOCTYPE html><p>sniffed</p>" {
+		t.Errorf("data opened with %q", content)
+	}
+
+	if _, _, err := WalkDirectory(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
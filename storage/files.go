@@ -4,6 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/Apillon/go-sdk/requests"
 )
@@ -22,13 +26,21 @@ func (e *StorageError) Error() string {
 	return fmt.Sprintf("storage error (code %d): %s", e.Code, e.Message)
 }
 
+func (e *StorageError) Unwrap() error {
+	return e.Err
+}
+
 // Error codes
 const (
 	ErrCodeDirectoryNotFound = 40406003
+	ErrCodeFileNotFound      = 40406005
 	ErrCodeDirectoryDeleting = 40006007
 	ErrCodeInvalidInput      = 40000001
 )
 
+// listPageSize is the page size used when paging through bucket files.
+const listPageSize = 100
+
 // GetBucketContent retrieves the raw content of a storage bucket by its UUID.
 // Returns the raw response as a string, or an error if the request fails.
 func GetBucketContent(ctx context.Context, bucketUuid string) (string, error) {
@@ -52,6 +64,81 @@ func GetBucketContent(ctx context.Context, bucketUuid string) (string, error) {
 	return res, nil
 }
 
+// ListBucketContent lists the directories and files directly inside a bucket directory.
+// An empty directoryUuid lists the bucket root.
+// Returns a ListBucketContentResponse struct or an error if the request or unmarshalling fails.
+func ListBucketContent(ctx context.Context, bucketUuid string, directoryUuid string) (ListBucketContentResponse, error) {
+	if bucketUuid == "" {
+		return ListBucketContentResponse{}, &StorageError{
+			Code:    ErrCodeInvalidInput,
+			Message: "bucket UUID cannot be empty",
+		}
+	}
+
+	params := map[string]string{}
+	if directoryUuid != "" {
+		params["directoryUuid"] = directoryUuid
+	}
+
+	res, err := requests.GetReq(ctx, "/storage/buckets/"+bucketUuid+"/content", params)
+	if err != nil {
+		return ListBucketContentResponse{}, &StorageError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to list content of bucket %s", bucketUuid),
+			Err:     err,
+		}
+	}
+
+	var content ListBucketContentResponse
+	if err := json.Unmarshal([]byte(res), &content); err != nil {
+		return ListBucketContentResponse{}, &StorageError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to unmarshal bucket content response for bucket %s", bucketUuid),
+			Err:     err,
+		}
+	}
+
+	return content, nil
+}
+
+// listAllContent pages through every item directly inside a bucket directory.
+// An empty directoryUuid lists the bucket root.
+func listAllContent(ctx context.Context, bucketUuid string, directoryUuid string) ([]ContentItem, error) {
+	path := "/storage/buckets/" + bucketUuid + "/content"
+	var items []ContentItem
+	for page := 1; ; page++ {
+		params := map[string]string{
+			"page":  strconv.Itoa(page),
+			"limit": strconv.Itoa(listPageSize),
+		}
+		if directoryUuid != "" {
+			params["directoryUuid"] = directoryUuid
+		}
+		res, err := requests.GetReq(ctx, path, params)
+		if err != nil {
+			return nil, &StorageError{
+				Code:    500,
+				Message: fmt.Sprintf("failed to list content of bucket %s (page %d)", bucketUuid, page),
+				Err:     err,
+			}
+		}
+
+		var content ListBucketContentResponse
+		if err := json.Unmarshal([]byte(res), &content); err != nil {
+			return nil, &StorageError{
+				Code:    500,
+				Message: fmt.Sprintf("failed to unmarshal bucket content response for bucket %s (page %d)", bucketUuid, page),
+				Err:     err,
+			}
+		}
+
+		items = append(items, content.Data.Items...)
+		if len(content.Data.Items) == 0 || len(items) >= content.Data.Total {
+			return items, nil
+		}
+	}
+}
+
 // ListFilesInBucket lists all files in a given bucket by its UUID.
 // Returns a ListFilesResponse struct or an error if the request or unmarshalling fails.
 func ListFilesInBucket(ctx context.Context, bucketUuid string) (ListFilesResponse, error) {
@@ -84,6 +171,105 @@ func ListFilesInBucket(ctx context.Context, bucketUuid string) (ListFilesRespons
 	return fileList, nil
 }
 
+// ListAllFilesInBucket lists every file in a given bucket, following pagination until all files are collected.
+// Returns the files or an error if any page request or unmarshalling fails.
+func ListAllFilesInBucket(ctx context.Context, bucketUuid string) ([]FileInfo, error) {
+	if bucketUuid == "" {
+		return nil, &StorageError{
+			Code:    ErrCodeInvalidInput,
+			Message: "bucket UUID cannot be empty",
+		}
+	}
+
+	path := "/storage/buckets/" + bucketUuid + "/files"
+	var files []FileInfo
+	for page := 1; ; page++ {
+		params := map[string]string{
+			"page":  strconv.Itoa(page),
+			"limit": strconv.Itoa(listPageSize),
+		}
+		res, err := requests.GetReq(ctx, path, params)
+		if err != nil {
+			return nil, &StorageError{
+				Code:    500,
+				Message: fmt.Sprintf("failed to list files in bucket %s (page %d)", bucketUuid, page),
+				Err:     err,
+			}
+		}
+
+		var fileList ListFilesResponse
+		if err := json.Unmarshal([]byte(res), &fileList); err != nil {
+			return nil, &StorageError{
+				Code:    500,
+				Message: fmt.Sprintf("failed to unmarshal list files response for bucket %s (page %d)", bucketUuid, page),
+				Err:     err,
+			}
+		}
+
+		files = append(files, fileList.Data.Items...)
+		if len(fileList.Data.Items) == 0 || len(files) >= fileList.Data.Total {
+			return files, nil
+		}
+	}
+}
+
+// FullPath returns the path of the file inside its bucket, including the file name.
+// Files in the bucket root return just their name.
+func (f FileInfo) FullPath() string {
+	if f.Path == nil || *f.Path == "" {
+		return f.Name
+	}
+	dir := *f.Path
+	if strings.HasSuffix(dir, "/") {
+		return dir + f.Name
+	}
+	// Some responses already include the file name in the path
+	if dir == f.Name || strings.HasSuffix(dir, "/"+f.Name) {
+		return dir
+	}
+	return dir + "/" + f.Name
+}
+
+// DownloadFile opens the content behind a file link (for example FileInfo.Link) using HTTP GET.
+// The caller must close the returned reader.
+func DownloadFile(ctx context.Context, link string) (io.ReadCloser, error) {
+	if link == "" {
+		return nil, &StorageError{
+			Code:    ErrCodeInvalidInput,
+			Message: "file link cannot be empty",
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, &StorageError{
+			Code:    500,
+			Message: "failed to create download request",
+			Err:     err,
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, &StorageError{
+			Code:    500,
+			Message: "failed to download file",
+			Err:     err,
+		}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, &StorageError{
+			Code:    resp.StatusCode,
+			Message: fmt.Sprintf("download failed: %s", string(bodyBytes)),
+		}
+	}
+
+	return resp.Body, nil
+}
+
 // GetFileDetails retrieves details for a specific file in a bucket using their UUIDs.
 // Returns a FileDetails struct or an error if the request or unmarshalling fails.
 func GetFileDetails(ctx context.Context, bucketUuid string, fileUuid string) (FileDetails, error) {
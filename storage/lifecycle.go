@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LifecycleRule describes which files in a bucket expire.
+// A rule applies to files whose full path starts with Prefix (all files if empty)
+// and must set MaxAge, KeepLast or both.
+type LifecycleRule struct {
+	Name     string        // Name of the rule, used in reports
+	Prefix   string        // Path prefix the rule applies to, e.g. "tmp/"
+	MaxAge   time.Duration // Files created longer ago than MaxAge expire
+	KeepLast int           // Only the KeepLast newest files under Prefix are kept
+}
+
+// validate checks that the rule defines at least one expiry condition.
+func (r LifecycleRule) validate() error {
+	if r.MaxAge < 0 || r.KeepLast < 0 {
+		return &StorageError{
+			Code:    ErrCodeInvalidInput,
+			Message: fmt.Sprintf("lifecycle rule %q has a negative MaxAge or KeepLast", r.Name),
+		}
+	}
+	if r.MaxAge == 0 && r.KeepLast == 0 {
+		return &StorageError{
+			Code:    ErrCodeInvalidInput,
+			Message: fmt.Sprintf("lifecycle rule %q must set MaxAge or KeepLast", r.Name),
+		}
+	}
+	return nil
+}
+
+// LifecycleAction is a file that expired under a rule.
+type LifecycleAction struct {
+	File   FileInfo // Expired file
+	Rule   string   // Name of the first rule that expired the file
+	Reason string   // Human-readable reason, e.g. "older than 168h0m0s"
+}
+
+// LifecycleReport is the outcome of applying lifecycle rules to a bucket.
+type LifecycleReport struct {
+	Actions []LifecycleAction // Files that expired
+	Results []DeleteResult    // Delete outcome per expired file, in the same order as Actions
+}
+
+// LifecycleOptions configures ApplyLifecycle and RunLifecycle.
+type LifecycleOptions struct {
+	// ReportOnly evaluates the rules without deleting anything.
+	ReportOnly bool
+	// Delete configures the bulk delete used to remove expired files.
+	Delete DeleteOptions
+}
+
+// EvaluateLifecycle returns the files that expire under the given rules at time now.
+// Files are evaluated on their creation time; files without a parsable creation time never expire.
+// A file matched by several rules is reported once, under the first matching rule, and files
+// expired by an earlier rule do not count towards the KeepLast of later rules.
+func EvaluateLifecycle(files []FileInfo, rules []LifecycleRule, now time.Time) ([]LifecycleAction, error) {
+	for _, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	var actions []LifecycleAction
+	expired := map[string]bool{}
+	expire := func(file FileInfo, rule LifecycleRule, reason string) {
+		if expired[file.FileUUID] {
+			return
+		}
+		expired[file.FileUUID] = true
+		actions = append(actions, LifecycleAction{File: file, Rule: rule.Name, Reason: reason})
+	}
+
+	for _, rule := range rules {
+		var matched []FileInfo
+		for _, file := range files {
+			if strings.HasPrefix(file.FullPath(), rule.Prefix) && !file.Created().IsZero() && !expired[file.FileUUID] {
+				matched = append(matched, file)
+			}
+		}
+
+		// Newest first, so KeepLast keeps the head of the list
+		SortFilesBy(matched, Descending(ByCreateTime))
+
+		for i, file := range matched {
+			switch {
+			case rule.MaxAge > 0 && now.Sub(file.Created()) > rule.MaxAge:
+				expire(file, rule, fmt.Sprintf("older than %s", rule.MaxAge))
+			case rule.KeepLast > 0 && i >= rule.KeepLast:
+				expire(file, rule, fmt.Sprintf("not among the %d newest files", rule.KeepLast))
+			}
+		}
+	}
+
+	return actions, nil
+}
+
+// ApplyLifecycle evaluates the rules against every file in a bucket and deletes the expired files
+// through the bulk delete path, unless opts.ReportOnly is set.
+// Returns the report or an error if the rules are invalid or listing the bucket fails, or the report
+// with ErrDeleteAborted if opts.Delete.Confirm declines the delete.
+func ApplyLifecycle(ctx context.Context, bucketUuid string, rules []LifecycleRule, opts LifecycleOptions) (LifecycleReport, error) {
+	if len(rules) == 0 {
+		return LifecycleReport{}, &StorageError{
+			Code:    ErrCodeInvalidInput,
+			Message: "no lifecycle rules provided",
+		}
+	}
+
+	files, err := ListAllFilesInBucket(ctx, bucketUuid)
+	if err != nil {
+		return LifecycleReport{}, err
+	}
+
+	actions, err := EvaluateLifecycle(files, rules, time.Now())
+	if err != nil {
+		return LifecycleReport{}, err
+	}
+
+	expired := make([]FileInfo, len(actions))
+	for i, action := range actions {
+		expired[i] = action.File
+	}
+
+	deleteOpts := opts.Delete
+	deleteOpts.DryRun = deleteOpts.DryRun || opts.ReportOnly
+
+	results, err := deleteFiles(ctx, bucketUuid, expired, deleteOpts)
+	return LifecycleReport{
+		Actions: actions,
+		Results: results,
+	}, err
+}
+
+// RunLifecycle applies the rules to a bucket immediately and then every interval until ctx is cancelled.
+// onReport, if set, receives the report or error of every run; failed runs do not stop the loop.
+// Returns the context error once ctx is done.
+func RunLifecycle(ctx context.Context, bucketUuid string, rules []LifecycleRule, interval time.Duration, opts LifecycleOptions, onReport func(LifecycleReport, error)) error {
+	if interval <= 0 {
+		return &StorageError{
+			Code:    ErrCodeInvalidInput,
+			Message: "lifecycle interval must be positive",
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := ApplyLifecycle(ctx, bucketUuid, rules, opts)
+		if onReport != nil {
+			onReport(report, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvaluateLifecycle(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	tmp, builds := "tmp/", "builds/"
+	file := func(uuid string, dir *string, age time.Duration) FileInfo {
+		return FileInfo{
+			FileUUID:   uuid,
+			Name:       uuid + ".bin",
+			Path:       dir,
+			Timestamps: Timestamps{CreateTime: Time{Time: now.Add(-age)}},
+		}
+	}
+	files := []FileInfo{
+		file("tmp-old", &tmp, 10*24*time.Hour),
+		file("tmp-new", &tmp, time.Hour),
+		file("build-1", &builds, 3*time.Hour),
+		file("build-2", &builds, 2*time.Hour),
+		file("build-3", &builds, 1*time.Hour),
+		file("root", nil, 100*24*time.Hour),
+		{FileUUID: "tmp-undated", Name: "x", Path: &tmp},
+	}
+
+	rules := []LifecycleRule{
+		{Name: "tmp", Prefix: "tmp/", MaxAge: 7 * 24 * time.Hour},
+		{Name: "builds", Prefix: "builds/", KeepLast: 2},
+	}
+	actions, err := EvaluateLifecycle(files, rules, now)
+	if err != nil {
+		t.Fatalf("EvaluateLifecycle failed: %v", err)
+	}
+
+	got := map[string]string{}
+	for _, a := range actions {
+		got[a.File.FileUUID] = a.Rule
+	}
+	want := map[string]string{"tmp-old": "tmp", "build-1": "builds"}
+	if len(got) != len(want) {
+		t.Fatalf("expired %v, want %v", got, want)
+	}
+	for uuid, rule := range want {
+		if got[uuid] != rule {
+			t.Errorf("file %s expired by %q, want %q", uuid, got[uuid], rule)
+		}
+	}
+}
+
+func TestEvaluateLifecycleOverlappingRules(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	builds := "builds/"
+	file := func(name string, age time.Duration) FileInfo {
+		return FileInfo{
+			FileUUID:   name,
+			Name:       name,
+			Path:       &builds,
+			Timestamps: Timestamps{CreateTime: Time{Time: now.Add(-age)}},
+		}
+	}
+	files := []FileInfo{
+		file("release-1", 4*time.Hour),
+		file("release-2", 3*time.Hour),
+		file("rc-1", 2*time.Hour),
+		file("rc-2", 1*time.Hour),
+	}
+
+	// rc-1 is expired by the first rule, so the second rule keeps rc-2 and release-2
+	rules := []LifecycleRule{
+		{Name: "rc", Prefix: "builds/rc-", KeepLast: 1},
+		{Name: "builds", Prefix: "builds/", KeepLast: 2},
+	}
+	actions, err := EvaluateLifecycle(files, rules, now)
+	if err != nil {
+		t.Fatalf("EvaluateLifecycle failed: %v", err)
+	}
+
+	got := map[string]string{}
+	for _, a := range actions {
+		got[a.File.FileUUID] = a.Rule
+	}
+	want := map[string]string{"rc-1": "rc", "release-1": "builds"}
+	if len(got) != len(want) {
+		t.Fatalf("expired %v, want %v", got, want)
+	}
+	for uuid, rule := range want {
+		if got[uuid] != rule {
+			t.Errorf("file %s expired by %q, want %q", uuid, got[uuid], rule)
+		}
+	}
+}
+
+func TestEvaluateLifecycleRejectsEmptyRule(t *testing.T) {
+	if _, err := EvaluateLifecycle(nil, []LifecycleRule{{Name: "empty"}}, time.Now()); err == nil {
+		t.Error("expected an error for a rule without MaxAge or KeepLast")
+	}
+}
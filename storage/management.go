@@ -3,6 +3,8 @@ package storage
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/Apillon/go-sdk/requests"
@@ -80,3 +82,63 @@ func GetBucket(ctx context.Context, name string) (ListBucketsResponse, error) {
 
 	return bucketList, nil
 }
+
+// ListAllBuckets lists every bucket, optionally filtered by name, following pagination until all buckets are collected.
+// Returns the buckets or an error if any page request or unmarshalling fails.
+func ListAllBuckets(ctx context.Context, name string) ([]BucketItem, error) {
+	var buckets []BucketItem
+	for page := 1; ; page++ {
+		params := map[string]string{
+			"page":  strconv.Itoa(page),
+			"limit": strconv.Itoa(listPageSize),
+		}
+		if name != "" {
+			params["name"] = name
+		}
+		res, err := requests.GetReq(ctx, "/storage/buckets/", params)
+		if err != nil {
+			return nil, &StorageError{
+				Code:    500,
+				Message: fmt.Sprintf("failed to list buckets (page %d)", page),
+				Err:     err,
+			}
+		}
+
+		var bucketList ListBucketsResponse
+		if err := json.Unmarshal([]byte(res), &bucketList); err != nil {
+			return nil, &StorageError{
+				Code:    500,
+				Message: fmt.Sprintf("failed to unmarshal bucket list response (page %d)", page),
+				Err:     err,
+			}
+		}
+
+		buckets = append(buckets, bucketList.Data.Items...)
+		if len(bucketList.Data.Items) == 0 || len(buckets) >= bucketList.Data.Total {
+			return buckets, nil
+		}
+	}
+}
+
+// DeleteBucket deletes a storage bucket by its UUID.
+// Sends a DELETE request to the storage API. The bucket is marked for deletion and removed by the API later.
+// Returns an error if the request fails or the API returns an error.
+func DeleteBucket(ctx context.Context, bucketUuid string) error {
+	if bucketUuid == "" {
+		return &StorageError{
+			Code:    ErrCodeInvalidInput,
+			Message: "bucket UUID cannot be empty",
+		}
+	}
+
+	_, err := requests.DeleteReq(ctx, "/storage/buckets/"+bucketUuid)
+	if err != nil {
+		return &StorageError{
+			Code:    500,
+			Message: "failed to delete bucket",
+			Err:     err,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"fmt"
+	"math"
+)
+
+// ByteSize is a size in bytes. It marshals to JSON as a plain number.
+type ByteSize int64
+
+// String formats the size with binary units, e.g. "512 B", "1.5 KiB" or "2.0 GiB".
+func (s ByteSize) String() string {
+	const unit = 1024
+	if s < unit && s > -unit {
+		return fmt.Sprintf("%d B", int64(s))
+	}
+
+	// int64 tops out at 8 EiB, so the exponent never runs past "E"
+	value := float64(s)
+	exp := 0
+	for value >= unit*unit || value <= -unit*unit {
+		value /= unit
+		exp++
+	}
+	// Pick the unit after rounding, so 1048575 prints as "1.0 MiB" rather than "1024.0 KiB"
+	if math.Abs(math.Round(value/unit*10)/10) >= unit {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", value/unit, "KMGTPE"[exp])
+}
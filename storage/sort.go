@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// FileOrder compares two files for sorting, returning a negative number when a sorts before b,
+// a positive number when a sorts after b and zero when they are equal.
+type FileOrder func(a, b FileInfo) int
+
+// Orders for SortFilesBy.
+var (
+	ByName       FileOrder = func(a, b FileInfo) int { return strings.Compare(a.Name, b.Name) }
+	ByPath       FileOrder = func(a, b FileInfo) int { return strings.Compare(a.FullPath(), b.FullPath()) }
+	BySize       FileOrder = func(a, b FileInfo) int { return cmp.Compare(a.Size, b.Size) }
+	ByCreateTime FileOrder = func(a, b FileInfo) int { return a.Created().Compare(b.Created()) }
+	ByUpdateTime FileOrder = func(a, b FileInfo) int { return a.Updated().Compare(b.Updated()) }
+)
+
+// Descending reverses an order, e.g. Descending(ByCreateTime) sorts the newest files first.
+func Descending(order FileOrder) FileOrder {
+	return func(a, b FileInfo) int { return order(b, a) }
+}
+
+// SortFilesBy sorts files in place by the given orders. Later orders break ties of earlier ones,
+// and files equal under all orders keep their original order.
+func SortFilesBy(files []FileInfo, orders ...FileOrder) {
+	slices.SortStableFunc(files, func(a, b FileInfo) int {
+		for _, order := range orders {
+			if c := order(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
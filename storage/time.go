@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// timeLayouts are the timestamp formats accepted from the API, tried in order.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	time.DateOnly,
+}
+
+// Time is a timestamp returned by the API.
+// It accepts the ISO8601 variants the API uses, and unmarshals null or an empty string to the zero time.
+// A timestamp in any other format also unmarshals to the zero time rather than failing the whole
+// response, and is kept in Raw.
+type Time struct {
+	time.Time
+	Raw string // Unparsed timestamp, set only when its format is not recognized
+}
+
+// ParseTime parses a timestamp in any of the formats returned by the API.
+// Timestamps without a zone are interpreted as UTC.
+func ParseTime(value string) (Time, error) {
+	if value == "" {
+		return Time{}, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return Time{Time: t}, nil
+		}
+	}
+	return Time{}, fmt.Errorf("unsupported timestamp format %q", value)
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = Time{}
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		// Not a string, e.g. a number
+		value = string(data)
+	}
+
+	parsed, err := ParseTime(value)
+	if err != nil {
+		parsed = Time{Raw: value}
+	}
+	*t = parsed
+	return nil
+}
+
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() && t.Raw != "" {
+		return json.Marshal(t.Raw)
+	}
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.UTC().Format(time.RFC3339Nano))
+}
+
+// Created returns the creation time, or the zero time if the API did not return one.
+func (t Timestamps) Created() time.Time {
+	return t.CreateTime.Time
+}
+
+// Updated returns the last update time, or the zero time if the API did not return one.
+func (t Timestamps) Updated() time.Time {
+	return t.UpdateTime.Time
+}
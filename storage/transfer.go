@@ -0,0 +1,354 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// TransferStatus describes the outcome of copying or moving a single file.
+type TransferStatus int
+
+const (
+	// TransferCopied means the file content was streamed through the client into the destination.
+	TransferCopied TransferStatus = iota
+	// TransferUnchanged means the destination already held a file with the same CID at the target path,
+	// so no bytes were transferred.
+	TransferUnchanged
+	// TransferFailed means the file could not be copied; see TransferResult.Err.
+	TransferFailed
+	// TransferEmpty means the source file is empty. Empty files cannot be uploaded through a signed URL,
+	// so it was not copied, and not deleted by moves.
+	TransferEmpty
+)
+
+func (s TransferStatus) String() string {
+	switch s {
+	case TransferCopied:
+		return "copied"
+	case TransferUnchanged:
+		return "unchanged"
+	case TransferFailed:
+		return "failed"
+	case TransferEmpty:
+		return "empty"
+	default:
+		return fmt.Sprintf("TransferStatus(%d)", int(s))
+	}
+}
+
+// TransferResult reports the outcome of a copy or move for a single file.
+type TransferResult struct {
+	Source            FileInfo       // Source file
+	DestinationBucket string         // UUID of the destination bucket
+	DestinationPath   string         // Full path of the file in the destination bucket
+	Status            TransferStatus // Outcome of the copy
+	Deleted           bool           // Whether the source was deleted (moves only)
+	Err               error          // Error for failed copies or deletes
+}
+
+// CopyFile copies a file to dstPath in dstBucketUuid, which may be the same bucket.
+// A dstPath that is empty or ends with "/" keeps the source file name.
+// The Apillon API has no server-side copy, so the file is compared by CID first and only streamed
+// through the client when the destination does not already hold the same content at that path.
+// Empty files are not copied and reported as TransferEmpty.
+// Returns the transfer result, whose Err is also returned when the copy fails.
+func CopyFile(ctx context.Context, srcBucketUuid string, fileUuid string, dstBucketUuid string, dstPath string) (TransferResult, error) {
+	return transferFile(ctx, srcBucketUuid, fileUuid, dstBucketUuid, dstPath, false)
+}
+
+// MoveFile copies a file like CopyFile and then deletes the source.
+// The source is only deleted once the destination file has a CID, so this waits for the upload to be processed.
+func MoveFile(ctx context.Context, srcBucketUuid string, fileUuid string, dstBucketUuid string, dstPath string) (TransferResult, error) {
+	return transferFile(ctx, srcBucketUuid, fileUuid, dstBucketUuid, dstPath, true)
+}
+
+// CopyDirectory copies every file under srcDir in srcBucketUuid to dstDir in dstBucketUuid, keeping relative paths.
+// An empty srcDir copies the whole bucket. Files are streamed in batches of 50, one upload session per batch.
+// Returns one result per file, or an error if listing the buckets fails.
+// Per-file failures are reported in the results and do not stop the copy.
+func CopyDirectory(ctx context.Context, srcBucketUuid string, srcDir string, dstBucketUuid string, dstDir string) ([]TransferResult, error) {
+	return transferDirectory(ctx, srcBucketUuid, srcDir, dstBucketUuid, dstDir, false)
+}
+
+// MoveDirectory copies a directory like CopyDirectory and deletes each source file that was copied successfully,
+// once its copy has a CID. The sources of a batch are deleted before the next batch is copied.
+func MoveDirectory(ctx context.Context, srcBucketUuid string, srcDir string, dstBucketUuid string, dstDir string) ([]TransferResult, error) {
+	return transferDirectory(ctx, srcBucketUuid, srcDir, dstBucketUuid, dstDir, true)
+}
+
+func transferFile(ctx context.Context, srcBucketUuid, fileUuid, dstBucketUuid, dstPath string, move bool) (TransferResult, error) {
+	if srcBucketUuid == "" || fileUuid == "" || dstBucketUuid == "" {
+		return TransferResult{}, &StorageError{
+			Code:    ErrCodeInvalidInput,
+			Message: "source bucket UUID, file UUID and destination bucket UUID cannot be empty",
+		}
+	}
+
+	details, err := GetFileDetails(ctx, srcBucketUuid, fileUuid)
+	if err != nil {
+		return TransferResult{}, err
+	}
+	src := details.Data
+
+	// Bucket paths have no leading "/", so "/a/b" and "a/b" name the same target
+	target := strings.TrimLeft(dstPath, "/")
+	if target == "" || strings.HasSuffix(target, "/") {
+		target += src.Name
+	}
+
+	// Only the target directory needs to be listed
+	list := func(ctx context.Context) ([]FileInfo, error) {
+		return directoryFiles(ctx, dstBucketUuid, path.Dir(target))
+	}
+	results, err := copyFiles(ctx, srcBucketUuid, dstBucketUuid, []FileInfo{src}, []string{target}, list, move)
+	if err != nil {
+		return TransferResult{}, err
+	}
+	return results[0], results[0].Err
+}
+
+func transferDirectory(ctx context.Context, srcBucketUuid, srcDir, dstBucketUuid, dstDir string, move bool) ([]TransferResult, error) {
+	if srcBucketUuid == "" || dstBucketUuid == "" {
+		return nil, &StorageError{
+			Code:    ErrCodeInvalidInput,
+			Message: "source and destination bucket UUIDs cannot be empty",
+		}
+	}
+
+	srcFiles, err := ListAllFilesInBucket(ctx, srcBucketUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []FileInfo
+	var targets []string
+	for _, file := range srcFiles {
+		if target, ok := transferTarget(file.FullPath(), srcDir, dstDir); ok {
+			selected = append(selected, file)
+			targets = append(targets, target)
+		}
+	}
+
+	list := func(ctx context.Context) ([]FileInfo, error) {
+		return ListAllFilesInBucket(ctx, dstBucketUuid)
+	}
+	return copyFiles(ctx, srcBucketUuid, dstBucketUuid, selected, targets, list, move)
+}
+
+// transferTarget maps a source file path under srcDir to the matching path under dstDir.
+// Returns false if the file is not inside srcDir.
+func transferTarget(filePath, srcDir, dstDir string) (string, bool) {
+	srcDir = strings.Trim(srcDir, "/")
+	dstDir = strings.Trim(dstDir, "/")
+	filePath = strings.TrimLeft(filePath, "/")
+
+	rel := filePath
+	if srcDir != "" {
+		if !strings.HasPrefix(filePath, srcDir+"/") {
+			return "", false
+		}
+		rel = strings.TrimPrefix(filePath, srcDir+"/")
+	}
+	if dstDir == "" {
+		return rel, true
+	}
+	return dstDir + "/" + rel, true
+}
+
+// directoryFiles lists the files directly inside dir of a bucket, walking down from the root.
+// An empty dir or "." lists the root. Returns no files if the directory does not exist.
+func directoryFiles(ctx context.Context, bucketUuid string, dir string) ([]FileInfo, error) {
+	if dir == "." {
+		dir = ""
+	}
+	dir = strings.Trim(dir, "/")
+	var names []string
+	if dir != "" {
+		names = strings.Split(dir, "/")
+	}
+
+	directoryUuid := ""
+	for depth := 0; ; depth++ {
+		items, err := listAllContent(ctx, bucketUuid, directoryUuid)
+		if err != nil {
+			return nil, err
+		}
+
+		if depth == len(names) {
+			var prefix *string
+			if dir != "" {
+				p := dir + "/"
+				prefix = &p
+			}
+			var files []FileInfo
+			for _, item := range items {
+				if item.Type == ContentFile {
+					files = append(files, FileInfo{
+						Timestamps:  item.Timestamps,
+						FileUUID:    item.UUID,
+						CID:         item.CID,
+						Name:        item.Name,
+						ContentType: item.ContentType,
+						Path:        prefix,
+						Size:        item.Size,
+						Link:        item.Link,
+					})
+				}
+			}
+			return files, nil
+		}
+
+		directoryUuid = ""
+		for _, item := range items {
+			if item.Type == ContentDirectory && item.Name == names[depth] {
+				directoryUuid = item.UUID
+			}
+		}
+		if directoryUuid == "" {
+			return nil, nil
+		}
+	}
+}
+
+// copyFiles copies each file to the matching target path, skipping files the destination already holds,
+// and deletes copied sources when move is set. Changed files are streamed in batches of
+// uploadBatchSize, one session per batch, and a move deletes the sources of a batch once its copies have a CID.
+// list returns the destination files at the target paths; it is called before copying and, for moves,
+// while waiting for the copies to get a CID.
+// Returns one result per file, or an error if listing the destination fails.
+func copyFiles(ctx context.Context, srcBucketUuid, dstBucketUuid string, files []FileInfo, targets []string, list func(context.Context) ([]FileInfo, error), move bool) ([]TransferResult, error) {
+	dstFiles, err := list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing := FileSnapshot{}
+	for _, file := range dstFiles {
+		existing[file.FullPath()] = append(existing[file.FullPath()], file)
+	}
+
+	results := make([]TransferResult, len(files))
+	var unchanged, changed []int // result indexes
+	for i, file := range files {
+		results[i] = TransferResult{
+			Source:            file,
+			DestinationBucket: dstBucketUuid,
+			DestinationPath:   targets[i],
+		}
+		if srcBucketUuid == dstBucketUuid && file.FullPath() == targets[i] || holdsCID(existing[targets[i]], file.CID) {
+			results[i].Status = TransferUnchanged
+			unchanged = append(unchanged, i)
+		} else {
+			changed = append(changed, i)
+		}
+	}
+
+	for start := 0; start < len(changed); start += uploadBatchSize {
+		batch := changed[start:min(start+uploadBatchSize, len(changed))]
+		copyBatch(ctx, dstBucketUuid, results, batch, list, existing, move)
+		if move {
+			deleteSources(ctx, srcBucketUuid, results, batch)
+		}
+	}
+	if move {
+		deleteSources(ctx, srcBucketUuid, results, unchanged)
+	}
+	return results, nil
+}
+
+// copyBatch streams the sources of the given results to the destination in one upload session, recording
+// the outcome in each result. Each source is downloaded while its copy is uploaded, so no file is held in memory.
+// For moves it then waits until the copies have a CID.
+func copyBatch(ctx context.Context, dstBucketUuid string, results []TransferResult, batch []int, list func(context.Context) ([]FileInfo, error), existing FileSnapshot, move bool) {
+	var sources []FileSource
+	var uploaded []int // result index of each source
+	for _, i := range batch {
+		file := results[i].Source
+		switch {
+		case file.Size == 0:
+			results[i].Status = TransferEmpty
+		case file.Link == "":
+			results[i].Status = TransferFailed
+			results[i].Err = &StorageError{
+				Code:    ErrCodeInvalidInput,
+				Message: fmt.Sprintf("file %s has no download link yet", file.FullPath()),
+			}
+		default:
+			results[i].Status = TransferCopied
+			sources = append(sources, transferSource(ctx, file, results[i].DestinationPath))
+			uploaded = append(uploaded, i)
+		}
+	}
+	if len(sources) == 0 {
+		return
+	}
+
+	if _, err := UploadSessionSources(ctx, "/storage/buckets/"+dstBucketUuid+"/upload", sources); err != nil {
+		for _, i := range uploaded {
+			results[i].Status = TransferFailed
+			results[i].Err = err
+		}
+		return
+	}
+	if !move {
+		return
+	}
+
+	// Copies must have a CID before their sources go
+	paths := make([]string, len(uploaded))
+	for n, i := range uploaded {
+		paths[n] = results[i].DestinationPath
+	}
+	if _, err := waitForFiles(ctx, dstBucketUuid, list, paths, existing, 0); err != nil {
+		for _, i := range uploaded {
+			results[i].Err = err
+		}
+	}
+}
+
+// deleteSources deletes the source of each given result that was copied or already present at the destination
+// without an error, marking it as deleted.
+func deleteSources(ctx context.Context, srcBucketUuid string, results []TransferResult, indexes []int) {
+	for _, i := range indexes {
+		r := &results[i]
+		sameLocation := srcBucketUuid == r.DestinationBucket && r.Source.FullPath() == r.DestinationPath
+		if r.Err != nil || sameLocation || (r.Status != TransferCopied && r.Status != TransferUnchanged) {
+			continue
+		}
+		if _, err := DeleteFile(ctx, srcBucketUuid, r.Source.FileUUID); err != nil {
+			r.Err = err
+		} else {
+			r.Deleted = true
+		}
+	}
+}
+
+// holdsCID reports whether one of files has the given non-empty CID.
+func holdsCID(files []FileInfo, cid string) bool {
+	for _, file := range files {
+		if cid != "" && file.CID == cid {
+			return true
+		}
+	}
+	return false
+}
+
+// transferSource returns an upload of file to target that streams the file through its download link.
+func transferSource(ctx context.Context, file FileInfo, target string) FileSource {
+	metadata := FileMetadata{
+		FileName:    path.Base(target),
+		ContentType: file.ContentType,
+	}
+	if dir := path.Dir(target); dir != "." {
+		metadata.Path = dir + "/"
+	}
+	return FileSource{
+		Metadata: metadata,
+		Size:     int64(file.Size),
+		Open: func() (io.ReadCloser, error) {
+			return DownloadFile(ctx, file.Link)
+		},
+	}
+}
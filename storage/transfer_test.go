@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Apillon/go-sdk/internal/apitest"
+)
+
+func TestTransferTarget(t *testing.T) {
+	tests := []struct {
+		file, srcDir, dstDir string
+		want                 string
+		ok                   bool
+	}{
+		{"images/a.png", "images", "archive/images", "archive/images/a.png", true},
+		{"images/sub/a.png", "images/", "", "sub/a.png", true},
+		{"imagesx/a.png", "images", "archive", "", false},
+		{"a.png", "", "copy", "copy/a.png", true},
+		{"/images/a.png", "/images", "/archive", "archive/a.png", true},
+	}
+	for _, tt := range tests {
+		got, ok := transferTarget(tt.file, tt.srcDir, tt.dstDir)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("transferTarget(%q, %q, %q) = %q, %v; want %q, %v", tt.file, tt.srcDir, tt.dstDir, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCopyDirectory(t *testing.T) {
+	skipURLDelay(t)
+	server := apitest.NewServer(t)
+	server.AddFile("src", "a/1.txt", "one")
+	server.AddFile("src", "a/2.txt", "two")
+	server.AddFile("src", "a/empty.txt", "")
+	server.AddFile("src", "other.txt", "other")
+	server.AddFile("dst", "b/2.txt", "two")
+
+	results, err := CopyDirectory(context.Background(), "src", "a", "dst", "b")
+	if err != nil {
+		t.Fatalf("CopyDirectory failed: %v", err)
+	}
+	statuses := map[string]TransferStatus{}
+	for _, r := range results {
+		if r.Err != nil || r.Deleted {
+			t.Errorf("%s: unexpected result %+v", r.DestinationPath, r)
+		}
+		statuses[r.DestinationPath] = r.Status
+	}
+	want := map[string]TransferStatus{"b/1.txt": TransferCopied, "b/2.txt": TransferUnchanged, "b/empty.txt": TransferEmpty}
+	if len(statuses) != len(want) {
+		t.Errorf("got results for %v, want %v", statuses, want)
+	}
+	for p, status := range want {
+		if statuses[p] != status {
+			t.Errorf("%s: status %s, want %s", p, statuses[p], status)
+		}
+	}
+
+	if sessions := server.Count("POST /storage/buckets/dst/upload") - server.Count("POST /storage/buckets/dst/upload/"); sessions != 1 {
+		t.Errorf("copy used %d upload sessions, want 1", sessions)
+	}
+	// Only the changed file is streamed; the empty one is known from its size
+	if downloads := server.Count("GET /ipfs/"); downloads != 1 {
+		t.Errorf("copy downloaded %d files, want 1", downloads)
+	}
+	var got []string
+	for _, f := range server.Files("dst") {
+		got = append(got, f.Path()+"="+f.Content)
+	}
+	if strings.Join(got, ",") != "b/1.txt=one,b/2.txt=two" {
+		t.Errorf("destination holds %v", got)
+	}
+}
+
+func TestCopyFileWithLeadingSlash(t *testing.T) {
+	skipURLDelay(t)
+	server := apitest.NewServer(t)
+	src := server.AddFile("src", "a/2.txt", "two")
+	server.AddFile("dst", "b/2.txt", "two")
+
+	result, err := CopyFile(context.Background(), "src", src.UUID, "dst", "/b/2.txt")
+	if err != nil {
+		t.Fatalf("CopyFile failed: %v", err)
+	}
+	if result.Status != TransferUnchanged || result.DestinationPath != "b/2.txt" {
+		t.Errorf("got %s to %q, want %s to %q", result.Status, result.DestinationPath, TransferUnchanged, "b/2.txt")
+	}
+	if sessions := server.Count("POST /storage/buckets/dst/upload"); sessions != 0 {
+		t.Errorf("copy used %d upload sessions, want 0", sessions)
+	}
+}
+
+func TestMoveFileWaitsForDestinationCID(t *testing.T) {
+	skipURLDelay(t)
+	server := apitest.NewServer(t)
+	// Walking to the destination directory takes two listings, so the copy shows up on the second poll
+	server.ProcessAfter = 2
+	src := server.AddFile("src", "drafts/post.md", "new post")
+	server.AddFile("dst", "published/post.md", "old post")
+	server.AddFile("dst", "unrelated/big.bin", "data")
+
+	result, err := MoveFile(context.Background(), "src", src.UUID, "dst", "published/")
+	if err != nil {
+		t.Fatalf("MoveFile failed: %v", err)
+	}
+	if result.Status != TransferCopied || !result.Deleted || result.DestinationPath != "published/post.md" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if files := server.Files("src"); len(files) != 0 {
+		t.Errorf("source still holds %d files", len(files))
+	}
+	dst := server.Files("dst")
+	if len(dst) != 2 || dst[0].Content != "new post" || dst[0].CID == "" {
+		t.Errorf("destination was not replaced: %+v", dst[0])
+	}
+
+	// The destination is read through its directory, not by listing the whole bucket
+	if n := server.Count("GET /storage/buckets/dst/files"); n != 0 {
+		t.Errorf("destination bucket was listed %d times", n)
+	}
+	deletes := server.Count("DELETE /storage/buckets/src/files/")
+	if requests := server.Requests(); deletes != 1 || !strings.HasPrefix(requests[len(requests)-1], "DELETE ") {
+		t.Errorf("expected the source to be deleted last, got %v", requests)
+	}
+}
+
+func TestMoveDirectoryInBatches(t *testing.T) {
+	skipURLDelay(t)
+	server := apitest.NewServer(t)
+	n := uploadBatchSize + 10
+	for i := range n {
+		server.AddFile("src", fmt.Sprintf("a/%03d.txt", i), fmt.Sprintf("file %d", i))
+	}
+
+	results, err := MoveDirectory(context.Background(), "src", "a", "dst", "b")
+	if err != nil {
+		t.Fatalf("MoveDirectory failed: %v", err)
+	}
+	for _, r := range results {
+		if r.Err != nil || r.Status != TransferCopied || !r.Deleted {
+			t.Errorf("%s: unexpected result %+v", r.DestinationPath, r)
+		}
+	}
+	if len(server.Files("src")) != 0 || len(server.Files("dst")) != n {
+		t.Errorf("source holds %d and destination %d files", len(server.Files("src")), len(server.Files("dst")))
+	}
+
+	// The sources of the first batch are deleted after its session ends and before the second one starts
+	var steps []string
+	for _, r := range server.Received() {
+		switch {
+		case r.Method == "POST" && strings.HasSuffix(r.Path, "/end"):
+			steps = append(steps, "end")
+		case r.Method == "POST":
+			steps = append(steps, "start")
+		case r.Method == "DELETE" && (len(steps) == 0 || steps[len(steps)-1] != "delete"):
+			steps = append(steps, "delete")
+		}
+	}
+	if got := strings.Join(steps, ","); got != "start,end,delete,start,end,delete" {
+		t.Errorf("upload and delete steps %s", got)
+	}
+}
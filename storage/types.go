@@ -1,9 +1,12 @@
 package storage
 
+import "io"
+
 // FileMetadata represents metadata for a file, including its name and content type.
 type FileMetadata struct {
 	FileName    string `json:"fileName" validate:"required"` // Name of the file
 	ContentType string `json:"contentType"`                  // MIME type of the file
+	Path        string `json:"path,omitempty"`               // Directory path inside the bucket (e.g. "images/")
 }
 
 // WholeFile represents a file's content and its associated metadata.
@@ -12,6 +15,13 @@ type WholeFile struct {
 	Metadata FileMetadata `json:"metadata"` // Metadata about the file
 }
 
+// FileSource is a file uploaded with UploadSessionSources, read from Open only when it is uploaded.
+type FileSource struct {
+	Metadata FileMetadata                  // Metadata about the file
+	Size     int64                         // Content length in bytes; the source must yield exactly this many
+	Open     func() (io.ReadCloser, error) // Opens the content for reading
+}
+
 // FileItem represents a file entry, including its path, name, type, URL, and UUID.
 type FileItem struct {
 	Path        *string `json:"path"`        // Path to the file (nullable)
@@ -81,32 +91,58 @@ type FileListData = ListData[FileInfo]
 
 // Timestamps contains common timestamp fields for created and updated times.
 type Timestamps struct {
-	CreateTime string `json:"createTime"` // Creation timestamp (ISO8601 format)
-	UpdateTime string `json:"updateTime"` // Last update timestamp (ISO8601 format)
+	CreateTime Time `json:"createTime"` // Creation timestamp (null if unknown)
+	UpdateTime Time `json:"updateTime"` // Last update timestamp (null if unknown)
 }
 
 // FileInfo contains detailed information about a file.
 type FileInfo struct {
 	Timestamps
-	FileUUID      string  `json:"fileUuid"`                // Unique identifier for the file
-	CID           string  `json:"CID"`                     // Content Identifier (CID) for IPFS
-	Name          string  `json:"name"`                    // Name of the file
-	ContentType   string  `json:"contentType"`             // MIME type of the file
-	Path          *string `json:"path"`                    // Path to the file (nullable)
-	Size          int64   `json:"size"`                    // Size of the file in bytes
-	FileStatus    int     `json:"fileStatus"`              // Status code of the file
-	Link          string  `json:"link"`                    // URL or IPFS link to the file
-	DirectoryUUID *string `json:"directoryUuid,omitempty"` // UUID of the parent directory (nullable)
+	FileUUID      string   `json:"fileUuid"`                // Unique identifier for the file
+	CID           string   `json:"CID"`                     // Content Identifier (CID) for IPFS
+	Name          string   `json:"name"`                    // Name of the file
+	ContentType   string   `json:"contentType"`             // MIME type of the file
+	Path          *string  `json:"path"`                    // Path to the file (nullable)
+	Size          ByteSize `json:"size"`                    // Size of the file in bytes
+	FileStatus    int      `json:"fileStatus"`              // Status code of the file
+	Link          string   `json:"link"`                    // URL or IPFS link to the file
+	DirectoryUUID *string  `json:"directoryUuid,omitempty"` // UUID of the parent directory (nullable)
+}
+
+// ContentItemType distinguishes directories from files in bucket content listings.
+type ContentItemType int
+
+const (
+	ContentDirectory ContentItemType = 1 // Item is a directory
+	ContentFile      ContentItemType = 2 // Item is a file
+)
+
+// ContentItem is a directory or file in a bucket content listing.
+type ContentItem struct {
+	Timestamps
+	Type        ContentItemType `json:"type"`        // Whether the item is a directory or a file
+	UUID        string          `json:"uuid"`        // Directory or file UUID
+	Name        string          `json:"name"`        // Name of the directory or file
+	CID         string          `json:"CID"`         // Content Identifier (CID) once the content is on IPFS
+	ContentType string          `json:"contentType"` // MIME type (files only)
+	Size        ByteSize        `json:"size"`        // Size in bytes (files only)
+	Link        string          `json:"link"`        // URL or IPFS link to the item
 }
 
+// BucketContentData represents a paginated list of bucket content items.
+type BucketContentData = ListData[ContentItem]
+
+// ListBucketContentResponse represents a response containing the content of a bucket directory.
+type ListBucketContentResponse = APIResponse[BucketContentData]
+
 // BucketItem contains information about a storage bucket.
 type BucketItem struct {
 	Timestamps
-	BucketUUID  string `json:"bucketUuid"`  // Unique identifier for the bucket
-	BucketType  int    `json:"bucketType"`  // Type of the bucket
-	Name        string `json:"name"`        // Name of the bucket
-	Description string `json:"description"` // Description of the bucket
-	Size        int64  `json:"size"`        // Total size of the bucket in bytes
+	BucketUUID  string   `json:"bucketUuid"`  // Unique identifier for the bucket
+	BucketType  int      `json:"bucketType"`  // Type of the bucket
+	Name        string   `json:"name"`        // Name of the bucket
+	Description string   `json:"description"` // Description of the bucket
+	Size        ByteSize `json:"size"`        // Total size of the bucket in bytes
 }
 
 type startUploadRequest struct {
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimeUnmarshalJSON(t *testing.T) {
+	want := time.Date(2024, 3, 1, 10, 20, 30, 0, time.UTC)
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{`"2024-03-01T10:20:30.000Z"`, want},
+		{`"2024-03-01T10:20:30Z"`, want},
+		{`"2024-03-01T12:20:30+02:00"`, want},
+		{`"2024-03-01T10:20:30.000"`, want},
+		{`"2024-03-01 10:20:30"`, want},
+		{`null`, time.Time{}},
+		{`""`, time.Time{}},
+	}
+	for _, tt := range tests {
+		var got Time
+		if err := json.Unmarshal([]byte(tt.input), &got); err != nil {
+			t.Errorf("Unmarshal(%s) failed: %v", tt.input, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tt.input, got.Time, tt.want)
+		}
+	}
+
+	for _, input := range []string{`"yesterday"`, `1709288430`} {
+		var unknown Time
+		if err := json.Unmarshal([]byte(input), &unknown); err != nil || !unknown.IsZero() || unknown.Raw == "" {
+			t.Errorf("Unmarshal(%s) = %+v, %v; want the zero time with the raw value", input, unknown, err)
+		}
+	}
+}
+
+func TestListFilesResponseWithUnknownTimestamp(t *testing.T) {
+	data := `{"id":"1","status":200,"data":{"items":[{"fileUuid":"f1","name":"a.txt","createTime":"01/03/2024 10:20","updateTime":"2024-03-01T10:20:30Z"}],"total":1}}`
+
+	var res ListFilesResponse
+	if err := json.Unmarshal([]byte(data), &res); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	file := res.Data.Items[0]
+	if file.FileUUID != "f1" || !file.Created().IsZero() || file.CreateTime.Raw != "01/03/2024 10:20" || file.Updated().IsZero() {
+		t.Errorf("unexpected file %+v", file)
+	}
+
+	out, err := json.Marshal(file.Timestamps)
+	if err != nil || string(out) != `{"createTime":"01/03/2024 10:20","updateTime":"2024-03-01T10:20:30Z"}` {
+		t.Errorf("Marshal = %s, %v", out, err)
+	}
+}
+
+func TestFileInfoTimestamps(t *testing.T) {
+	var info FileInfo
+	data := `{"createTime":"2024-03-01T10:20:30.000Z","updateTime":null,"size":2048}`
+	if err := json.Unmarshal([]byte(data), &info); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if info.Created().Year() != 2024 || !info.Updated().IsZero() {
+		t.Errorf("unexpected timestamps: created %v, updated %v", info.Created(), info.Updated())
+	}
+	if info.Size.String() != "2.0 KiB" {
+		t.Errorf("Size = %s, want 2.0 KiB", info.Size)
+	}
+
+	out, err := json.Marshal(info.Timestamps)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(out) != `{"createTime":"2024-03-01T10:20:30Z","updateTime":null}` {
+		t.Errorf("Marshal = %s", out)
+	}
+}
+
+func TestByteSizeString(t *testing.T) {
+	tests := map[ByteSize]string{
+		0:                "0 B",
+		1023:             "1023 B",
+		1536:             "1.5 KiB",
+		1024*1024 - 1:    "1.0 MiB",
+		1024*1024 - 60:   "1023.9 KiB",
+		-(1024*1024 - 1): "-1.0 MiB",
+		5 * 1024 * 1024:  "5.0 MiB",
+		1 << 40:          "1.0 TiB",
+	}
+	for size, want := range tests {
+		if got := size.String(); got != want {
+			t.Errorf("ByteSize(%d) = %q, want %q", int64(size), got, want)
+		}
+	}
+}
+
+func TestSortFilesBy(t *testing.T) {
+	at := func(h int) Timestamps {
+		return Timestamps{CreateTime: Time{Time: time.Date(2024, 1, 1, h, 0, 0, 0, time.UTC)}}
+	}
+	files := []FileInfo{
+		{Name: "b", Size: 10, Timestamps: at(1)},
+		{Name: "a", Size: 10, Timestamps: at(3)},
+		{Name: "c", Size: 5, Timestamps: at(2)},
+	}
+
+	SortFilesBy(files, Descending(ByCreateTime))
+	if files[0].Name != "a" || files[2].Name != "b" {
+		t.Errorf("newest first order wrong: %s, %s, %s", files[0].Name, files[1].Name, files[2].Name)
+	}
+
+	SortFilesBy(files, BySize, ByName)
+	if files[0].Name != "c" || files[1].Name != "a" || files[2].Name != "b" {
+		t.Errorf("size then name order wrong: %s, %s, %s", files[0].Name, files[1].Name, files[2].Name)
+	}
+}
@@ -7,17 +7,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/Apillon/go-sdk/requests"
 )
 
-const (
-	defaultContentType = "text/plain"
-	urlReadyDelay      = 2 * time.Second
-)
+const defaultContentType = "text/plain"
+
+// urlReadyDelay is the wait before uploading to newly issued signed URLs.
+var urlReadyDelay = 2 * time.Second
 
 // StartUploadFilesToBucket initiates an upload session for a set of files in a given bucket.
 // It sends file metadata to the Apillon API and returns the raw API response or an error.
@@ -29,6 +31,11 @@ func StartUploadFilesToBucket(ctx context.Context, bucketUuid string, files []Fi
 		}
 	}
 
+	return startUploadSession(ctx, "/storage/buckets/"+bucketUuid+"/upload", files)
+}
+
+// startUploadSession starts an upload session on the given upload endpoint.
+func startUploadSession(ctx context.Context, uploadPath string, files []FileMetadata) (string, error) {
 	if len(files) == 0 {
 		return "", &StorageError{
 			Code:    ErrCodeInvalidInput,
@@ -59,8 +66,7 @@ func StartUploadFilesToBucket(ctx context.Context, bucketUuid string, files []Fi
 		}
 	}
 
-	path := "/storage/buckets/" + bucketUuid + "/upload"
-	res, err := requests.PostReq(ctx, path, strings.NewReader(string(bodyBytes)))
+	res, err := requests.PostReq(ctx, uploadPath, strings.NewReader(string(bodyBytes)))
 	if err != nil {
 		return "", &StorageError{
 			Code:    500,
@@ -75,6 +81,12 @@ func StartUploadFilesToBucket(ctx context.Context, bucketUuid string, files []Fi
 // UploadFiles uploads a file's raw content to a signed URL using HTTP PUT.
 // Returns a success message or an error if the upload fails.
 func UploadFiles(ctx context.Context, signedURL string, rawFile string) error {
+	return uploadToURL(ctx, signedURL, strings.NewReader(rawFile), int64(len(rawFile)))
+}
+
+// uploadToURL streams size bytes of body to a signed URL using HTTP PUT.
+// The length is sent up front, as signed URLs do not accept chunked uploads.
+func uploadToURL(ctx context.Context, signedURL string, body io.Reader, size int64) (err error) {
 	if signedURL == "" {
 		return &StorageError{
 			Code:    ErrCodeInvalidInput,
@@ -82,14 +94,23 @@ func UploadFiles(ctx context.Context, signedURL string, rawFile string) error {
 		}
 	}
 
-	if rawFile == "" {
+	if size <= 0 {
 		return &StorageError{
 			Code:    ErrCodeInvalidInput,
 			Message: "file content cannot be empty",
 		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, signedURL, strings.NewReader(rawFile))
+	var status int
+	ctx, end := requests.StartOperation(ctx, requests.Operation{
+		Name:   requests.OpUploadFile,
+		Method: http.MethodPut,
+		Path:   stripQuery(signedURL),
+		Bytes:  size,
+	})
+	defer func() { end(requests.Outcome{Status: status, Attempts: 1, Err: err}) }()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, signedURL, io.LimitReader(body, size))
 	if err != nil {
 		return &StorageError{
 			Code:    500,
@@ -97,9 +118,25 @@ func UploadFiles(ctx context.Context, signedURL string, rawFile string) error {
 			Err:     err,
 		}
 	}
+	req.ContentLength = size
 
 	client := &http.Client{}
+	start := time.Now()
 	resp, err := client.Do(req)
+	if logger := requests.Logger(); logger != nil {
+		attrs := []slog.Attr{slog.String("url", stripQuery(signedURL)), slog.Int64("size", size), slog.Duration("duration", time.Since(start))}
+		level := slog.LevelInfo
+		if err != nil {
+			level = slog.LevelWarn
+			attrs = append(attrs, slog.String("error", err.Error()))
+		} else {
+			if resp.StatusCode >= 300 {
+				level = slog.LevelWarn
+			}
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		}
+		logger.LogAttrs(ctx, level, "apillon signed URL upload", attrs...)
+	}
 	if err != nil {
 		return &StorageError{
 			Code:    500,
@@ -108,6 +145,7 @@ func UploadFiles(ctx context.Context, signedURL string, rawFile string) error {
 		}
 	}
 	defer resp.Body.Close()
+	status = resp.StatusCode
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
@@ -120,6 +158,18 @@ func UploadFiles(ctx context.Context, signedURL string, rawFile string) error {
 	return nil
 }
 
+// stripQuery removes the query, which holds the signature, from a signed URL so it can be logged.
+func stripQuery(signedURL string) string {
+	u, err := url.Parse(signedURL)
+	if err != nil {
+		return ""
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
+
 // EndSession finalizes an upload session for a given bucket and session ID.
 // Returns the API response or an error.
 func EndSession(ctx context.Context, bucketUuid string, sessionId string) (string, error) {
@@ -130,8 +180,12 @@ func EndSession(ctx context.Context, bucketUuid string, sessionId string) (strin
 		}
 	}
 
-	path := "/storage/buckets/" + bucketUuid + "/upload/" + sessionId + "/end"
-	res, err := requests.PostReq(ctx, path, nil)
+	return endUploadSession(ctx, "/storage/buckets/"+bucketUuid+"/upload", sessionId)
+}
+
+// endUploadSession ends an upload session on the given upload endpoint.
+func endUploadSession(ctx context.Context, uploadPath string, sessionId string) (string, error) {
+	res, err := requests.PostReq(ctx, uploadPath+"/"+sessionId+"/end", nil)
 	if err != nil {
 		return "", &StorageError{
 			Code:    500,
@@ -156,6 +210,31 @@ func UploadFileProcess(ctx context.Context, bucketUuid string, files []WholeFile
 		}
 	}
 
+	return UploadSessionProcess(ctx, "/storage/buckets/"+bucketUuid+"/upload", files)
+}
+
+// UploadSessionProcess runs the upload session flow of UploadFileProcess against any Apillon upload endpoint
+// that follows the same protocol, such as "/hosting/websites/{websiteUuid}/upload".
+// Returns the final API response or an error.
+func UploadSessionProcess(ctx context.Context, uploadPath string, files []WholeFile) (string, error) {
+	sources := make([]FileSource, len(files))
+	for i, file := range files {
+		content := file.Content
+		sources[i] = FileSource{
+			Metadata: file.Metadata,
+			Size:     int64(len(content)),
+			Open: func() (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader(content)), nil
+			},
+		}
+	}
+	return UploadSessionSources(ctx, uploadPath, sources)
+}
+
+// UploadSessionSources runs the upload session flow of UploadSessionProcess for files read from their
+// sources while uploading, so large files need not be held in memory.
+// Returns the final API response or an error.
+func UploadSessionSources(ctx context.Context, uploadPath string, files []FileSource) (_ string, err error) {
 	if len(files) == 0 {
 		return "", &StorageError{
 			Code:    ErrCodeInvalidInput,
@@ -165,18 +244,27 @@ func UploadFileProcess(ctx context.Context, bucketUuid string, files []WholeFile
 
 	// Extract only the metadata for the upload session initiation
 	onlyMetadata := make([]FileMetadata, len(files))
+	var total int64
 	for i, file := range files {
-		if file.Content == "" || file.Metadata.FileName == "" {
+		if file.Size <= 0 || file.Open == nil || file.Metadata.FileName == "" {
 			return "", &StorageError{
 				Code:    ErrCodeInvalidInput,
 				Message: fmt.Sprintf("file content or metadata is empty for file %s", file.Metadata.FileName),
 			}
 		}
 		onlyMetadata[i] = file.Metadata
+		total += file.Size
 	}
 
+	ctx, end := requests.StartOperation(ctx, requests.Operation{Name: requests.OpUpload, Path: uploadPath, Files: len(files), Bytes: total})
+	defer func() { end(requests.Outcome{Err: err}) }()
+
 	// Step 1: Start upload session and get signed URLs
-	res, err := StartUploadFilesToBucket(ctx, bucketUuid, onlyMetadata)
+	logger := requests.Logger()
+	start := time.Now()
+	phaseCtx, endPhase := requests.StartOperation(ctx, requests.Operation{Name: requests.OpUploadStartSession, Path: uploadPath, Files: len(files)})
+	res, err := startUploadSession(phaseCtx, uploadPath, onlyMetadata)
+	endPhase(requests.Outcome{Err: err})
 	if err != nil {
 		return "", fmt.Errorf("failed to start upload session: %w", err)
 	}
@@ -214,21 +302,58 @@ func UploadFileProcess(ctx context.Context, bucketUuid string, files []WholeFile
 		}
 	}
 
+	if logger != nil {
+		logger.LogAttrs(ctx, slog.LevelInfo, "apillon upload session started",
+			slog.String("path", uploadPath), slog.String("sessionUuid", apiResp.Data.SessionUUID),
+			slog.Int("files", len(files)), slog.Duration("duration", time.Since(start)))
+	}
+
 	// Wait for the URLs to be ready
 	time.Sleep(urlReadyDelay)
 
 	// Step 2: Upload each file to its signed URL
+	uploadStart := time.Now()
+	var uploaded int64
 	for i, file := range files {
-		if err := UploadFiles(ctx, urls[i], file.Content); err != nil {
+		if err := uploadSource(ctx, urls[i], file); err != nil {
 			return "", fmt.Errorf("failed to upload file %s: %w", file.Metadata.FileName, err)
 		}
+		uploaded += file.Size
+	}
+	if logger != nil {
+		logger.LogAttrs(ctx, slog.LevelInfo, "apillon upload session files uploaded",
+			slog.String("sessionUuid", apiResp.Data.SessionUUID), slog.Int("files", len(files)),
+			slog.Int64("bytes", uploaded), slog.Duration("duration", time.Since(uploadStart)))
 	}
 
 	// Step 3: End the upload session
-	res, err = EndSession(ctx, bucketUuid, apiResp.Data.SessionUUID)
+	endStart := time.Now()
+	phaseCtx, endPhase = requests.StartOperation(ctx, requests.Operation{Name: requests.OpUploadEndSession, Path: uploadPath, Files: len(files)})
+	res, err = endUploadSession(phaseCtx, uploadPath, apiResp.Data.SessionUUID)
+	endPhase(requests.Outcome{Err: err})
 	if err != nil {
 		return "", fmt.Errorf("failed to end upload session: %w", err)
 	}
+	if logger != nil {
+		logger.LogAttrs(ctx, slog.LevelInfo, "apillon upload session ended",
+			slog.String("sessionUuid", apiResp.Data.SessionUUID), slog.Duration("duration", time.Since(endStart)),
+			slog.Duration("total", time.Since(start)))
+	}
 
 	return res, nil
 }
+
+// uploadSource opens a file source and streams it to its signed URL.
+func uploadSource(ctx context.Context, signedURL string, file FileSource) error {
+	body, err := file.Open()
+	if err != nil {
+		return &StorageError{
+			Code:    500,
+			Message: "failed to open file",
+			Err:     err,
+		}
+	}
+	defer body.Close()
+
+	return uploadToURL(ctx, signedURL, body, file.Size)
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Apillon/go-sdk/internal/apitest"
+	"github.com/Apillon/go-sdk/requests"
+)
+
+func TestUploadFilesLogsWithoutSignature(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	requests.SetLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+	defer requests.SetLogger(nil)
+
+	if err := UploadFiles(context.Background(), server.URL+"/bucket/a.txt?X-Amz-Signature=abc", "hello"); err != nil {
+		t.Fatal(err)
+	}
+	out := logs.String()
+	if !strings.Contains(out, "url="+server.URL+"/bucket/a.txt") || !strings.Contains(out, "status=200") || strings.Contains(out, "X-Amz-Signature") {
+		t.Errorf("unexpected log output %q", out)
+	}
+}
+
+func TestUploadSessionSources(t *testing.T) {
+	skipURLDelay(t)
+	server := apitest.NewServer(t)
+	opened := 0
+	source := func(content string) FileSource {
+		return FileSource{
+			Metadata: FileMetadata{FileName: content + ".txt"},
+			Size:     int64(len(content)),
+			Open: func() (io.ReadCloser, error) {
+				opened++
+				return io.NopCloser(strings.NewReader(content)), nil
+			},
+		}
+	}
+	if _, err := UploadSessionSources(context.Background(), "/storage/buckets/b1/upload", []FileSource{source("one"), source("three")}); err != nil {
+		t.Fatalf("UploadSessionSources failed: %v", err)
+	}
+	if opened != 2 {
+		t.Errorf("opened %d sources, want 2", opened)
+	}
+	var got []string
+	for _, f := range server.Files("b1") {
+		got = append(got, f.Path()+"="+f.Content)
+	}
+	if strings.Join(got, ",") != "one.txt=one,three.txt=three" {
+		t.Errorf("uploaded %v", got)
+	}
+
+	// A source shorter than its size fails instead of uploading a truncated file
+	short := source("two")
+	short.Size = 10
+	if _, err := UploadSessionSources(context.Background(), "/storage/buckets/b1/upload", []FileSource{short}); err == nil {
+		t.Error("expected an error for a source shorter than its size")
+	}
+}
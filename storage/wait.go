@@ -0,0 +1,214 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// defaultWaitInterval is the delay between checks when no poll interval is given.
+const defaultWaitInterval = 3 * time.Second
+
+// FileSnapshot records the files found at some paths before an upload, keyed by full path
+// (see FileInfo.FullPath), so that WaitForFiles can tell uploaded files from the ones they replace.
+type FileSnapshot map[string][]FileInfo
+
+// SnapshotFiles lists the files currently at the given full paths of a bucket.
+// Take the snapshot before starting the upload session and pass it to WaitForFiles.
+// Returns the snapshot, or an error if listing fails.
+func SnapshotFiles(ctx context.Context, bucketUuid string, paths []string) (FileSnapshot, error) {
+	files, err := ListAllFilesInBucket(ctx, bucketUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		wanted[p] = true
+	}
+	snapshot := FileSnapshot{}
+	for _, file := range files {
+		if p := file.FullPath(); wanted[p] {
+			snapshot[p] = append(snapshot[p], file)
+		}
+	}
+	return snapshot, nil
+}
+
+// SnapshotUpload is SnapshotFiles for the paths of files about to be uploaded. A path whose current
+// file already has the content being uploaded is left out of the snapshot, since the upload may not
+// change that file, so WaitForFiles accepts it as it is.
+// Returns the snapshot, or an error if listing or downloading a file to compare fails.
+func SnapshotUpload(ctx context.Context, bucketUuid string, files []WholeFile) (FileSnapshot, error) {
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = file.Metadata.Path + file.Metadata.FileName
+	}
+	snapshot, err := SnapshotFiles(ctx, bucketUuid, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, file := range files {
+		for _, old := range snapshot[paths[i]] {
+			same, err := hasContent(ctx, old, file.Content)
+			if err != nil {
+				return nil, err
+			}
+			if same {
+				delete(snapshot, paths[i])
+				break
+			}
+		}
+	}
+	return snapshot, nil
+}
+
+// hasContent reports whether a processed file holds exactly content, downloading it only if the sizes match.
+func hasContent(ctx context.Context, file FileInfo, content string) (bool, error) {
+	if file.CID == "" || file.Link == "" || int64(file.Size) != int64(len(content)) {
+		return false, nil
+	}
+
+	body, err := DownloadFile(ctx, file.Link)
+	if err != nil {
+		return false, err
+	}
+	defer body.Close()
+
+	stored, err := io.ReadAll(body)
+	if err != nil {
+		return false, &StorageError{
+			Code:    500,
+			Message: fmt.Sprintf("failed to read content of file %s", file.FullPath()),
+			Err:     err,
+		}
+	}
+	return bytes.Equal(stored, []byte(content)), nil
+}
+
+// replaces reports whether file is not one of the entries recorded at its path: a new entry,
+// or a recorded entry whose CID or update time has changed since.
+func (s FileSnapshot) replaces(file FileInfo) bool {
+	for _, old := range s[file.FullPath()] {
+		if old.FileUUID != file.FileUUID {
+			continue
+		}
+		if old.CID != "" && old.CID != file.CID {
+			return true
+		}
+		return file.UpdateTime.After(old.UpdateTime.Time)
+	}
+	return true
+}
+
+// WaitForFiles polls a bucket until every file at the given full paths (see FileInfo.FullPath) has a CID,
+// which happens some time after an upload session ends. Files recorded in before, taken with
+// SnapshotFiles or SnapshotUpload ahead of the upload, are ignored unless they have been updated since,
+// so a file replaced at the same path is not mistaken for the upload. A nil snapshot accepts any file.
+// Returns the files keyed by path, or an error if listing fails or ctx is done first.
+func WaitForFiles(ctx context.Context, bucketUuid string, paths []string, before FileSnapshot, interval time.Duration) (map[string]FileInfo, error) {
+	list := func(ctx context.Context) ([]FileInfo, error) {
+		return ListAllFilesInBucket(ctx, bucketUuid)
+	}
+	return waitForFiles(ctx, bucketUuid, list, paths, before, interval)
+}
+
+// UploadAndWait uploads files to a bucket in one session and waits until each of them has a CID.
+// A file already in the bucket with the same path and content counts as uploaded.
+// Returns the files keyed by full path, or an error if the upload fails or ctx is done before every file has a CID.
+func UploadAndWait(ctx context.Context, bucketUuid string, files []WholeFile, interval time.Duration) (map[string]FileInfo, error) {
+	before, err := SnapshotUpload(ctx, bucketUuid, files)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := UploadFileProcess(ctx, bucketUuid, files); err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = file.Metadata.Path + file.Metadata.FileName
+	}
+	return WaitForFiles(ctx, bucketUuid, paths, before, interval)
+}
+
+// waitForFiles implements WaitForFiles, reading the files of the bucket with list.
+func waitForFiles(ctx context.Context, bucketUuid string, list func(context.Context) ([]FileInfo, error), paths []string, before FileSnapshot, interval time.Duration) (map[string]FileInfo, error) {
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+
+	wanted := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		wanted[p] = true
+	}
+
+	for {
+		files, err := list(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		found := make(map[string]FileInfo, len(paths))
+		for _, file := range files {
+			if p := file.FullPath(); wanted[p] && file.CID != "" && before.replaces(file) {
+				found[p] = file
+			}
+		}
+		if len(found) == len(wanted) {
+			return found, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return found, fmt.Errorf("waiting for %d of %d files in bucket %s: %w", len(wanted)-len(found), len(wanted), bucketUuid, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// FindDirectory returns the directory with the given name at the root of a bucket.
+// The bool is false if there is no such directory.
+// Returns an error if listing fails.
+func FindDirectory(ctx context.Context, bucketUuid string, name string) (ContentItem, bool, error) {
+	content, err := ListBucketContent(ctx, bucketUuid, "")
+	if err != nil {
+		return ContentItem{}, false, err
+	}
+
+	for _, item := range content.Data.Items {
+		if item.Type == ContentDirectory && item.Name == name {
+			return item, true, nil
+		}
+	}
+	return ContentItem{}, false, nil
+}
+
+// WaitForDirectoryCID polls the root of a bucket until the directory with the given name has a CID
+// other than previousCID. Pass the CID the directory had before the upload (see FindDirectory), or ""
+// to accept any CID, e.g. when the upload did not change the directory content.
+// Returns the directory item, or an error if listing fails or ctx is done first.
+func WaitForDirectoryCID(ctx context.Context, bucketUuid string, name string, previousCID string, interval time.Duration) (ContentItem, error) {
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+
+	for {
+		dir, ok, err := FindDirectory(ctx, bucketUuid, name)
+		if err != nil {
+			return ContentItem{}, err
+		}
+		if ok && dir.CID != "" && dir.CID != previousCID {
+			return dir, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ContentItem{}, fmt.Errorf("waiting for directory %s in bucket %s: %w", name, bucketUuid, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
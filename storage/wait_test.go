@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Apillon/go-sdk/internal/apitest"
+)
+
+func TestWaitForFilesIgnoresReplacedFile(t *testing.T) {
+	skipURLDelay(t)
+	server := apitest.NewServer(t)
+	server.ProcessAfter = 2
+	server.AddFile("b1", "site/index.html", "old")
+	ctx := context.Background()
+	paths := []string{"site/index.html", "site/new.txt"}
+
+	before, err := SnapshotFiles(ctx, "b1", paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := []WholeFile{
+		{Content: "new", Metadata: FileMetadata{FileName: "index.html", Path: "site/"}},
+		{Content: "added", Metadata: FileMetadata{FileName: "new.txt", Path: "site/"}},
+	}
+	if _, err := UploadFileProcess(ctx, "b1", files); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := WaitForFiles(ctx, "b1", paths, before, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["site/index.html"].CID != apitest.CID("new") || got["site/new.txt"].CID != apitest.CID("added") {
+		t.Errorf("expected the uploaded files, got %+v", got)
+	}
+	if server.Count("GET /storage/buckets/b1/files") < 3 {
+		t.Error("expected WaitForFiles to wait for the replaced file to be processed")
+	}
+}
+
+func TestWaitForFilesAcceptsIdenticalFile(t *testing.T) {
+	skipURLDelay(t)
+	server := apitest.NewServer(t)
+	server.AddFile("b1", "site/index.html", "same")
+	server.AddFile("b1", "site/app.js", "old")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	files := []WholeFile{
+		{Content: "same", Metadata: FileMetadata{FileName: "index.html", Path: "site/"}},
+		{Content: "new", Metadata: FileMetadata{FileName: "app.js", Path: "site/"}},
+	}
+
+	before, err := SnapshotUpload(ctx, "b1", files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := before["site/index.html"]; ok || len(before["site/app.js"]) != 1 {
+		t.Fatalf("expected only the changed file in the snapshot, got %+v", before)
+	}
+	if _, err := UploadFileProcess(ctx, "b1", files); err != nil {
+		t.Fatal(err)
+	}
+
+	// The identical upload leaves index.html as it was
+	got, err := WaitForFiles(ctx, "b1", []string{"site/index.html", "site/app.js"}, before, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["site/index.html"].CID != apitest.CID("same") || got["site/app.js"].CID != apitest.CID("new") {
+		t.Errorf("unexpected files %+v", got)
+	}
+}
+
+func TestUploadAndWait(t *testing.T) {
+	skipURLDelay(t)
+	server := apitest.NewServer(t)
+	server.ProcessAfter = 1
+	existing := server.AddFile("b1", "jobs/run.js", "main()")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	files := []WholeFile{{Content: "main()", Metadata: FileMetadata{FileName: "run.js", Path: "jobs/"}}}
+	got, err := UploadAndWait(ctx, "b1", files, time.Millisecond)
+	if err != nil {
+		t.Fatalf("UploadAndWait with identical content failed: %v", err)
+	}
+	if got["jobs/run.js"].FileUUID != existing.UUID {
+		t.Errorf("expected the file already in the bucket, got %+v", got)
+	}
+
+	files[0].Content = "main(2)"
+	got, err = UploadAndWait(ctx, "b1", files, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["jobs/run.js"].CID != apitest.CID("main(2)") {
+		t.Errorf("expected the uploaded file, got %+v", got)
+	}
+}
+
+func TestWaitForDirectoryCID(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.AddFile("b1", "metadata/1.json", "old")
+	ctx := context.Background()
+
+	dir, ok, err := FindDirectory(ctx, "b1", "metadata")
+	if err != nil || !ok || dir.CID == "" {
+		t.Fatalf("FindDirectory = %+v, %v, %v", dir, ok, err)
+	}
+
+	type result struct {
+		dir ContentItem
+		err error
+	}
+	done := make(chan result)
+	go func() {
+		dir, err := WaitForDirectoryCID(ctx, "b1", "metadata", dir.CID, time.Millisecond)
+		done <- result{dir, err}
+	}()
+
+	// The unchanged directory is ignored until its content changes
+	for server.Count("GET /storage/buckets/b1/content") < 3 {
+		time.Sleep(time.Millisecond)
+	}
+	server.AddFile("b1", "metadata/2.json", "new")
+	got := <-done
+	if got.err != nil || got.dir.CID == "" || got.dir.CID == dir.CID {
+		t.Errorf("WaitForDirectoryCID = %+v, %v", got.dir, got.err)
+	}
+}
+
+// skipURLDelay removes the wait for signed URLs for the duration of a test.
+func skipURLDelay(t *testing.T) {
+	delay := urlReadyDelay
+	urlReadyDelay = 0
+	t.Cleanup(func() { urlReadyDelay = delay })
+}
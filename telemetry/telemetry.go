@@ -0,0 +1,222 @@
+// Package telemetry instruments the SDK with OpenTelemetry traces and metrics.
+//
+// Instrumentation is opt-in. Enable it once at startup:
+//
+//	if err := telemetry.Enable(telemetry.Options{}); err != nil {
+//		// handle error
+//	}
+//
+// Every Apillon API request then gets a client span, and upload sessions such as
+// storage.UploadFileProcess get a span with a child span for each phase: starting the session,
+// uploading each file to its signed URL and ending the session. The trace context is propagated to
+// the API in the request headers.
+//
+// The following metrics are recorded:
+//
+//   - apillon.client.requests: HTTP requests made, including signed URL uploads
+//   - apillon.client.operation.duration: duration of requests and upload phases, in seconds
+//   - apillon.client.upload.size: bytes uploaded to signed URLs
+//   - apillon.client.retries: API request attempts that were retried
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Apillon/go-sdk/requests"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter.
+const ScopeName = "github.com/Apillon/go-sdk/telemetry"
+
+// Attribute keys specific to the SDK.
+const (
+	OperationKey   = attribute.Key("apillon.operation")
+	AttemptsKey    = attribute.Key("apillon.attempts")
+	UploadFilesKey = attribute.Key("apillon.upload.files")
+	UploadSizeKey  = attribute.Key("apillon.upload.size")
+)
+
+// TelemetryError represents an error that occurred while setting up instrumentation
+type TelemetryError struct {
+	Code    int
+	Message string
+	Err     error
+}
+
+func (e *TelemetryError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("telemetry error (code %d): %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("telemetry error (code %d): %s", e.Code, e.Message)
+}
+
+func (e *TelemetryError) Unwrap() error {
+	return e.Err
+}
+
+// Options configures the instrumentation. Zero values use the global OpenTelemetry providers.
+type Options struct {
+	TracerProvider trace.TracerProvider          // Defaults to otel.GetTracerProvider()
+	MeterProvider  metric.MeterProvider          // Defaults to otel.GetMeterProvider()
+	Propagator     propagation.TextMapPropagator // Defaults to otel.GetTextMapPropagator()
+}
+
+// Instrumenter records SDK operations as OpenTelemetry spans and metrics.
+// It implements requests.Instrumenter.
+type Instrumenter struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+
+	requests metric.Int64Counter
+	duration metric.Float64Histogram
+	uploaded metric.Int64Counter
+	retries  metric.Int64Counter
+}
+
+// New creates an Instrumenter. Most applications use Enable instead.
+func New(opts Options) (*Instrumenter, error) {
+	if opts.TracerProvider == nil {
+		opts.TracerProvider = otel.GetTracerProvider()
+	}
+	if opts.MeterProvider == nil {
+		opts.MeterProvider = otel.GetMeterProvider()
+	}
+	if opts.Propagator == nil {
+		opts.Propagator = otel.GetTextMapPropagator()
+	}
+
+	meter := opts.MeterProvider.Meter(ScopeName)
+	requestsCounter, err1 := meter.Int64Counter("apillon.client.requests",
+		metric.WithDescription("HTTP requests made, including signed URL uploads"), metric.WithUnit("{request}"))
+	duration, err2 := meter.Float64Histogram("apillon.client.operation.duration",
+		metric.WithDescription("Duration of API requests and upload phases"), metric.WithUnit("s"))
+	uploaded, err3 := meter.Int64Counter("apillon.client.upload.size",
+		metric.WithDescription("Bytes uploaded to signed URLs"), metric.WithUnit("By"))
+	retries, err4 := meter.Int64Counter("apillon.client.retries",
+		metric.WithDescription("API request attempts that were retried"), metric.WithUnit("{retry}"))
+	if err := errors.Join(err1, err2, err3, err4); err != nil {
+		return nil, &TelemetryError{
+			Code:    500,
+			Message: "failed to create metric instruments",
+			Err:     err,
+		}
+	}
+
+	return &Instrumenter{
+		tracer:     opts.TracerProvider.Tracer(ScopeName),
+		propagator: opts.Propagator,
+		requests:   requestsCounter,
+		duration:   duration,
+		uploaded:   uploaded,
+		retries:    retries,
+	}, nil
+}
+
+// Enable instruments every SDK operation, replacing any instrumenter set before.
+func Enable(opts Options) error {
+	i, err := New(opts)
+	if err != nil {
+		return err
+	}
+	requests.SetInstrumenter(i)
+	return nil
+}
+
+// Disable removes the instrumentation.
+func Disable() {
+	requests.SetInstrumenter(nil)
+}
+
+type startKey struct{}
+
+// Start starts a span for the operation.
+func (i *Instrumenter) Start(ctx context.Context, op requests.Operation) context.Context {
+	kind := trace.SpanKindInternal
+	if isHTTP(op) {
+		kind = trace.SpanKindClient
+	}
+
+	attrs := []attribute.KeyValue{OperationKey.String(op.Name)}
+	if op.Method != "" {
+		attrs = append(attrs, semconv.HTTPRequestMethodKey.String(op.Method))
+	}
+	switch {
+	case op.Name == requests.OpUploadFile:
+		attrs = append(attrs, semconv.URLFullKey.String(op.Path))
+	case op.Path != "":
+		attrs = append(attrs, semconv.URLPathKey.String(op.Path))
+	}
+	if op.Files > 0 {
+		attrs = append(attrs, UploadFilesKey.Int(op.Files))
+	}
+	if op.Bytes > 0 {
+		attrs = append(attrs, UploadSizeKey.Int64(op.Bytes))
+	}
+
+	ctx, _ = i.tracer.Start(ctx, op.Name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+	return context.WithValue(ctx, startKey{}, time.Now())
+}
+
+// End ends the span of the operation and records its metrics.
+func (i *Instrumenter) End(ctx context.Context, op requests.Operation, out requests.Outcome) {
+	span := trace.SpanFromContext(ctx)
+
+	attrs := []attribute.KeyValue{OperationKey.String(op.Name)}
+	if op.Method != "" {
+		attrs = append(attrs, semconv.HTTPRequestMethodKey.String(op.Method))
+	}
+	if out.Status > 0 {
+		attrs = append(attrs, semconv.HTTPResponseStatusCodeKey.Int(out.Status))
+	}
+	if out.Err != nil {
+		errorType := "_OTHER"
+		if out.Status >= 400 {
+			errorType = strconv.Itoa(out.Status)
+		}
+		attrs = append(attrs, semconv.ErrorTypeKey.String(errorType))
+		span.RecordError(out.Err)
+		span.SetStatus(codes.Error, out.Err.Error())
+	}
+	span.SetAttributes(attrs[1:]...)
+	if out.Attempts > 1 {
+		span.SetAttributes(AttemptsKey.Int(out.Attempts))
+	}
+	span.End()
+
+	set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+	if start, ok := ctx.Value(startKey{}).(time.Time); ok {
+		i.duration.Record(ctx, time.Since(start).Seconds(), set)
+	}
+	if isHTTP(op) {
+		i.requests.Add(ctx, 1, set)
+	}
+	if op.Name == requests.OpUploadFile && out.Err == nil {
+		i.uploaded.Add(ctx, op.Bytes, set)
+	}
+	if out.Attempts > 1 {
+		i.retries.Add(ctx, int64(out.Attempts-1), set)
+	}
+}
+
+// Inject adds the trace context to the headers of an API request.
+func (i *Instrumenter) Inject(ctx context.Context, header http.Header) {
+	i.propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// isHTTP reports whether the operation is a single HTTP request.
+func isHTTP(op requests.Operation) bool {
+	return op.Name == requests.OpRequest || op.Name == requests.OpUploadFile
+}
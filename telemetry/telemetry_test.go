@@ -0,0 +1,174 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/Apillon/go-sdk/credentials"
+	"github.com/Apillon/go-sdk/requests"
+	"github.com/Apillon/go-sdk/storage"
+)
+
+// setup enables instrumentation with in-memory exporters.
+func setup(t *testing.T) (*tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	t.Helper()
+	spans := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	err := Enable(Options{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		Propagator:     propagation.TraceContext{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(Disable)
+	return spans, reader
+}
+
+// collect returns the sum of each counter and the number of histogram records.
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	values := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					values[m.Name] += dp.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					values[m.Name] += int64(dp.Count)
+				}
+			}
+		}
+	}
+	return values
+}
+
+func TestUploadSpansAndMetrics(t *testing.T) {
+	spans, reader := setup(t)
+
+	var mu sync.Mutex
+	traceparents := map[string]string{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		traceparents[r.Method+" "+r.URL.Path] = r.Header.Get("traceparent")
+		mu.Unlock()
+		switch {
+		case r.Method == http.MethodPut:
+			io.Copy(io.Discard, r.Body)
+		case strings.HasSuffix(r.URL.Path, "/end"):
+			fmt.Fprint(w, `{"data":true}`)
+		default:
+			fmt.Fprintf(w, `{"data":{"sessionUuid":"s1","files":[{"url":"%s/signed/a.txt?X-Amz-Signature=x"}]}}`, server.URL)
+		}
+	}))
+	defer server.Close()
+	requests.SetCredentials(credentials.Credentials{APIKey: "test", BaseURL: server.URL})
+	defer requests.SetCredentials(credentials.Credentials{})
+
+	files := []storage.WholeFile{{Metadata: storage.FileMetadata{FileName: "a.txt"}, Content: "hello"}}
+	if _, err := storage.UploadSessionProcess(context.Background(), "/storage/buckets/b1/upload", files); err != nil {
+		t.Fatal(err)
+	}
+
+	byName := map[string]sdktrace.ReadOnlySpan{}
+	for _, s := range spans.GetSpans().Snapshots() {
+		byName[s.Name()] = s
+	}
+	upload := byName[requests.OpUpload]
+	if upload == nil || len(byName) != 5 {
+		t.Fatalf("unexpected spans %v", byName)
+	}
+	for child, parent := range map[string]string{
+		requests.OpUploadStartSession: requests.OpUpload,
+		requests.OpUploadFile:         requests.OpUpload,
+		requests.OpUploadEndSession:   requests.OpUpload,
+	} {
+		if byName[child].Parent().SpanID() != byName[parent].SpanContext().SpanID() {
+			t.Errorf("%s is not a child of %s", child, parent)
+		}
+	}
+
+	put := byName[requests.OpUploadFile]
+	if !hasAttribute(put.Attributes(), attribute.String("url.full", server.URL+"/signed/a.txt")) ||
+		!hasAttribute(put.Attributes(), attribute.Int("http.response.status_code", 200)) {
+		t.Errorf("unexpected upload attributes %v", put.Attributes())
+	}
+
+	// Start and end session requests both propagate the trace of the upload
+	traceID := upload.SpanContext().TraceID().String()
+	for _, req := range []string{"POST /storage/buckets/b1/upload", "POST /storage/buckets/b1/upload/s1/end"} {
+		if !strings.Contains(traceparents[req], traceID) {
+			t.Errorf("%s traceparent %q does not carry trace %s", req, traceparents[req], traceID)
+		}
+	}
+	if traceparents["PUT /signed/a.txt"] != "" {
+		t.Error("trace context should not be sent to signed URLs")
+	}
+
+	values := collect(t, reader)
+	if values["apillon.client.requests"] != 3 || values["apillon.client.upload.size"] != 5 ||
+		values["apillon.client.operation.duration"] != 6 || values["apillon.client.retries"] != 0 {
+		t.Errorf("unexpected metrics %v", values)
+	}
+}
+
+func TestErrorsAndRetries(t *testing.T) {
+	spans, reader := setup(t)
+
+	ctx, end := requests.StartOperation(context.Background(), requests.Operation{Name: requests.OpRequest, Method: http.MethodGet, Path: "/project/info"})
+	if ctx == context.Background() {
+		t.Fatal("expected a span context")
+	}
+	end(requests.Outcome{Status: 503, Attempts: 3, Err: errors.New("unavailable")})
+
+	got := spans.GetSpans().Snapshots()
+	if len(got) != 1 || got[0].Status().Code != codes.Error ||
+		!hasAttribute(got[0].Attributes(), attribute.String("error.type", "503")) ||
+		!hasAttribute(got[0].Attributes(), AttemptsKey.Int(3)) {
+		t.Fatalf("unexpected spans %v", got)
+	}
+	if values := collect(t, reader); values["apillon.client.retries"] != 2 || values["apillon.client.requests"] != 1 {
+		t.Errorf("unexpected metrics %v", values)
+	}
+
+	Disable()
+	if ctx, _ := requests.StartOperation(context.Background(), requests.Operation{Name: requests.OpRequest}); ctx != context.Background() {
+		t.Error("expected no instrumentation after Disable")
+	}
+	if _, err := New(Options{}); err != nil {
+		t.Errorf("New with the global providers failed: %v", err)
+	}
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, a := range attrs {
+		if a == want {
+			return true
+		}
+	}
+	return false
+}